@@ -2,13 +2,17 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
 
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestDatabaseSecretEngineConfigGetPath(t *testing.T) {
@@ -864,3 +868,313 @@ func TestDatabaseSecretEngineConfig_PrepareInternalValues(t *testing.T) {
 		})
 	}
 }
+
+// databaseVerificationHandler serves the credentials of the roles in creds with a lease, and fails the paths in failures with a Vault error.
+type databaseVerificationHandler struct {
+	*recordingVaultHandler
+	creds    map[string]string
+	failures map[string]string
+}
+
+func (h *databaseVerificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path[len("/v1/"):]
+	if message, ok := h.failures[path]; ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{message}}) // test handler; encode error is not actionable
+		return
+	}
+	if leaseID, ok := h.creds[path]; ok && r.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"lease_id": leaseID, "data": map[string]any{"username": "v-test", "password": "secret"}}) // test handler; encode error is not actionable
+		return
+	}
+	h.recordingVaultHandler.ServeHTTP(w, r)
+}
+
+func TestDatabaseSecretEngineConfigEnrichStatus(t *testing.T) {
+	handler := newFakeVaultHandler()
+	handler.setGet("database/config/db", map[string]any{"plugin_name": "postgresql-database-plugin", "plugin_version": "v1.0.0+builtin"})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	config := &DatabaseSecretEngineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "db"},
+		Spec:       DatabaseSecretEngineConfigSpec{Path: "database"},
+	}
+	if err := config.EnrichStatus(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Status.PluginName != "postgresql-database-plugin" || config.Status.PluginVersion != "v1.0.0+builtin" {
+		t.Errorf("unexpected plugin %q %q", config.Status.PluginName, config.Status.PluginVersion)
+	}
+}
+
+func TestDatabaseSecretEngineConfigVerification(t *testing.T) {
+	tests := []struct {
+		name         string
+		verification *DBSEConnectionVerification
+		failures     map[string]string
+		status       metav1.ConditionStatus
+		reason       string
+		message      string
+		revoked      bool
+	}{
+		{
+			name: "no verification",
+		},
+		{
+			name:         "reset and credentials",
+			verification: &DBSEConnectionVerification{Reset: true, Role: "readonly"},
+			status:       metav1.ConditionTrue,
+			reason:       ConnectionVerifiedReason,
+			message:      "connection reset, credentials generated with role readonly and revoked",
+			revoked:      true,
+		},
+		{
+			name:         "reset failure",
+			verification: &DBSEConnectionVerification{Reset: true, Role: "readonly"},
+			failures:     map[string]string{"database/reset/db": "error verifying connection: pq: password authentication failed for user \"vault\""},
+			status:       metav1.ConditionFalse,
+			reason:       ConnectionVerificationFailedReason,
+			message:      "unable to reset the connection: error verifying connection: pq: password authentication failed for user \"vault\"",
+		},
+		{
+			name:         "credentials failure",
+			verification: &DBSEConnectionVerification{Role: "readonly"},
+			failures:     map[string]string{"database/creds/readonly": "pq: permission denied to create role"},
+			status:       metav1.ConditionFalse,
+			reason:       ConnectionVerificationFailedReason,
+			message:      "unable to generate credentials with role readonly: pq: permission denied to create role",
+		},
+		{
+			name:         "revocation failure",
+			verification: &DBSEConnectionVerification{Role: "readonly"},
+			failures:     map[string]string{"sys/leases/revoke": "pq: role \"v-test\" cannot be dropped"},
+			status:       metav1.ConditionFalse,
+			reason:       ConnectionVerificationFailedReason,
+			message:      "unable to revoke the credentials generated with role readonly: pq: role \"v-test\" cannot be dropped",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &databaseVerificationHandler{
+				recordingVaultHandler: &recordingVaultHandler{fakeVaultHandler: newFakeVaultHandler()},
+				creds:                 map[string]string{"database/creds/readonly": "database/creds/readonly/abc"},
+				failures:              tt.failures,
+			}
+			handler.setGet("database/reset/db", map[string]any{})
+			handler.setGet("sys/leases/revoke", map[string]any{})
+			vc, ts := newFakeVaultClient(t, handler)
+			defer ts.Close()
+			// the failures are not retried
+			vc.SetMaxRetries(0)
+
+			// the condition of the previous generation does not skip the verification
+			config := &DatabaseSecretEngineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Generation: 2},
+				Spec:       DatabaseSecretEngineConfigSpec{Path: "database", Verification: tt.verification},
+				Status: DatabaseSecretEngineConfigStatus{Conditions: []metav1.Condition{
+					{Type: ConnectionVerified, Status: metav1.ConditionTrue, Reason: ConnectionVerifiedReason, ObservedGeneration: 1},
+				}},
+			}
+			err := config.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc))
+			if (err != nil) != (tt.status == metav1.ConditionFalse) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			condition := apimeta.FindStatusCondition(config.Status.Conditions, ConnectionVerified)
+			if tt.verification == nil {
+				if condition != nil {
+					t.Errorf("did not expect %s, got %+v", ConnectionVerified, condition)
+				}
+				return
+			}
+			if condition == nil || condition.Status != tt.status || condition.Reason != tt.reason || condition.Message != tt.message {
+				t.Fatalf("unexpected condition %+v", condition)
+			}
+			revoke := handler.find(http.MethodPut, "sys/leases/revoke")
+			if tt.revoked && (revoke == nil || revoke.body["lease_id"] != "database/creds/readonly/abc") {
+				t.Errorf("expected the lease to be revoked, got %v", revoke)
+			}
+		})
+	}
+}
+
+func TestDatabaseSecretEngineConfigVerificationNeeded(t *testing.T) {
+	verified := metav1.Condition{Type: ConnectionVerified, Status: metav1.ConditionTrue, Reason: ConnectionVerifiedReason, ObservedGeneration: 1}
+	failed := metav1.Condition{Type: ConnectionVerified, Status: metav1.ConditionFalse, Reason: ConnectionVerificationFailedReason, ObservedGeneration: 1}
+	tests := []struct {
+		name               string
+		generation         int64
+		annotations        map[string]string
+		condition          *metav1.Condition
+		credentialsChanged bool
+		expected           bool
+	}{
+		{name: "never verified", generation: 1, expected: true},
+		{name: "verified", generation: 1, condition: &verified},
+		{name: "spec changed", generation: 2, condition: &verified, expected: true},
+		{name: "last verification failed", generation: 1, condition: &failed, expected: true},
+		{name: "credentials changed", generation: 1, condition: &verified, credentialsChanged: true, expected: true},
+		{name: "reseed requested", generation: 1, condition: &verified, annotations: map[string]string{ReseedRootCredentialsAnnotation: "1"}, expected: true},
+		{name: "verification requested", generation: 1, condition: &verified, annotations: map[string]string{VerifyConnectionAnnotation: "1"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &DatabaseSecretEngineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Generation: tt.generation, Annotations: tt.annotations},
+				Spec:       DatabaseSecretEngineConfigSpec{Path: "database", Verification: &DBSEConnectionVerification{Reset: true}},
+			}
+			config.Spec.credentialsChanged = tt.credentialsChanged
+			if tt.condition != nil {
+				config.Status.Conditions = []metav1.Condition{*tt.condition}
+			}
+			if needed := config.isVerificationNeeded(); needed != tt.expected {
+				t.Errorf("isVerificationNeeded() = %v, expected %v", needed, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDatabaseSecretEngineConfigVerificationRequest(t *testing.T) {
+	handler := &recordingVaultHandler{fakeVaultHandler: newFakeVaultHandler()}
+	handler.setGet("database/reset/db", map[string]any{})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+	ctx := pivContext(newFakeKubeClient(), vc)
+
+	config := &DatabaseSecretEngineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Generation: 1},
+		Spec:       DatabaseSecretEngineConfigSpec{Path: "database", Verification: &DBSEConnectionVerification{Reset: true}},
+	}
+	resets := func() int {
+		count := 0
+		for _, request := range handler.requests {
+			if request.path == "database/reset/db" {
+				count++
+			}
+		}
+		return count
+	}
+	for range 2 {
+		if err := config.ReconcilePostWrite(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if resets() != 1 {
+		t.Fatalf("expected the connection to be verified once, got %d resets", resets())
+	}
+
+	config.Annotations = map[string]string{VerifyConnectionAnnotation: "1"}
+	for range 2 {
+		if err := config.ReconcilePostWrite(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if resets() != 2 {
+		t.Errorf("expected the connection to be verified again once on request, got %d resets", resets())
+	}
+	if config.Status.LastVerificationRequest != "1" {
+		t.Errorf("last verification request = %q, expected 1", config.Status.LastVerificationRequest)
+	}
+}
+
+func TestDatabaseSecretEngineConfigRootCredentialsHandOff(t *testing.T) {
+	const ns = "test-ns"
+	handler := newFakeVaultHandler()
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+	kube := newFakeKubeClient(newK8sSecret(ns, "db-cred", map[string][]byte{
+		"username": []byte("db-user"),
+		"password": []byte("initial"),
+	}))
+	ctx := pivContext(kube, vc)
+	instance := &DatabaseSecretEngineConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "db"},
+		Spec: DatabaseSecretEngineConfigSpec{
+			Path: "database",
+			DBSEConfig: DBSEConfig{
+				PluginName:           "postgresql-database-plugin",
+				RootPasswordRotation: &RootPasswordRotation{Enable: true},
+			},
+			RootCredentials: vaultutils.RootCredentialConfig{
+				Secret:      &corev1.LocalObjectReference{Name: "db-cred"},
+				UsernameKey: "username",
+				PasswordKey: "password",
+			},
+		},
+	}
+
+	// before the first rotation the password of the source is pushed
+	if err := instance.PrepareInternalValues(ctx, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if instance.GetPayload()["password"] != "initial" {
+		t.Errorf("expected the password of the source, got %v", instance.GetPayload()["password"])
+	}
+
+	// once Vault rotated it, a changed source is not pushed
+	rotation := metav1.NewTime(time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	instance.Status.LastRootPasswordRotation = rotation
+	instance.SetRootCredentialsCondition()
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, client.ObjectKey{Namespace: ns, Name: "db-cred"}, secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	secret.Data["password"] = []byte("changed")
+	if err := kube.Update(ctx, secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := instance.PrepareInternalValues(ctx, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := instance.GetPayload()["password"]; ok {
+		t.Error("did not expect the password of the source after the rotation")
+	}
+	if !instance.Status.LastRootPasswordRotation.Equal(&rotation) {
+		t.Errorf("expected the rotation to be kept, got %v", instance.Status.LastRootPasswordRotation)
+	}
+	condition := apimeta.FindStatusCondition(instance.Status.Conditions, RootCredentialsSuperseded)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != RootPasswordRotatedReason {
+		t.Fatalf("unexpected condition %+v", condition)
+	}
+	if condition.Message != "the root password was rotated by Vault at 2026-10-19T10:00:00Z, the password of Secret db-cred is no longer used" {
+		t.Errorf("unexpected message %q", condition.Message)
+	}
+
+	// the reseed annotation pushes the source again, and lets Vault rotate it again
+	instance.Annotations = map[string]string{ReseedRootCredentialsAnnotation: "1"}
+	if err := instance.PrepareInternalValues(ctx, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if instance.GetPayload()["password"] != "changed" {
+		t.Errorf("expected the password of the source, got %v", instance.GetPayload()["password"])
+	}
+	if !instance.Status.LastRootPasswordRotation.IsZero() {
+		t.Errorf("expected the rotation to be reset, got %v", instance.Status.LastRootPasswordRotation)
+	}
+	if instance.IsEquivalentToDesiredState(map[string]any{}) {
+		t.Error("expected the configuration to be written again")
+	}
+	if err := instance.EnrichStatus(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if instance.Status.LastReseedRequest != "1" {
+		t.Errorf("last reseed request = %q, expected 1", instance.Status.LastReseedRequest)
+	}
+	if apimeta.FindStatusCondition(instance.Status.Conditions, RootCredentialsSuperseded) != nil {
+		t.Errorf("did not expect %s before the next rotation", RootCredentialsSuperseded)
+	}
+
+	// the same annotation does not push the source again
+	instance.Status.LastRootPasswordRotation = rotation
+	if err := instance.PrepareInternalValues(ctx, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := instance.GetPayload()["password"]; ok {
+		t.Error("did not expect the password of the source for a handled reseed")
+	}
+}
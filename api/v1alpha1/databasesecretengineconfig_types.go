@@ -21,10 +21,15 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
+	vault "github.com/hashicorp/vault/api"
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -61,9 +66,26 @@ type DatabaseSecretEngineConfigSpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Pattern:=`[a-z0-9]([-a-z0-9]*[a-z0-9])?`
 	Name string `json:"name,omitempty"`
+
+	// Verification checks the connection after the configuration is written, when the spec or the root credentials changed, when the last verification did not succeed, or when the verify-connection annotation requests it, and reports the result with the ConnectionVerified condition.
+	// +kubebuilder:validation:Optional
+	Verification *DBSEConnectionVerification `json:"verification,omitempty"`
+}
+
+// DBSEConnectionVerification configures the checks of the connection to the database.
+type DBSEConnectionVerification struct {
+	// Reset resets the connection before the other checks, so that they do not run on connections opened with a previous configuration.
+	// +kubebuilder:validation:Optional
+	Reset bool `json:"reset,omitempty"`
+
+	// Role is the name in Vault of a dynamic role of this connection. Credentials are generated with it and revoked immediately.
+	// +kubebuilder:validation:Optional
+	Role string `json:"role,omitempty"`
 }
 
 var _ vaultutils.VaultObject = &DatabaseSecretEngineConfig{}
+var _ vaultutils.VaultStatusEnricher = &DatabaseSecretEngineConfig{}
+var _ vaultutils.VaultPostWriteReconciler = &DatabaseSecretEngineConfig{}
 
 func (d *DatabaseSecretEngineConfig) GetVaultConnection() *vaultutils.VaultConnection {
 	return d.Spec.Connection
@@ -85,10 +107,23 @@ func (d *DatabaseSecretEngineConfig) GetRootPasswordRotationPath() string {
 	}
 	return vaultutils.CleansePath(string(d.Spec.Path) + "/" + "rotate-root" + "/" + d.Name)
 }
+func (d *DatabaseSecretEngineConfig) GetResetPath() string {
+	if d.Spec.Name != "" {
+		return vaultutils.CleansePath(string(d.Spec.Path) + "/" + "reset" + "/" + d.Spec.Name)
+	}
+	return vaultutils.CleansePath(string(d.Spec.Path) + "/" + "reset" + "/" + d.Name)
+}
+func (d *DatabaseSecretEngineConfig) GetCredsPath(role string) string {
+	return vaultutils.CleansePath(string(d.Spec.Path) + "/" + "creds" + "/" + role)
+}
 func (d *DatabaseSecretEngineConfig) GetPayload() map[string]any {
 	return d.Spec.toMap()
 }
 func (d *DatabaseSecretEngineConfig) IsEquivalentToDesiredState(payload map[string]any) bool {
+	// the password is not read back from Vault, the configuration is written to push it again
+	if d.isReseedRequested() {
+		return false
+	}
 	if d.Spec.DBSEConfig.RootPasswordRotation != nil && d.Spec.DBSEConfig.RootPasswordRotation.Enable && d.Status.LastRootPasswordRotation.IsZero() {
 		return false
 	}
@@ -129,7 +164,62 @@ func (d *DatabaseSecretEngineConfig) IsInitialized() bool {
 }
 
 func (d *DatabaseSecretEngineConfig) PrepareInternalValues(context context.Context, object client.Object) error {
-	return d.setInternalCredentials(context)
+	rotatedByVault := d.IsRootPasswordRotatedByVault()
+	lastRootPasswordRotation := d.Status.LastRootPasswordRotation
+	err := d.setInternalCredentials(context)
+	if err != nil {
+		return err
+	}
+	if d.isReseedRequested() {
+		// the password of the source is pushed again, and rotated right after by Vault when the rotation is enabled
+		log.FromContext(context).Info("re-seeding the root credentials from their source", "instance", d.Name)
+		d.Status.LastRootPasswordRotation = metav1.Time{}
+		return nil
+	}
+	if rotatedByVault {
+		// Vault owns the root password since it rotated it, the password of the source is stale and would break the connection
+		d.Status.LastRootPasswordRotation = lastRootPasswordRotation
+		d.Spec.DBSEConfig.retrievedPassword = ""
+	}
+	return nil
+}
+
+// IsRootPasswordRotatedByVault returns whether Vault rotated the root password, so that the password of the root credentials source is no longer valid.
+func (d *DatabaseSecretEngineConfig) IsRootPasswordRotatedByVault() bool {
+	return d.Spec.RootPasswordRotation != nil && d.Spec.RootPasswordRotation.Enable && !d.Status.LastRootPasswordRotation.IsZero()
+}
+
+// isReseedRequested returns whether the reseed annotation requests to push the password of the root credentials source again.
+func (d *DatabaseSecretEngineConfig) isReseedRequested() bool {
+	request := d.GetAnnotations()[ReseedRootCredentialsAnnotation]
+	return request != "" && request != d.Status.LastReseedRequest
+}
+
+// SetRootCredentialsCondition reports with the RootCredentialsSuperseded condition whether the root credentials source is superseded by the password rotated by Vault.
+func (d *DatabaseSecretEngineConfig) SetRootCredentialsCondition() {
+	if !d.IsRootPasswordRotatedByVault() {
+		apimeta.RemoveStatusCondition(&d.Status.Conditions, RootCredentialsSuperseded)
+		return
+	}
+	apimeta.SetStatusCondition(&d.Status.Conditions, metav1.Condition{
+		Type:               RootCredentialsSuperseded,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: d.GetGeneration(),
+		Reason:             RootPasswordRotatedReason,
+		Message:            fmt.Sprintf("the root password was rotated by Vault at %s, the password of %s is no longer used", d.Status.LastRootPasswordRotation.UTC().Format(time.RFC3339), d.getRootCredentialsSource()),
+	})
+}
+
+func (d *DatabaseSecretEngineConfig) getRootCredentialsSource() string {
+	switch {
+	case d.Spec.RootCredentials.Secret != nil:
+		return "Secret " + d.Spec.RootCredentials.Secret.Name
+	case d.Spec.RootCredentials.RandomSecret != nil:
+		return "RandomSecret " + d.Spec.RootCredentials.RandomSecret.Name
+	case d.Spec.RootCredentials.VaultSecret != nil:
+		return "Vault secret " + string(d.Spec.RootCredentials.VaultSecret.Path)
+	}
+	return "the root credentials"
 }
 
 func (d *DatabaseSecretEngineConfig) PrepareTLSConfig(context context.Context, object client.Object) error {
@@ -271,6 +361,9 @@ type DBSEConfig struct {
 
 	retrievedUsername string `json:"-"`
 
+	// credentialsChanged is for internal use and will not be serialized
+	credentialsChanged bool `json:"-"`
+
 	// +kubebuilder:validation:Optional
 	RootPasswordRotation *RootPasswordRotation `json:"rootPasswordRotation,omitempty"`
 }
@@ -299,6 +392,22 @@ type DatabaseSecretEngineConfigStatus struct {
 	// CredentialsHash stores the hash of the current username and password to detect credential changes
 	// +kubebuilder:validation:Optional
 	CredentialsHash string `json:"credentialsHash,omitempty"`
+
+	// PluginName is the name of the plugin of the connection, as read back from Vault.
+	// +kubebuilder:validation:Optional
+	PluginName string `json:"pluginName,omitempty"`
+
+	// PluginVersion is the version of the plugin running the connection, as read back from Vault.
+	// +kubebuilder:validation:Optional
+	PluginVersion string `json:"pluginVersion,omitempty"`
+
+	// LastReseedRequest is the value of the reseed annotation which last pushed the password of the root credentials source again.
+	// +kubebuilder:validation:Optional
+	LastReseedRequest string `json:"lastReseedRequest,omitempty"`
+
+	// LastVerificationRequest is the value of the verify-connection annotation which last requested to verify the connection.
+	// +kubebuilder:validation:Optional
+	LastVerificationRequest string `json:"lastVerificationRequest,omitempty"`
 }
 
 var _ vaultutils.ConditionsAware = &DatabaseSecretEngineConfig{}
@@ -329,6 +438,7 @@ func (m *DatabaseSecretEngineConfig) SetUsernameAndPassword(username string, pas
 	if m.Status.CredentialsHash != "" && m.Status.CredentialsHash != newHash {
 		m.Status.LastRootPasswordRotation = metav1.Time{}
 	}
+	m.Spec.DBSEConfig.credentialsChanged = m.Status.CredentialsHash != newHash
 
 	// Update the stored hash
 	m.Status.CredentialsHash = newHash
@@ -403,3 +513,124 @@ func (d *DatabaseSecretEngineConfig) RotateRootPassword(ctx context.Context) err
 	}
 	return nil
 }
+
+const (
+	// ConnectionVerified is true when the checks of spec.verification succeeded on their last run.
+	ConnectionVerified = "ConnectionVerified"
+
+	ConnectionVerifiedReason           = "Verified"
+	ConnectionVerificationFailedReason = "VerificationFailed"
+
+	// RootCredentialsSuperseded is true when Vault rotated the root password, so that the password of the root credentials source is no longer pushed to Vault.
+	RootCredentialsSuperseded = "RootCredentialsSuperseded"
+
+	RootPasswordRotatedReason = "RotatedByVault"
+)
+
+// ReseedRootCredentialsAnnotation requests to push the password of the root credentials source to Vault again, after Vault rotated the root password. The password is pushed every time its value changes, for example to the current timestamp.
+const ReseedRootCredentialsAnnotation = "redhatcop.redhat.io/reseed-root-credentials"
+
+// VerifyConnectionAnnotation requests to verify the connection again, when spec.verification is set. The connection is verified every time its value changes, for example to the current timestamp.
+const VerifyConnectionAnnotation = "redhatcop.redhat.io/verify-connection"
+
+// EnrichStatus records the requested re-seed of the root credentials and whether their source is superseded, and records the plugin of the connection as read back from Vault.
+func (d *DatabaseSecretEngineConfig) EnrichStatus(ctx context.Context) error {
+	// the configuration was written, so the requested re-seed is done
+	if d.isReseedRequested() {
+		d.Status.LastReseedRequest = d.GetAnnotations()[ReseedRootCredentialsAnnotation]
+	}
+	d.SetRootCredentialsCondition()
+	secret, found, err := vaultutils.ReadSecret(ctx, d.GetPath())
+	if err != nil {
+		return err
+	}
+	if found && secret != nil && secret.Data != nil {
+		d.Status.PluginName = vaultutils.ToString(secret.Data["plugin_name"])
+		d.Status.PluginVersion = vaultutils.ToString(secret.Data["plugin_version"])
+	}
+	return nil
+}
+
+// ReconcilePostWrite verifies the connection when spec.verification is set and the verification is needed.
+func (d *DatabaseSecretEngineConfig) ReconcilePostWrite(ctx context.Context) error {
+	if d.Spec.Verification == nil {
+		apimeta.RemoveStatusCondition(&d.Status.Conditions, ConnectionVerified)
+		return nil
+	}
+	if !d.isVerificationNeeded() {
+		return nil
+	}
+	d.Status.LastVerificationRequest = d.GetAnnotations()[VerifyConnectionAnnotation]
+	condition := metav1.Condition{
+		Type:               ConnectionVerified,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: d.GetGeneration(),
+		Reason:             ConnectionVerifiedReason,
+	}
+	message, err := d.verifyConnection(ctx)
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ConnectionVerificationFailedReason
+		message = err.Error()
+	}
+	condition.Message = message
+	apimeta.SetStatusCondition(&d.Status.Conditions, condition)
+	return err
+}
+
+// isVerificationNeeded returns whether the connection must be verified: when the spec, and so the configuration, changed since the last verification, when the root credentials changed or are re-seeded, when the last verification did not succeed, or when the verify-connection annotation requests it.
+func (d *DatabaseSecretEngineConfig) isVerificationNeeded() bool {
+	condition := apimeta.FindStatusCondition(d.Status.Conditions, ConnectionVerified)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.ObservedGeneration != d.GetGeneration() {
+		return true
+	}
+	return d.Spec.credentialsChanged || d.isReseedRequested() || d.GetAnnotations()[VerifyConnectionAnnotation] != d.Status.LastVerificationRequest
+}
+
+// verifyConnection resets the connection and generates and revokes credentials with the verification role, as configured.
+func (d *DatabaseSecretEngineConfig) verifyConnection(ctx context.Context) (string, error) {
+	log := log.FromContext(ctx)
+	vaultClient := vaultutils.VaultClientFromContext(ctx)
+	checks := []string{}
+
+	if d.Spec.Verification.Reset {
+		_, err := vaultClient.Logical().WriteWithContext(ctx, d.GetResetPath(), nil)
+		if err != nil {
+			return "", fmt.Errorf("unable to reset the connection: %s", getVaultErrorMessage(err))
+		}
+		checks = append(checks, "connection reset")
+	}
+
+	if d.Spec.Verification.Role != "" {
+		secret, err := vaultClient.Logical().ReadWithContext(ctx, d.GetCredsPath(d.Spec.Verification.Role))
+		if err != nil {
+			return "", fmt.Errorf("unable to generate credentials with role %s: %s", d.Spec.Verification.Role, getVaultErrorMessage(err))
+		}
+		if secret == nil {
+			return "", fmt.Errorf("unable to generate credentials with role %s: role not found", d.Spec.Verification.Role)
+		}
+		// the credentials are revoked so that the verification does not leave users behind in the database
+		if secret.LeaseID != "" {
+			err := vaultClient.Sys().RevokeWithContext(ctx, secret.LeaseID)
+			if err != nil {
+				log.Error(err, "unable to revoke the credentials generated to verify the connection", "lease", secret.LeaseID)
+				return "", fmt.Errorf("unable to revoke the credentials generated with role %s: %s", d.Spec.Verification.Role, getVaultErrorMessage(err))
+			}
+		}
+		checks = append(checks, "credentials generated with role "+d.Spec.Verification.Role+" and revoked")
+	}
+
+	if len(checks) == 0 {
+		return "no check configured", nil
+	}
+	return strings.Join(checks, ", "), nil
+}
+
+// getVaultErrorMessage returns the errors reported by Vault, which hold the error of the database, or the error itself when it did not come from Vault.
+func getVaultErrorMessage(err error) string {
+	var responseError *vault.ResponseError
+	if errors.As(err, &responseError) && len(responseError.Errors) > 0 {
+		return strings.Join(responseError.Errors, "; ")
+	}
+	return err.Error()
+}
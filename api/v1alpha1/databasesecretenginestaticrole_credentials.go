@@ -0,0 +1,166 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DBSECredentialsSecret is the Kubernetes secret where the credentials of the static role are published.
+type DBSECredentialsSecret struct {
+	// Name is the name of the Kubernetes secret, in the same namespace, owned by the DatabaseSecretEngineStaticRole.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+func (d *DatabaseSecretEngineStaticRole) GetCredsPath() string {
+	if d.Spec.Name != "" {
+		return vaultutils.CleansePath(string(d.Spec.Path) + "/" + "static-creds" + "/" + d.Spec.Name)
+	}
+	return vaultutils.CleansePath(string(d.Spec.Path) + "/" + "static-creds" + "/" + d.Name)
+}
+
+// GetConfigPath returns the path of the database connection of the static role.
+func (d *DatabaseSecretEngineStaticRole) GetConfigPath() string {
+	return vaultutils.CleansePath(string(d.Spec.Path) + "/" + "config" + "/" + d.Spec.DBName)
+}
+
+// reconcileCredentialsSecret publishes the credentials of the static role to the credentials secret, when CredentialsSecret is set.
+func (d *DatabaseSecretEngineStaticRole) reconcileCredentialsSecret(ctx context.Context) error {
+	if d.Spec.CredentialsSecret == nil {
+		apimeta.RemoveStatusCondition(&d.Status.Conditions, CredentialsSecretSynced)
+		return nil
+	}
+	err := d.syncCredentialsSecret(ctx)
+	condition := metav1.Condition{
+		Type:               CredentialsSecretSynced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: d.GetGeneration(),
+		Reason:             CredentialsSecretSyncedReason,
+		Message:            fmt.Sprintf("credentials published to secret %s", d.Spec.CredentialsSecret.Name),
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = CredentialsSecretNotSyncedReason
+		condition.Message = err.Error()
+	}
+	apimeta.SetStatusCondition(&d.Status.Conditions, condition)
+	return err
+}
+
+// GetCredentialsSecretData returns the data of the credentials secret, read from the static credentials in Vault and the connection URL of the DatabaseSecretEngineConfig.
+func (d *DatabaseSecretEngineStaticRole) GetCredentialsSecretData(ctx context.Context) (map[string][]byte, error) {
+	creds, found, err := vaultutils.ReadSecret(ctx, d.GetCredsPath())
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("static credentials %s not found in Vault", d.GetCredsPath())
+	}
+	username, _ := creds.Data["username"].(string)
+	if username == "" {
+		return nil, errors.New("no username returned by Vault for the static role")
+	}
+	data := map[string][]byte{
+		"username": []byte(username),
+	}
+	password, _ := creds.Data["password"].(string)
+	if d.Spec.CredentialType == "rsa_private_key" {
+		privateKey, _ := creds.Data["rsa_private_key"].(string)
+		data["rsa_private_key"] = []byte(privateKey)
+	} else {
+		data["password"] = []byte(password)
+	}
+
+	connectionURL, err := d.getConnectionURL(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+	if connectionURL != "" {
+		data["connection_url"] = []byte(connectionURL)
+	}
+	return data, nil
+}
+
+// getConnectionURL returns the connection URL of the DatabaseSecretEngineConfig of the static role, with the credentials of the static role in place of the root credentials, escaped as Vault escapes them. It is empty when the DatabaseSecretEngineConfig is not in the namespace of the static role.
+func (d *DatabaseSecretEngineStaticRole) getConnectionURL(ctx context.Context, username string, password string) (string, error) {
+	kubeClient := vaultutils.KubeClientFromContext(ctx)
+	configs := &DatabaseSecretEngineConfigList{}
+	err := kubeClient.List(ctx, configs, &client.ListOptions{Namespace: d.Namespace})
+	if err != nil {
+		return "", err
+	}
+	for i := range configs.Items {
+		config := &configs.Items[i]
+		if config.GetPath() != d.GetConfigPath() {
+			continue
+		}
+		if !config.Spec.DisableEscaping {
+			username = url.PathEscape(username)
+			password = url.PathEscape(password)
+		}
+		replacements := []string{"{{username}}", username, "{{name}}", username}
+		// key pair authentication has no password
+		if d.Spec.CredentialType != "rsa_private_key" {
+			replacements = append(replacements, "{{password}}", password)
+		}
+		return strings.NewReplacer(replacements...).Replace(config.Spec.ConnectionURL), nil
+	}
+	log.FromContext(ctx).V(1).Info("DatabaseSecretEngineConfig of the static role not found, the connection URL is not published", "path", d.GetConfigPath())
+	return "", nil
+}
+
+// syncCredentialsSecret creates or updates the credentials secret, owned by the static role.
+func (d *DatabaseSecretEngineStaticRole) syncCredentialsSecret(ctx context.Context) error {
+	data, err := d.GetCredentialsSecretData(ctx)
+	if err != nil {
+		return err
+	}
+	kubeClient := vaultutils.KubeClientFromContext(ctx)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      d.Spec.CredentialsSecret.Name,
+			Namespace: d.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, kubeClient, secret, func() error {
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, d) {
+			return fmt.Errorf("secret %s already exists and is not owned by the DatabaseSecretEngineStaticRole", secret.Name)
+		}
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels["redhatcop.redhat.io/databasesecretenginestaticroles"] = d.Name
+		secret.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(d, GroupVersion.WithKind("DatabaseSecretEngineStaticRole"))}
+		secret.Data = data
+		return nil
+	})
+	return err
+}
@@ -1,10 +1,17 @@
 package v1alpha1
 
 import (
+	"encoding/json"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestDatabaseSecretEngineStaticRoleGetPath(t *testing.T) {
@@ -314,3 +321,232 @@ func TestRSAPrivateKeyCredentialConfigToMap(t *testing.T) {
 		t.Errorf("key_bits should be string '4096', got %v", result["key_bits"])
 	}
 }
+
+func TestDBSEStaticRoleToMapRotationSchedule(t *testing.T) {
+	role := DBSEStaticRole{
+		DBName:           "my-db",
+		Username:         "static-user",
+		RotationSchedule: "0 2 * * SAT",
+		RotationWindow:   3600,
+		CredentialType:   "password",
+	}
+
+	result := role.toMap()
+
+	if result["rotation_schedule"] != "0 2 * * SAT" {
+		t.Errorf("rotation_schedule = %v, expected 0 2 * * SAT", result["rotation_schedule"])
+	}
+	if result["rotation_window"] != 3600 {
+		t.Errorf("rotation_window = %v, expected 3600", result["rotation_window"])
+	}
+	if _, ok := result["rotation_period"]; ok {
+		t.Error("did not expect rotation_period with a rotation schedule")
+	}
+}
+
+func TestDatabaseSecretEngineStaticRoleValidateRotation(t *testing.T) {
+	tests := []struct {
+		name string
+		role DBSEStaticRole
+		err  string
+	}{
+		{name: "rotation period", role: DBSEStaticRole{RotationPeriod: 86400}},
+		{name: "rotation schedule", role: DBSEStaticRole{RotationSchedule: "0 2 * * SAT", RotationWindow: 3600}},
+		{name: "neither", role: DBSEStaticRole{}, err: "one of spec.rotationPeriod or spec.rotationSchedule must be specified"},
+		{name: "both", role: DBSEStaticRole{RotationPeriod: 86400, RotationSchedule: "0 2 * * SAT"}, err: "only one of"},
+		{name: "window without schedule", role: DBSEStaticRole{RotationPeriod: 86400, RotationWindow: 3600}, err: "requires spec.rotationSchedule"},
+		{name: "invalid schedule", role: DBSEStaticRole{RotationSchedule: "@daily"}, err: "must have five fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := &DatabaseSecretEngineStaticRole{Spec: DatabaseSecretEngineStaticRoleSpec{DBSEStaticRole: tt.role}}
+			err := role.validateRotation()
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("validateRotation() = %v, expected error containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestDatabaseSecretEngineStaticRoleEnrichStatus(t *testing.T) {
+	handler := &recordingVaultHandler{fakeVaultHandler: newFakeVaultHandler()}
+	handler.setGet("database/static-roles/app", map[string]any{
+		"last_vault_rotation": "2026-10-18T02:00:00.123456Z",
+		"ttl":                 json.Number("3600"),
+	})
+	handler.setGet("database/rotate-role/app", map[string]any{})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	role := &DatabaseSecretEngineStaticRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec:       DatabaseSecretEngineStaticRoleSpec{Path: "database"},
+	}
+	ctx := pivContext(newFakeKubeClient(), vc)
+
+	// without the annotation the credentials are not rotated
+	before := time.Now()
+	if err := role.ReconcilePostWrite(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := role.EnrichStatus(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler.find(http.MethodPut, "database/rotate-role/app") != nil {
+		t.Error("did not expect a rotation")
+	}
+	if role.Status.LastVaultRotation == nil || !role.Status.LastVaultRotation.Equal(&metav1.Time{Time: time.Date(2026, 10, 18, 2, 0, 0, 123456000, time.UTC)}) {
+		t.Errorf("unexpected last vault rotation %v", role.Status.LastVaultRotation)
+	}
+	if role.Status.TTL != 3600 {
+		t.Errorf("ttl = %d, expected 3600", role.Status.TTL)
+	}
+	if next := role.Status.NextVaultRotation; next == nil || next.Time.Before(before.Add(59*time.Minute)) || next.Time.After(time.Now().Add(time.Hour)) {
+		t.Errorf("unexpected next vault rotation %v", next)
+	}
+
+	// a new annotation value rotates the credentials once
+	role.Annotations = map[string]string{RotateAnnotation: "2026-10-19T10:00:00Z"}
+	if err := role.ReconcilePostWrite(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler.find(http.MethodPut, "database/rotate-role/app") == nil {
+		t.Error("expected a rotation")
+	}
+	if role.Status.LastRotateRequest != "2026-10-19T10:00:00Z" {
+		t.Errorf("last rotate request = %q", role.Status.LastRotateRequest)
+	}
+	handler.requests = nil
+	if err := role.ReconcilePostWrite(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler.find(http.MethodPut, "database/rotate-role/app") != nil {
+		t.Error("did not expect another rotation")
+	}
+
+	// a failed rotation is not recorded, so that it is requested again
+	role.Spec.Name = "missing"
+	role.Annotations[RotateAnnotation] = "again"
+	if err := role.ReconcilePostWrite(ctx); err == nil {
+		t.Error("expected a rotation error")
+	}
+	if role.Status.LastRotateRequest != "2026-10-19T10:00:00Z" {
+		t.Errorf("last rotate request = %q", role.Status.LastRotateRequest)
+	}
+}
+
+func TestDatabaseSecretEngineStaticRoleReconcilePostWriteCredentialsSecret(t *testing.T) {
+	newRole := func(credentialType string) *DatabaseSecretEngineStaticRole {
+		return &DatabaseSecretEngineStaticRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns", UID: "role-uid"},
+			Spec: DatabaseSecretEngineStaticRoleSpec{
+				Path:              "database",
+				DBSEStaticRole:    DBSEStaticRole{DBName: "postgres", Username: "app", CredentialType: credentialType},
+				CredentialsSecret: &DBSECredentialsSecret{Name: "app-db"},
+			},
+		}
+	}
+	config := &DatabaseSecretEngineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "ns"},
+		Spec: DatabaseSecretEngineConfigSpec{
+			Path:       "database",
+			DBSEConfig: DBSEConfig{ConnectionURL: "postgresql://{{username}}:{{password}}@db:5432/app"},
+		},
+	}
+	handler := newFakeVaultHandler()
+	handler.setGet("database/static-roles/app", map[string]any{"ttl": json.Number("3600")})
+	handler.setGet("database/static-creds/app", map[string]any{"username": "app", "password": "p@ss/word", "rsa_private_key": "KEY"})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	t.Run("publishes the credentials", func(t *testing.T) {
+		role := newRole("password")
+		kubeClient := newFakeKubeClient(config)
+		ctx := pivContext(kubeClient, vc)
+		if err := role.ReconcilePostWrite(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		secret := &corev1.Secret{}
+		if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "app-db"}, secret); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := map[string][]byte{
+			"username":       []byte("app"),
+			"password":       []byte("p@ss/word"),
+			"connection_url": []byte("postgresql://app:p@ss%2Fword@db:5432/app"),
+		}
+		if !reflect.DeepEqual(secret.Data, expected) {
+			t.Errorf("secret data = %v, expected %v", secret.Data, expected)
+		}
+		if !metav1.IsControlledBy(secret, role) {
+			t.Errorf("secret is not owned by the static role: %v", secret.OwnerReferences)
+		}
+		if !apimeta.IsStatusConditionTrue(role.Status.Conditions, CredentialsSecretSynced) {
+			t.Errorf("expected the %s condition to be true: %v", CredentialsSecretSynced, role.Status.Conditions)
+		}
+
+		// Vault rotates the password
+		handler.setGet("database/static-creds/app", map[string]any{"username": "app", "password": "rotated"})
+		defer handler.setGet("database/static-creds/app", map[string]any{"username": "app", "password": "p@ss/word", "rsa_private_key": "KEY"})
+		if err := role.ReconcilePostWrite(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "app-db"}, secret); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(secret.Data["password"]) != "rotated" || string(secret.Data["connection_url"]) != "postgresql://app:rotated@db:5432/app" {
+			t.Errorf("expected the rotated password, got %v", secret.Data)
+		}
+	})
+
+	t.Run("publishes the private key without the connection URL", func(t *testing.T) {
+		role := newRole("rsa_private_key")
+		kubeClient := newFakeKubeClient()
+		ctx := pivContext(kubeClient, vc)
+		if err := role.ReconcilePostWrite(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		secret := &corev1.Secret{}
+		if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "app-db"}, secret); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := map[string][]byte{
+			"username":        []byte("app"),
+			"rsa_private_key": []byte("KEY"),
+		}
+		if !reflect.DeepEqual(secret.Data, expected) {
+			t.Errorf("secret data = %v, expected %v", secret.Data, expected)
+		}
+	})
+
+	t.Run("refuses to take over a secret", func(t *testing.T) {
+		role := newRole("password")
+		kubeClient := newFakeKubeClient(config, newK8sSecret("ns", "app-db", map[string][]byte{"other": []byte("data")}))
+		err := role.ReconcilePostWrite(pivContext(kubeClient, vc))
+		if err == nil || !strings.Contains(err.Error(), "is not owned by the DatabaseSecretEngineStaticRole") {
+			t.Errorf("expected an ownership error, got %v", err)
+		}
+		if !apimeta.IsStatusConditionFalse(role.Status.Conditions, CredentialsSecretSynced) {
+			t.Errorf("expected the %s condition to be false: %v", CredentialsSecretSynced, role.Status.Conditions)
+		}
+	})
+
+	t.Run("without credentials secret", func(t *testing.T) {
+		role := newRole("password")
+		role.Spec.CredentialsSecret = nil
+		role.Status.Conditions = []metav1.Condition{{Type: CredentialsSecretSynced, Status: metav1.ConditionTrue}}
+		if err := role.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if apimeta.FindStatusCondition(role.Status.Conditions, CredentialsSecretSynced) != nil {
+			t.Errorf("expected the %s condition to be removed", CredentialsSecretSynced)
+		}
+	})
+}
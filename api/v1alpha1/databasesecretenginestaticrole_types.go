@@ -19,12 +19,16 @@ package v1alpha1
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -49,6 +53,10 @@ type DatabaseSecretEngineStaticRoleSpec struct {
 
 	DBSEStaticRole `json:",inline"`
 
+	// CredentialsSecret writes the credentials of the static role into a Kubernetes secret, which is updated when Vault rotates them.
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *DBSECredentialsSecret `json:"credentialsSecret,omitempty"`
+
 	// The name of the obejct created in Vault. If this is specified it takes precedence over {metatada.name}
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Pattern:=`[a-z0-9]([-a-z0-9]*[a-z0-9])?`
@@ -64,11 +72,20 @@ type DBSEStaticRole struct {
 	// +kubebuilder:validation:Required
 	Username string `json:"username,omitempty"`
 
-	// RotationPeriod Specifies the amount of time Vault should wait before rotating the password. The minimum is 5 seconds.
-	// +kubebuilder:validation:Required
+	// RotationPeriod Specifies the amount of time Vault should wait before rotating the password. The minimum is 5 seconds. Mutually exclusive with rotationSchedule.
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Minimum=5
 	RotationPeriod int `json:"rotationPeriod,omitempty"`
 
+	// RotationSchedule A cron-style string of five fields, minute, hour, day of month, month and day of week, defining the schedule on which Vault rotates the password. Requires Vault 1.15 or later. Mutually exclusive with rotationPeriod.
+	// +kubebuilder:validation:Optional
+	RotationSchedule string `json:"rotationSchedule,omitempty"`
+
+	// RotationWindow Specifies the amount of time, in seconds, in which the rotation is allowed to occur starting from a given rotationSchedule. If the rotation does not happen within the window, it is retried at the next scheduled time. The minimum is 3600 seconds. Only valid with rotationSchedule.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=3600
+	RotationWindow int `json:"rotationWindow,omitempty"`
+
 	// RotationStatements Specifies the database statements to be executed to rotate the password for the configured database user. Not every plugin type will support this functionality. See the plugin's API page for more information on support and formatting for this parameter.
 	// +kubebuilder:validation:Optional
 	// +listType=set
@@ -124,7 +141,14 @@ func (i *DBSEStaticRole) toMap() map[string]any {
 	payload := map[string]any{}
 	payload["db_name"] = i.DBName
 	payload["username"] = i.Username
-	payload["rotation_period"] = i.RotationPeriod
+	if i.RotationSchedule != "" {
+		payload["rotation_schedule"] = i.RotationSchedule
+		if i.RotationWindow != 0 {
+			payload["rotation_window"] = i.RotationWindow
+		}
+	} else {
+		payload["rotation_period"] = i.RotationPeriod
+	}
 	payload["rotation_statements"] = i.RotationStatements
 	payload["credential_type"] = i.CredentialType
 	if i.PasswordCredentialConfig != nil {
@@ -140,6 +164,12 @@ var _ vaultutils.VaultObject = &DatabaseSecretEngineStaticRole{}
 
 var _ vaultutils.ConditionsAware = &DatabaseSecretEngineStaticRole{}
 
+var _ vaultutils.VaultStatusEnricher = &DatabaseSecretEngineStaticRole{}
+var _ vaultutils.VaultPostWriteReconciler = &DatabaseSecretEngineStaticRole{}
+
+// RotateAnnotation requests an immediate rotation of the credentials of the static role. The credentials are rotated every time its value changes, for example to the current timestamp.
+const RotateAnnotation = "redhatcop.redhat.io/rotate"
+
 func (d *DatabaseSecretEngineStaticRole) GetVaultConnection() *vaultutils.VaultConnection {
 	return d.Spec.Connection
 }
@@ -154,6 +184,12 @@ func (d *DatabaseSecretEngineStaticRole) GetPath() string {
 	}
 	return vaultutils.CleansePath(string(d.Spec.Path) + "/" + "static-roles" + "/" + d.Name)
 }
+func (d *DatabaseSecretEngineStaticRole) GetRotatePath() string {
+	if d.Spec.Name != "" {
+		return vaultutils.CleansePath(string(d.Spec.Path) + "/" + "rotate-role" + "/" + d.Spec.Name)
+	}
+	return vaultutils.CleansePath(string(d.Spec.Path) + "/" + "rotate-role" + "/" + d.Name)
+}
 func (d *DatabaseSecretEngineStaticRole) GetPayload() map[string]any {
 	return d.Spec.toMap()
 }
@@ -180,9 +216,28 @@ func (r *DatabaseSecretEngineStaticRole) IsValid() (bool, error) {
 }
 
 func (r *DatabaseSecretEngineStaticRole) isValid() error {
+	if err := r.validateRotation(); err != nil {
+		return err
+	}
 	return r.validateEitherPasswordOrKey()
 }
 
+func (r *DatabaseSecretEngineStaticRole) validateRotation() error {
+	if r.Spec.RotationPeriod == 0 && r.Spec.RotationSchedule == "" {
+		return errors.New("one of spec.rotationPeriod or spec.rotationSchedule must be specified")
+	}
+	if r.Spec.RotationPeriod != 0 && r.Spec.RotationSchedule != "" {
+		return errors.New("only one of spec.rotationPeriod or spec.rotationSchedule can be specified")
+	}
+	if r.Spec.RotationWindow != 0 && r.Spec.RotationSchedule == "" {
+		return errors.New("spec.rotationWindow requires spec.rotationSchedule")
+	}
+	if r.Spec.RotationSchedule != "" && len(strings.Fields(r.Spec.RotationSchedule)) != 5 {
+		return fmt.Errorf("spec.rotationSchedule %q must have five fields: minute, hour, day of month, month and day of week", r.Spec.RotationSchedule)
+	}
+	return nil
+}
+
 func (r *DatabaseSecretEngineStaticRole) validateEitherPasswordOrKey() error {
 	count := 0
 	if r.Spec.PasswordCredentialConfig != nil {
@@ -208,6 +263,22 @@ type DatabaseSecretEngineStaticRoleStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// LastVaultRotation is the time of the last rotation of the credentials by Vault.
+	// +kubebuilder:validation:Optional
+	LastVaultRotation *metav1.Time `json:"lastVaultRotation,omitempty"`
+
+	// TTL is the number of seconds until the next rotation of the credentials, when the status was last read from Vault.
+	// +kubebuilder:validation:Optional
+	TTL int64 `json:"ttl,omitempty"`
+
+	// NextVaultRotation is the time of the next rotation of the credentials by Vault.
+	// +kubebuilder:validation:Optional
+	NextVaultRotation *metav1.Time `json:"nextVaultRotation,omitempty"`
+
+	// LastRotateRequest is the value of the rotate annotation which requested the last immediate rotation.
+	// +kubebuilder:validation:Optional
+	LastRotateRequest string `json:"lastRotateRequest,omitempty"`
 }
 
 func (m *DatabaseSecretEngineStaticRole) GetConditions() []metav1.Condition {
@@ -242,3 +313,52 @@ type DatabaseSecretEngineStaticRoleList struct {
 func init() {
 	SchemeBuilder.Register(&DatabaseSecretEngineStaticRole{}, &DatabaseSecretEngineStaticRoleList{})
 }
+
+// ReconcilePostWrite rotates the credentials when the rotate annotation changed, and syncs the credentials secret.
+func (d *DatabaseSecretEngineStaticRole) ReconcilePostWrite(ctx context.Context) error {
+	var rotateErr error
+	if request := d.GetAnnotations()[RotateAnnotation]; request != "" && request != d.Status.LastRotateRequest {
+		// the request is recorded once the rotation succeeded, a failed rotation fails the reconciliation and is retried
+		rotateErr = d.rotate(ctx)
+		if rotateErr == nil {
+			d.Status.LastRotateRequest = request
+		}
+	}
+	return errors.Join(rotateErr, d.reconcileCredentialsSecret(ctx))
+}
+
+// EnrichStatus records the rotation status of the static role as read back from Vault.
+func (d *DatabaseSecretEngineStaticRole) EnrichStatus(ctx context.Context) error {
+	return d.readRotationStatus(ctx)
+}
+
+// readRotationStatus records the last and next rotations of the static role as read back from Vault.
+func (d *DatabaseSecretEngineStaticRole) readRotationStatus(ctx context.Context) error {
+	secret, found, err := vaultutils.ReadSecret(ctx, d.GetPath())
+	if err != nil {
+		return err
+	}
+	if !found || secret == nil || secret.Data == nil {
+		return nil
+	}
+	d.Status.LastVaultRotation = toTime(secret.Data["last_vault_rotation"])
+	d.Status.TTL = toInt64(secret.Data["ttl"])
+	d.Status.NextVaultRotation = toTime(secret.Data["next_vault_rotation"])
+	// Vault reports the next rotation since 1.17 only
+	if d.Status.NextVaultRotation == nil && d.Status.TTL > 0 {
+		d.Status.NextVaultRotation = &metav1.Time{Time: time.Now().Add(time.Duration(d.Status.TTL) * time.Second).Truncate(time.Second)}
+	}
+	return nil
+}
+
+func (d *DatabaseSecretEngineStaticRole) rotate(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	vaultClient := vaultutils.VaultClientFromContext(ctx)
+	log.Info("rotating the credentials of the static role", "path", d.GetRotatePath())
+	_, err := vaultClient.Logical().WriteWithContext(ctx, d.GetRotatePath(), nil)
+	if err != nil {
+		log.Error(err, "unable to rotate the credentials of the static role", "path", d.GetRotatePath())
+		return err
+	}
+	return nil
+}
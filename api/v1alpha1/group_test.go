@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	vault "github.com/hashicorp/vault/api"
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -303,3 +305,142 @@ func TestGroupEnrichStatusVaultError(t *testing.T) {
 		t.Error("expected error from Vault 500 response, got nil")
 	}
 }
+
+func TestGroupToMapWithMemberSelectors(t *testing.T) {
+	spec := &GroupSpec{
+		GroupConfig: GroupConfig{
+			Type:            "internal",
+			MemberGroupIDs:  []string{"group-2"},
+			MemberEntityIDs: []string{"entity-3", "entity-1"},
+		},
+		MemberSelectors:         &GroupMemberSelectors{Entities: &metav1.LabelSelector{}},
+		selectedMemberEntityIDs: []string{"entity-2", "entity-1"},
+		selectedMemberGroupIDs:  []string{"group-1"},
+	}
+
+	m := spec.toMap()
+
+	if !reflect.DeepEqual(m["member_entity_ids"], []string{"entity-1", "entity-2", "entity-3"}) {
+		t.Errorf("member_entity_ids = %v", m["member_entity_ids"])
+	}
+	if !reflect.DeepEqual(m["member_group_ids"], []string{"group-1", "group-2"}) {
+		t.Errorf("member_group_ids = %v", m["member_group_ids"])
+	}
+}
+
+func TestGroupIsValidMemberSelectors(t *testing.T) {
+	group := &Group{Spec: GroupSpec{
+		GroupConfig:     GroupConfig{Type: "external"},
+		MemberSelectors: &GroupMemberSelectors{Entities: &metav1.LabelSelector{}},
+	}}
+	if valid, err := group.IsValid(); valid || err == nil {
+		t.Errorf("expected member selectors on an external group to be invalid")
+	}
+
+	group.Spec.Type = "internal"
+	if valid, err := group.IsValid(); !valid || err != nil {
+		t.Errorf("expected member selectors on an internal group to be valid, got %v", err)
+	}
+}
+
+// fakeEntityLookupHandler answers identity/lookup/entity with the entity ID registered for the requested alias, and delegates the other requests.
+type fakeEntityLookupHandler struct {
+	*fakeVaultHandler
+	entityIDs map[string]string
+}
+
+func (h *fakeEntityLookupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/identity/lookup/entity" {
+		h.fakeVaultHandler.ServeHTTP(w, r)
+		return
+	}
+	body := map[string]string{}
+	_ = json.NewDecoder(r.Body).Decode(&body) // test handler; a malformed body fails the lookup
+	id, ok := h.entityIDs[body["alias_mount_accessor"]+":"+body["alias_name"]]
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"id": id}}) // test handler; encode error is not actionable
+}
+
+func TestGroupPrepareInternalValuesMemberSelectors(t *testing.T) {
+	team := map[string]string{"team": "payments"}
+	kubeClient := newFakeKubeClient(
+		&Entity{ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: "alice", Labels: team}},
+		&Entity{ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: "bob", Labels: team}, Spec: EntitySpec{Name: "robert"}},
+		&Entity{ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: "carol"}},
+		&Entity{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "dave", Labels: team}},
+		&Entity{ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: "not-in-vault", Labels: team}},
+		&Group{ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: "payments", Labels: team}},
+		&Group{ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: "payments-ops", Labels: team}},
+		&KubernetesAuthEngineRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: "apps", Labels: team},
+			Spec: KubernetesAuthEngineRoleSpec{
+				Path:             "kubernetes",
+				TargetNamespaces: vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"payments-dev", "payments-prod", "missing"}},
+				VRole:            VRole{TargetServiceAccounts: []string{"app", "absent"}, AliasNameSource: "serviceaccount_uid"},
+			},
+		},
+		&KubernetesAuthEngineRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: "unselected"},
+			Spec: KubernetesAuthEngineRoleSpec{
+				Path:             "kubernetes",
+				TargetNamespaces: vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"payments-dev"}},
+				VRole:            VRole{TargetServiceAccounts: []string{"other"}, AliasNameSource: "serviceaccount_name"},
+			},
+		},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments-dev", Labels: map[string]string{"env": "dev"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments-prod", Labels: map[string]string{"env": "prod"}}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "payments-dev", Name: "app", UID: "uid-dev"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "payments-prod", Name: "app", UID: "uid-prod"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "payments-dev", Name: "other", UID: "uid-other"}},
+	)
+	handler := &fakeEntityLookupHandler{
+		fakeVaultHandler: newFakeVaultHandler(),
+		entityIDs: map[string]string{
+			"auth_kubernetes_1234:uid-dev":            "entity-sa-dev",
+			"auth_kubernetes_1234:uid-prod":           "entity-sa-prod",
+			"auth_kubernetes_1234:payments-dev/other": "entity-sa-other",
+		},
+	}
+	handler.setGet("identity/entity/name/alice", map[string]any{"id": "entity-alice"})
+	handler.setGet("identity/entity/name/robert", map[string]any{"id": "entity-bob"})
+	handler.setGet("identity/entity/name/dave", map[string]any{"id": "entity-dave"})
+	handler.setGet("identity/group/name/payments", map[string]any{"id": "group-payments"})
+	handler.setGet("identity/group/name/payments-ops", map[string]any{"id": "group-payments-ops"})
+	handler.setGet("sys/auth/kubernetes", map[string]any{"accessor": "auth_kubernetes_1234"})
+	vaultClient, server := newFakeVaultClient(t, handler)
+	defer server.Close()
+
+	group := &Group{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: "payments"},
+		Spec: GroupSpec{
+			GroupConfig: GroupConfig{Type: "internal", MemberEntityIDs: []string{"entity-explicit"}},
+			MemberSelectors: &GroupMemberSelectors{
+				Entities: &metav1.LabelSelector{MatchLabels: team},
+				Groups:   &metav1.LabelSelector{MatchLabels: team},
+				KubernetesAuthEngineRoles: &KubernetesAuthEngineRoleMemberSelector{
+					Selector:          metav1.LabelSelector{MatchLabels: team},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				},
+			},
+		},
+	}
+
+	err := group.PrepareInternalValues(pivContext(kubeClient, vaultClient), group)
+	if err != nil {
+		t.Fatalf("PrepareInternalValues() unexpected error: %v", err)
+	}
+
+	payload := group.GetPayload()
+	expectedEntityIDs := []string{"entity-alice", "entity-bob", "entity-explicit", "entity-sa-prod"}
+	if !reflect.DeepEqual(payload["member_entity_ids"], expectedEntityIDs) {
+		t.Errorf("member_entity_ids = %v, expected %v", payload["member_entity_ids"], expectedEntityIDs)
+	}
+	expectedGroupIDs := []string{"group-payments-ops"}
+	if !reflect.DeepEqual(payload["member_group_ids"], expectedGroupIDs) {
+		t.Errorf("member_group_ids = %v, expected %v", payload["member_group_ids"], expectedGroupIDs)
+	}
+}
@@ -18,11 +18,18 @@ package v1alpha1
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"slices"
 
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -40,6 +47,17 @@ type GroupSpec struct {
 
 	GroupConfig `json:",inline"`
 
+	// MemberSelectors select members of the group among the resources of the namespace of the group, in addition to MemberEntityIDs and MemberGroupIDs.
+	// The members are resolved at every reconcile and kept in sync as they come and go. Only internal groups can have member selectors.
+	// +kubebuilder:validation:Optional
+	MemberSelectors *GroupMemberSelectors `json:"memberSelectors,omitempty"`
+
+	// this field is for internal use and will not be serialized
+	selectedMemberEntityIDs []string `json:"-"`
+
+	// this field is for internal use and will not be serialized
+	selectedMemberGroupIDs []string `json:"-"`
+
 	// The name of the obejct created in Vault. If this is specified it takes precedence over {metatada.name}
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Pattern:=`[a-z0-9]([-a-z0-9]*[a-z0-9])?`
@@ -78,6 +96,30 @@ type GroupConfig struct {
 	MemberEntityIDs []string `json:"memberEntityIDs,omitempty"`
 }
 
+type GroupMemberSelectors struct {
+	// Entities selects the Entity resources whose Vault entities are members of the group.
+	// +kubebuilder:validation:Optional
+	Entities *metav1.LabelSelector `json:"entities,omitempty"`
+
+	// Groups selects the Group resources whose Vault groups are members of the group. The group itself is never selected.
+	// +kubebuilder:validation:Optional
+	Groups *metav1.LabelSelector `json:"groups,omitempty"`
+
+	// KubernetesAuthEngineRoles selects the service accounts bound to KubernetesAuthEngineRoles whose Vault entities are members of the group.
+	// +kubebuilder:validation:Optional
+	KubernetesAuthEngineRoles *KubernetesAuthEngineRoleMemberSelector `json:"kubernetesAuthEngineRoles,omitempty"`
+}
+
+type KubernetesAuthEngineRoleMemberSelector struct {
+	// Selector selects the KubernetesAuthEngineRoles.
+	// +kubebuilder:validation:Required
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// NamespaceSelector restricts the service accounts to the namespaces matching this selector. If not set, the service accounts of all the namespaces bound to the roles are selected.
+	// +kubebuilder:validation:Optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
 // GroupStatus defines the observed state of Group
 type GroupStatus struct {
 	// ID is the Vault-assigned unique identifier for this identity group.
@@ -155,6 +197,10 @@ func (i *GroupSpec) toMap() map[string]any {
 	if i.Type == "internal" {
 		payload["member_group_ids"] = i.MemberGroupIDs
 		payload["member_entity_ids"] = i.MemberEntityIDs
+		if i.MemberSelectors != nil {
+			payload["member_group_ids"] = mergeMemberIDs(i.MemberGroupIDs, i.selectedMemberGroupIDs)
+			payload["member_entity_ids"] = mergeMemberIDs(i.MemberEntityIDs, i.selectedMemberEntityIDs)
+		}
 	}
 	return payload
 }
@@ -164,14 +210,211 @@ func (d *Group) IsInitialized() bool {
 }
 
 func (d *Group) PrepareInternalValues(context context.Context, object client.Object) error {
+	if d.Spec.MemberSelectors == nil || d.Spec.Type != "internal" {
+		return nil
+	}
+	log := log.FromContext(context)
+	entityIDs := []string{}
+	if d.Spec.MemberSelectors.Entities != nil {
+		ids, err := d.findSelectedEntityIDs(context)
+		if err != nil {
+			log.Error(err, "unable to resolve the selected entities", "instance", object)
+			return err
+		}
+		entityIDs = append(entityIDs, ids...)
+	}
+	if d.Spec.MemberSelectors.KubernetesAuthEngineRoles != nil {
+		ids, err := d.findSelectedServiceAccountEntityIDs(context)
+		if err != nil {
+			log.Error(err, "unable to resolve the entities of the selected service accounts", "instance", object)
+			return err
+		}
+		entityIDs = append(entityIDs, ids...)
+	}
+	groupIDs := []string{}
+	if d.Spec.MemberSelectors.Groups != nil {
+		ids, err := d.findSelectedGroupIDs(context)
+		if err != nil {
+			log.Error(err, "unable to resolve the selected groups", "instance", object)
+			return err
+		}
+		groupIDs = append(groupIDs, ids...)
+	}
+	d.Spec.selectedMemberEntityIDs = entityIDs
+	d.Spec.selectedMemberGroupIDs = groupIDs
 	return nil
 }
 
+// findSelectedEntityIDs returns the IDs of the Vault entities of the selected Entity resources.
+// Entities not created in Vault yet are skipped, the group is reconciled again when they are.
+func (d *Group) findSelectedEntityIDs(context context.Context) ([]string, error) {
+	log := log.FromContext(context)
+	labelSelector, err := metav1.LabelSelectorAsSelector(d.Spec.MemberSelectors.Entities)
+	if err != nil {
+		return nil, err
+	}
+	entityList := &EntityList{}
+	kubeClient := vaultutils.KubeClientFromContext(context)
+	err = kubeClient.List(context, entityList, &client.ListOptions{
+		Namespace:     d.Namespace,
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for i := range entityList.Items {
+		id, found, err := readIdentityID(context, entityList.Items[i].GetPath())
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			log.V(1).Info("selected entity not found in Vault, skipping", "entity", entityList.Items[i].Name)
+			continue
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+// findSelectedGroupIDs returns the IDs of the Vault groups of the selected Group resources.
+// Groups not created in Vault yet are skipped, the group is reconciled again when they are.
+func (d *Group) findSelectedGroupIDs(context context.Context) ([]string, error) {
+	log := log.FromContext(context)
+	labelSelector, err := metav1.LabelSelectorAsSelector(d.Spec.MemberSelectors.Groups)
+	if err != nil {
+		return nil, err
+	}
+	groupList := &GroupList{}
+	kubeClient := vaultutils.KubeClientFromContext(context)
+	err = kubeClient.List(context, groupList, &client.ListOptions{
+		Namespace:     d.Namespace,
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for i := range groupList.Items {
+		if groupList.Items[i].Name == d.Name {
+			continue
+		}
+		id, found, err := readIdentityID(context, groupList.Items[i].GetPath())
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			log.V(1).Info("selected group not found in Vault, skipping", "group", groupList.Items[i].Name)
+			continue
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+// findSelectedServiceAccountEntityIDs returns the IDs of the Vault entities of the service accounts bound to the selected KubernetesAuthEngineRoles.
+// The entities are looked up by their alias on the mount of each role. Service accounts that have not logged in yet have no entity and are skipped.
+func (d *Group) findSelectedServiceAccountEntityIDs(context context.Context) ([]string, error) {
+	log := log.FromContext(context)
+	selector := d.Spec.MemberSelectors.KubernetesAuthEngineRoles
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector.Selector)
+	if err != nil {
+		return nil, err
+	}
+	namespaceSelector := labels.Everything()
+	if selector.NamespaceSelector != nil {
+		namespaceSelector, err = metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+	kubeClient := vaultutils.KubeClientFromContext(context)
+	roleList := &KubernetesAuthEngineRoleList{}
+	err = kubeClient.List(context, roleList, &client.ListOptions{
+		Namespace:     d.Namespace,
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for i := range roleList.Items {
+		role := &roleList.Items[i]
+		err = role.PrepareInternalValues(context, role)
+		if err != nil {
+			return nil, err
+		}
+		secret, found, err := vaultutils.ReadSecret(context, vaultutils.CleansePath("sys/auth/"+string(role.Spec.Path)))
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			log.V(1).Info("auth engine of selected role not found, skipping", "role", role.Name, "path", role.Spec.Path)
+			continue
+		}
+		accessor, _ := secret.Data["accessor"].(string)
+		for _, namespaceName := range role.Spec.namespaces {
+			namespace := &corev1.Namespace{}
+			err = kubeClient.Get(context, types.NamespacedName{Name: namespaceName}, namespace)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if !namespaceSelector.Matches(labels.Set(namespace.Labels)) {
+				continue
+			}
+			serviceAccounts, err := role.listTargetServiceAccounts(context, namespaceName)
+			if err != nil {
+				return nil, err
+			}
+			for _, serviceAccount := range serviceAccounts {
+				secret, found, err := vaultutils.ReadSecretWithPayload(context, "identity/lookup/entity", map[string]string{
+					"alias_name":           role.GetAliasName(&serviceAccount),
+					"alias_mount_accessor": accessor,
+				})
+				if err != nil {
+					return nil, err
+				}
+				if !found {
+					log.V(1).Info("no entity found for selected service account, skipping", "namespace", namespaceName, "serviceAccount", serviceAccount.Name)
+					continue
+				}
+				if id, ok := secret.Data["id"].(string); ok {
+					result = append(result, id)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// readIdentityID returns the ID of the identity object, entity or group, at the given path.
+func readIdentityID(context context.Context, path string) (string, bool, error) {
+	secret, found, err := vaultutils.ReadSecret(context, path)
+	if err != nil || !found {
+		return "", found, err
+	}
+	id, ok := secret.Data["id"].(string)
+	return id, ok, nil
+}
+
+// mergeMemberIDs returns the sorted union of the explicit and the selected member IDs.
+func mergeMemberIDs(explicit []string, selected []string) []string {
+	result := append(slices.Clone(explicit), selected...)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
 func (d *Group) PrepareTLSConfig(context context.Context, object client.Object) error {
 	return nil
 }
 
 func (r *Group) IsValid() (bool, error) {
+	if r.Spec.MemberSelectors != nil && r.Spec.Type != "internal" {
+		return false, errors.New("member selectors can only be specified for internal groups")
+	}
 	return true, nil
 }
 
@@ -2,9 +2,13 @@ package v1alpha1
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestIdentityOIDCProviderGetPath(t *testing.T) {
@@ -566,3 +570,85 @@ func TestIdentityOIDCConditions(t *testing.T) {
 		t.Error("expected IdentityOIDCAssignment conditions to be set and retrieved")
 	}
 }
+
+func TestIdentityOIDCClientReconcilePostWriteCredentialsSecret(t *testing.T) {
+	newClient := func() *IdentityOIDCClient {
+		return &IdentityOIDCClient{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns", UID: "client-uid"},
+			Spec: IdentityOIDCClientSpec{
+				CredentialsSecret: &OIDCClientCredentialsSecret{Name: "app-oidc", Provider: "provider"},
+			},
+		}
+	}
+	provider := &IdentityOIDCProvider{ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: "ns"}}
+	handler := newFakeVaultHandler()
+	handler.setGet("identity/oidc/client/app", map[string]any{"client_id": "id", "client_secret": "secret"})
+	handler.setGet("identity/oidc/provider/provider", map[string]any{"issuer": "https://vault/v1/identity/oidc/provider/provider"})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	t.Run("publishes the credentials", func(t *testing.T) {
+		oidcClient := newClient()
+		kubeClient := newFakeKubeClient(provider)
+		ctx := pivContext(kubeClient, vc)
+		if err := oidcClient.ReconcilePostWrite(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		secret := &corev1.Secret{}
+		if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "app-oidc"}, secret); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := map[string][]byte{
+			"client_id":     []byte("id"),
+			"client_secret": []byte("secret"),
+			"issuer":        []byte("https://vault/v1/identity/oidc/provider/provider"),
+			"discovery_url": []byte("https://vault/v1/identity/oidc/provider/provider/.well-known/openid-configuration"),
+		}
+		if !reflect.DeepEqual(secret.Data, expected) {
+			t.Errorf("secret data = %v, expected %v", secret.Data, expected)
+		}
+		if !metav1.IsControlledBy(secret, oidcClient) {
+			t.Errorf("secret is not owned by the client: %v", secret.OwnerReferences)
+		}
+		if !apimeta.IsStatusConditionTrue(oidcClient.Status.Conditions, CredentialsSecretSynced) {
+			t.Errorf("expected the %s condition to be true: %v", CredentialsSecretSynced, oidcClient.Status.Conditions)
+		}
+
+		// the issuer of the provider changes
+		handler.setGet("identity/oidc/provider/provider", map[string]any{"issuer": "https://other/v1/identity/oidc/provider/provider"})
+		defer handler.setGet("identity/oidc/provider/provider", map[string]any{"issuer": "https://vault/v1/identity/oidc/provider/provider"})
+		if err := oidcClient.ReconcilePostWrite(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "app-oidc"}, secret); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(secret.Data["issuer"]) != "https://other/v1/identity/oidc/provider/provider" {
+			t.Errorf("issuer = %q, expected the new issuer", secret.Data["issuer"])
+		}
+	})
+
+	t.Run("refuses to take over a secret", func(t *testing.T) {
+		oidcClient := newClient()
+		kubeClient := newFakeKubeClient(provider, newK8sSecret("ns", "app-oidc", map[string][]byte{"other": []byte("data")}))
+		err := oidcClient.ReconcilePostWrite(pivContext(kubeClient, vc))
+		if err == nil || !strings.Contains(err.Error(), "is not owned by the IdentityOIDCClient") {
+			t.Errorf("expected an ownership error, got %v", err)
+		}
+		if !apimeta.IsStatusConditionFalse(oidcClient.Status.Conditions, CredentialsSecretSynced) {
+			t.Errorf("expected the %s condition to be false: %v", CredentialsSecretSynced, oidcClient.Status.Conditions)
+		}
+	})
+
+	t.Run("without credentials secret", func(t *testing.T) {
+		oidcClient := newClient()
+		oidcClient.Spec.CredentialsSecret = nil
+		oidcClient.Status.Conditions = []metav1.Condition{{Type: CredentialsSecretSynced, Status: metav1.ConditionTrue}}
+		if err := oidcClient.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if apimeta.FindStatusCondition(oidcClient.Status.Conditions, CredentialsSecretSynced) != nil {
+			t.Errorf("expected the %s condition to be removed", CredentialsSecretSynced)
+		}
+	})
+}
@@ -18,11 +18,17 @@ package v1alpha1
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
 
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // IdentityOIDCClientSpec defines the desired state of IdentityOIDCClient
@@ -38,6 +44,10 @@ type IdentityOIDCClientSpec struct {
 
 	IdentityOIDCClientConfig `json:",inline"`
 
+	// CredentialsSecret is the Kubernetes secret where the client_id and client_secret of the client are published, along with the issuer and discovery URLs of the provider.
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *OIDCClientCredentialsSecret `json:"credentialsSecret,omitempty"`
+
 	// The name of the obejct created in Vault. If this is specified it takes precedence over {metatada.name}
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Pattern:=`[a-z0-9]([-a-z0-9]*[a-z0-9])?`
@@ -86,6 +96,18 @@ type IdentityOIDCClientConfig struct {
 	AccessTokenTTL string `json:"accessTokenTTL"`
 }
 
+type OIDCClientCredentialsSecret struct {
+	// Name is the name of the Kubernetes secret, in the same namespace, owned by the IdentityOIDCClient.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Provider is the name of the IdentityOIDCProvider, in the same namespace, whose issuer and discovery URLs are published.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Provider string `json:"provider"`
+}
+
 // IdentityOIDCClientStatus defines the observed state of IdentityOIDCClient
 type IdentityOIDCClientStatus struct {
 	// +patchMergeKey=type
@@ -122,6 +144,15 @@ func init() {
 
 var _ vaultutils.VaultObject = &IdentityOIDCClient{}
 var _ vaultutils.ConditionsAware = &IdentityOIDCClient{}
+var _ vaultutils.VaultPostWriteReconciler = &IdentityOIDCClient{}
+
+const (
+	// CredentialsSecretSynced is true when the credentials of the client are published to the credentials secret.
+	CredentialsSecretSynced = "CredentialsSecretSynced"
+
+	CredentialsSecretSyncedReason    = "Synced"
+	CredentialsSecretNotSyncedReason = "SyncFailed"
+)
 
 func (m *IdentityOIDCClient) GetConditions() []metav1.Condition {
 	return m.Status.Conditions
@@ -185,3 +216,98 @@ func (d *IdentityOIDCClient) IsEquivalentToDesiredState(payload map[string]any)
 	desiredState := d.Spec.toMap()
 	return reflect.DeepEqual(desiredState, filterPayloadToDesiredKeys(desiredState, payload))
 }
+
+// ReconcilePostWrite publishes the credentials of the client to the credentials secret, when CredentialsSecret is set.
+func (d *IdentityOIDCClient) ReconcilePostWrite(ctx context.Context) error {
+	if d.Spec.CredentialsSecret == nil {
+		apimeta.RemoveStatusCondition(&d.Status.Conditions, CredentialsSecretSynced)
+		return nil
+	}
+	err := d.syncCredentialsSecret(ctx)
+	condition := metav1.Condition{
+		Type:               CredentialsSecretSynced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: d.GetGeneration(),
+		Reason:             CredentialsSecretSyncedReason,
+		Message:            fmt.Sprintf("credentials published to secret %s", d.Spec.CredentialsSecret.Name),
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = CredentialsSecretNotSyncedReason
+		condition.Message = err.Error()
+	}
+	apimeta.SetStatusCondition(&d.Status.Conditions, condition)
+	return err
+}
+
+// GetCredentialsSecretData returns the data of the credentials secret, read from the client and the provider in Vault.
+func (d *IdentityOIDCClient) GetCredentialsSecretData(ctx context.Context) (map[string][]byte, error) {
+	clientSecret, found, err := vaultutils.ReadSecret(ctx, d.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("client %s not found in Vault", d.GetPath())
+	}
+	clientID, _ := clientSecret.Data["client_id"].(string)
+	if clientID == "" {
+		return nil, errors.New("no client_id returned by Vault for the client")
+	}
+
+	kubeClient := vaultutils.KubeClientFromContext(ctx)
+	provider := &IdentityOIDCProvider{}
+	err = kubeClient.Get(ctx, types.NamespacedName{Namespace: d.Namespace, Name: d.Spec.CredentialsSecret.Provider}, provider)
+	if err != nil {
+		return nil, err
+	}
+	providerSecret, found, err := vaultutils.ReadSecret(ctx, provider.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("IdentityOIDCProvider %s has not been created in Vault yet", provider.Name)
+	}
+	issuer, _ := providerSecret.Data["issuer"].(string)
+	if issuer == "" {
+		return nil, fmt.Errorf("no issuer returned by Vault for the provider %s", provider.Name)
+	}
+
+	data := map[string][]byte{
+		"client_id":     []byte(clientID),
+		"issuer":        []byte(issuer),
+		"discovery_url": []byte(issuer + "/.well-known/openid-configuration"),
+	}
+	// public clients have no secret
+	if secret, ok := clientSecret.Data["client_secret"].(string); ok && secret != "" {
+		data["client_secret"] = []byte(secret)
+	}
+	return data, nil
+}
+
+// syncCredentialsSecret creates or updates the credentials secret, owned by the client.
+func (d *IdentityOIDCClient) syncCredentialsSecret(ctx context.Context) error {
+	data, err := d.GetCredentialsSecretData(ctx)
+	if err != nil {
+		return err
+	}
+	kubeClient := vaultutils.KubeClientFromContext(ctx)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      d.Spec.CredentialsSecret.Name,
+			Namespace: d.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, kubeClient, secret, func() error {
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, d) {
+			return fmt.Errorf("secret %s already exists and is not owned by the IdentityOIDCClient", secret.Name)
+		}
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels["redhatcop.redhat.io/identityoidcclients"] = d.Name
+		secret.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(d, GroupVersion.WithKind("IdentityOIDCClient"))}
+		secret.Data = data
+		return nil
+	})
+	return err
+}
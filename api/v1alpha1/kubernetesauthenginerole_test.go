@@ -2,12 +2,17 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -328,3 +333,425 @@ func TestKubernetesAuthEngineRole_PrepareInternalValues_LabelSelectorNoMatch(t *
 		t.Fatalf("namespaces: got %v", role.Spec.namespaces)
 	}
 }
+
+// fakeIdentityHandler keeps the entities and the entity aliases written to it, to check the entities of service accounts.
+type fakeIdentityHandler struct {
+	// accessors are the accessors of the auth engine mounts, by path
+	accessors map[string]string
+	entities  map[string]map[string]any
+	aliases   map[string]string
+	nextID    int
+}
+
+func newFakeIdentityHandler() *fakeIdentityHandler {
+	return &fakeIdentityHandler{
+		accessors: map[string]string{"kubernetes": "auth_kubernetes_1234"},
+		entities:  map[string]map[string]any{},
+		aliases:   map[string]string{},
+	}
+}
+
+func (h *fakeIdentityHandler) entityID(name string) string {
+	if entity, ok := h.entities[name]; ok {
+		return entity["id"].(string)
+	}
+	return ""
+}
+
+func (h *fakeIdentityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	body := map[string]any{}
+	_ = json.NewDecoder(r.Body).Decode(&body) // test handler; GET and DELETE requests have no body
+	respond := func(data map[string]any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data}) // test handler; encode error is not actionable
+	}
+	switch {
+	case strings.HasPrefix(path, "sys/auth/"):
+		accessor, ok := h.accessors[strings.TrimPrefix(path, "sys/auth/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		respond(map[string]any{"accessor": accessor})
+	case path == "identity/lookup/entity":
+		id, ok := h.aliases[body["alias_mount_accessor"].(string)+":"+body["alias_name"].(string)]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		respond(map[string]any{"id": id})
+	case path == "identity/entity-alias":
+		h.aliases[body["mount_accessor"].(string)+":"+body["name"].(string)] = body["canonical_id"].(string)
+		respond(map[string]any{"id": "alias"})
+	case strings.HasPrefix(path, "identity/entity/name/"):
+		name := strings.TrimPrefix(path, "identity/entity/name/")
+		switch r.Method {
+		case http.MethodGet:
+			entity, ok := h.entities[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			respond(entity)
+		case http.MethodDelete:
+			id := h.entityID(name)
+			delete(h.entities, name)
+			for alias, entityID := range h.aliases {
+				if entityID == id {
+					delete(h.aliases, alias)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			entity, ok := h.entities[name]
+			if !ok {
+				h.nextID++
+				entity = map[string]any{"id": fmt.Sprintf("entity-%d", h.nextID)}
+				h.entities[name] = entity
+			}
+			// like Vault, an update keeps the fields it does not set
+			for key, value := range body {
+				entity[key] = value
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestKubernetesAuthEngineRoleServiceAccountEntities(t *testing.T) {
+	app := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app", UID: "uid-app"}}
+	kubeClient := newFakeKubeClient(
+		app,
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "app", UID: "uid-app-b"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "unbound", UID: "uid-unbound"}},
+	)
+	handler := newFakeIdentityHandler()
+	vaultClient, server := newFakeVaultClient(t, handler)
+	defer server.Close()
+	ctx := pivContext(kubeClient, vaultClient)
+
+	role := &KubernetesAuthEngineRole{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: "apps"},
+		Spec: KubernetesAuthEngineRoleSpec{
+			Path:             "kubernetes",
+			TargetNamespaces: vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"team-a", "team-b"}},
+			VRole:            VRole{TargetServiceAccounts: []string{"app", "absent"}, AliasNameSource: "serviceaccount_uid"},
+			ServiceAccountEntities: &ServiceAccountEntitiesConfig{
+				Metadata: map[string]string{"cluster": "east"},
+				Policies: []string{"apps"},
+			},
+		},
+	}
+	if err := role.PrepareInternalValues(ctx, role); err != nil {
+		t.Fatalf("PrepareInternalValues() unexpected error: %v", err)
+	}
+
+	if err := role.ReconcilePostWrite(ctx); err != nil {
+		t.Fatalf("ReconcilePostWrite() unexpected error: %v", err)
+	}
+	if len(role.Status.ServiceAccountEntities) != 2 {
+		t.Fatalf("expected 2 service account entities, got %v", role.Status.ServiceAccountEntities)
+	}
+	entity := handler.entities["auth_kubernetes_1234.team-a.app"]
+	if entity == nil {
+		t.Fatalf("entity auth_kubernetes_1234.team-a.app not created, got %v", handler.entities)
+	}
+	expectedMetadata := map[string]any{
+		"cluster":                      "east",
+		"service_account_namespace":    "team-a",
+		"service_account_name":         "app",
+		"service_account_uid":          "uid-app",
+		"kubernetes_auth_engine_roles": "vault-admin/apps",
+	}
+	if !reflect.DeepEqual(entity["metadata"], expectedMetadata) {
+		t.Errorf("entity metadata = %v, expected %v", entity["metadata"], expectedMetadata)
+	}
+	if handler.aliases["auth_kubernetes_1234:uid-app"] != handler.entityID("auth_kubernetes_1234.team-a.app") {
+		t.Errorf("alias uid-app not bound to the entity, got %v", handler.aliases)
+	}
+	if role.Status.ServiceAccountEntities[0].EntityID != handler.entityID("auth_kubernetes_1234.team-a.app") {
+		t.Errorf("status entity ID = %q", role.Status.ServiceAccountEntities[0].EntityID)
+	}
+	if c := apimeta.FindStatusCondition(role.Status.Conditions, ServiceAccountEntitiesSynced); c == nil || c.Status != metav1.ConditionTrue {
+		t.Errorf("expected condition %s to be true, got %v", ServiceAccountEntitiesSynced, c)
+	}
+
+	// a second reconcile is idempotent
+	if err := role.ReconcilePostWrite(ctx); err != nil {
+		t.Fatalf("ReconcilePostWrite() unexpected error: %v", err)
+	}
+	if len(handler.entities) != 2 || len(handler.aliases) != 2 {
+		t.Errorf("expected 2 entities and 2 aliases, got %v and %v", handler.entities, handler.aliases)
+	}
+
+	// the entity of a deleted service account is deleted
+	if err := kubeClient.Delete(ctx, app); err != nil {
+		t.Fatalf("unable to delete service account: %v", err)
+	}
+	if err := role.ReconcilePostWrite(ctx); err != nil {
+		t.Fatalf("ReconcilePostWrite() unexpected error: %v", err)
+	}
+	if _, ok := handler.entities["auth_kubernetes_1234.team-a.app"]; ok {
+		t.Error("expected the entity of the deleted service account to be deleted")
+	}
+	if len(role.Status.ServiceAccountEntities) != 1 || role.Status.ServiceAccountEntities[0].Namespace != "team-b" {
+		t.Errorf("unexpected service account entities %v", role.Status.ServiceAccountEntities)
+	}
+
+	// the remaining entities are deleted with the role
+	if err := role.DeleteDependents(ctx); err != nil {
+		t.Fatalf("DeleteDependents() unexpected error: %v", err)
+	}
+	if len(handler.entities) != 0 || len(handler.aliases) != 0 || role.Status.ServiceAccountEntities != nil {
+		t.Errorf("expected all entities to be deleted, got %v and %v", handler.entities, role.Status.ServiceAccountEntities)
+	}
+}
+
+func TestKubernetesAuthEngineRoleServiceAccountEntitiesAliasConflict(t *testing.T) {
+	kubeClient := newFakeKubeClient(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app", UID: "uid-app"}},
+	)
+	handler := newFakeIdentityHandler()
+	// Vault already created an entity for the service account at its first login
+	handler.aliases["auth_kubernetes_1234:team-a/app"] = "entity-anonymous"
+	vaultClient, server := newFakeVaultClient(t, handler)
+	defer server.Close()
+	ctx := pivContext(kubeClient, vaultClient)
+
+	role := &KubernetesAuthEngineRole{
+		Spec: KubernetesAuthEngineRoleSpec{
+			Path:                   "kubernetes",
+			TargetNamespaces:       vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"team-a"}},
+			VRole:                  VRole{TargetServiceAccounts: []string{"app"}, AliasNameSource: "serviceaccount_name"},
+			ServiceAccountEntities: &ServiceAccountEntitiesConfig{},
+		},
+	}
+	if err := role.PrepareInternalValues(ctx, role); err != nil {
+		t.Fatalf("PrepareInternalValues() unexpected error: %v", err)
+	}
+
+	err := role.ReconcilePostWrite(ctx)
+	if err == nil || !strings.Contains(err.Error(), "already belongs to entity entity-anonymous") {
+		t.Fatalf("expected an alias conflict, got %v", err)
+	}
+	if c := apimeta.FindStatusCondition(role.Status.Conditions, ServiceAccountEntitiesSynced); c == nil || c.Status != metav1.ConditionFalse {
+		t.Errorf("expected condition %s to be false, got %v", ServiceAccountEntitiesSynced, c)
+	}
+}
+
+func TestKubernetesAuthEngineRoleServiceAccountEntitiesSharedByRoles(t *testing.T) {
+	kubeClient := newFakeKubeClient(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app", UID: "uid-app"}},
+	)
+	handler := newFakeIdentityHandler()
+	vaultClient, server := newFakeVaultClient(t, handler)
+	defer server.Close()
+	ctx := pivContext(kubeClient, vaultClient)
+
+	newRole := func(name string) *KubernetesAuthEngineRole {
+		role := &KubernetesAuthEngineRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: name},
+			Spec: KubernetesAuthEngineRoleSpec{
+				Path:                   "kubernetes",
+				TargetNamespaces:       vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"team-a"}},
+				VRole:                  VRole{TargetServiceAccounts: []string{"app"}, AliasNameSource: "serviceaccount_uid"},
+				ServiceAccountEntities: &ServiceAccountEntitiesConfig{},
+			},
+		}
+		if err := role.PrepareInternalValues(ctx, role); err != nil {
+			t.Fatalf("PrepareInternalValues() unexpected error: %v", err)
+		}
+		if err := role.ReconcilePostWrite(ctx); err != nil {
+			t.Fatalf("ReconcilePostWrite() unexpected error: %v", err)
+		}
+		return role
+	}
+	reader := newRole("reader")
+	writer := newRole("writer")
+	if len(handler.entities) != 1 || len(handler.aliases) != 1 {
+		t.Fatalf("expected the roles to share 1 entity and 1 alias, got %v and %v", handler.entities, handler.aliases)
+	}
+	owners := func() any {
+		return handler.entities["auth_kubernetes_1234.team-a.app"]["metadata"].(map[string]any)["kubernetes_auth_engine_roles"]
+	}
+	if owners() != "vault-admin/reader,vault-admin/writer" {
+		t.Errorf("entity owners = %v", owners())
+	}
+
+	// disabling the entities of a role keeps the entity of the other role
+	reader.Spec.ServiceAccountEntities = nil
+	if err := reader.ReconcilePostWrite(ctx); err != nil {
+		t.Fatalf("ReconcilePostWrite() unexpected error: %v", err)
+	}
+	if handler.entityID("auth_kubernetes_1234.team-a.app") != writer.Status.ServiceAccountEntities[0].EntityID {
+		t.Fatalf("expected the entity of the writer role to be kept, got %v", handler.entities)
+	}
+	if owners() != "vault-admin/writer" {
+		t.Errorf("entity owners = %v", owners())
+	}
+
+	// the entity is deleted with its last owner
+	if err := writer.DeleteDependents(ctx); err != nil {
+		t.Fatalf("DeleteDependents() unexpected error: %v", err)
+	}
+	if len(handler.entities) != 0 || len(handler.aliases) != 0 {
+		t.Errorf("expected the entity to be deleted, got %v and %v", handler.entities, handler.aliases)
+	}
+}
+
+func TestKubernetesAuthEngineRoleServiceAccountEntitiesPerMount(t *testing.T) {
+	kubeClient := newFakeKubeClient(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app-api", UID: "uid-app-api"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app-worker", UID: "uid-app-worker"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "default", UID: "uid-default"}},
+	)
+	handler := newFakeIdentityHandler()
+	// the dashes replacing the slashes of a path would make the entities of these mounts collide
+	handler.accessors = map[string]string{"a/b": "auth_kubernetes_ab12", "a-b": "auth_kubernetes_cd34"}
+	vaultClient, server := newFakeVaultClient(t, handler)
+	defer server.Close()
+	ctx := pivContext(kubeClient, vaultClient)
+
+	for _, path := range []string{"a/b", "a-b"} {
+		role := &KubernetesAuthEngineRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin", Name: strings.ReplaceAll(path, "/", "-") + "-apps"},
+			Spec: KubernetesAuthEngineRoleSpec{
+				Path:                   vaultutils.Path(path),
+				TargetNamespaces:       vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"team-a"}},
+				VRole:                  VRole{TargetServiceAccounts: []string{"app-*"}, AliasNameSource: "serviceaccount_uid"},
+				ServiceAccountEntities: &ServiceAccountEntitiesConfig{},
+			},
+		}
+		if err := role.PrepareInternalValues(ctx, role); err != nil {
+			t.Fatalf("PrepareInternalValues() unexpected error: %v", err)
+		}
+		if err := role.ReconcilePostWrite(ctx); err != nil {
+			t.Fatalf("ReconcilePostWrite() unexpected error: %v", err)
+		}
+		if len(role.Status.ServiceAccountEntities) != 2 {
+			t.Fatalf("expected the entities of the 2 service accounts matching app-*, got %v", role.Status.ServiceAccountEntities)
+		}
+	}
+
+	names := []string{}
+	for name := range handler.entities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	expected := []string{
+		"auth_kubernetes_ab12.team-a.app-api",
+		"auth_kubernetes_ab12.team-a.app-worker",
+		"auth_kubernetes_cd34.team-a.app-api",
+		"auth_kubernetes_cd34.team-a.app-worker",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("entities = %v, expected %v", names, expected)
+	}
+}
+
+func TestKubernetesAuthEngineRoleBindsServiceAccount(t *testing.T) {
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"}}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}}
+
+	tests := []struct {
+		name     string
+		spec     KubernetesAuthEngineRoleSpec
+		expected bool
+	}{
+		{
+			name: "listed namespace",
+			spec: KubernetesAuthEngineRoleSpec{
+				VRole:            VRole{TargetServiceAccounts: []string{"app"}},
+				TargetNamespaces: vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"team-a"}},
+			},
+			expected: true,
+		},
+		{
+			name: "other service account",
+			spec: KubernetesAuthEngineRoleSpec{
+				VRole:            VRole{TargetServiceAccounts: []string{"default"}},
+				TargetNamespaces: vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"team-a"}},
+			},
+		},
+		{
+			name: "matching glob",
+			spec: KubernetesAuthEngineRoleSpec{
+				VRole:            VRole{TargetServiceAccounts: []string{"default", "ap*"}},
+				TargetNamespaces: vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"team-a"}},
+			},
+			expected: true,
+		},
+		{
+			name: "wildcard",
+			spec: KubernetesAuthEngineRoleSpec{
+				VRole:            VRole{TargetServiceAccounts: []string{"*"}},
+				TargetNamespaces: vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"team-a"}},
+			},
+			expected: true,
+		},
+		{
+			name: "other glob",
+			spec: KubernetesAuthEngineRoleSpec{
+				VRole:            VRole{TargetServiceAccounts: []string{"web-*"}},
+				TargetNamespaces: vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"team-a"}},
+			},
+		},
+		{
+			name: "matching namespace selector",
+			spec: KubernetesAuthEngineRoleSpec{
+				VRole:            VRole{TargetServiceAccounts: []string{"app"}},
+				TargetNamespaces: vaultutils.TargetNamespaceConfig{TargetNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+			},
+			expected: true,
+		},
+		{
+			name: "other namespace selector",
+			spec: KubernetesAuthEngineRoleSpec{
+				VRole:            VRole{TargetServiceAccounts: []string{"app"}},
+				TargetNamespaces: vaultutils.TargetNamespaceConfig{TargetNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := &KubernetesAuthEngineRole{Spec: tt.spec}
+			binds, err := role.BindsServiceAccount(serviceAccount, namespace)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if binds != tt.expected {
+				t.Errorf("BindsServiceAccount() = %v, want %v", binds, tt.expected)
+			}
+		})
+	}
+}
+
+func TestKubernetesAuthEngineRoleListTargetServiceAccounts(t *testing.T) {
+	kubeClient := newFakeKubeClient(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "app-api", Namespace: "team-a"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "app-worker", Namespace: "team-a"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "team-a"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "app-api", Namespace: "team-b"}},
+	)
+	vc, ts := newFakeVaultClient(t, newFakeVaultHandler())
+	defer ts.Close()
+	ctx := pivContext(kubeClient, vc)
+
+	role := &KubernetesAuthEngineRole{Spec: KubernetesAuthEngineRoleSpec{VRole: VRole{TargetServiceAccounts: []string{"app-*", "missing"}}}}
+	serviceAccounts, err := role.listTargetServiceAccounts(ctx, "team-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := []string{}
+	for _, serviceAccount := range serviceAccounts {
+		names = append(names, serviceAccount.Namespace+"/"+serviceAccount.Name)
+	}
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"team-a/app-api", "team-a/app-worker"}) {
+		t.Errorf("listTargetServiceAccounts() = %v", names)
+	}
+}
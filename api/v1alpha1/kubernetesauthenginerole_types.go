@@ -19,11 +19,19 @@ package v1alpha1
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
+	vault "github.com/hashicorp/vault/api"
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	"github.com/ryanuber/go-glob"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -54,14 +62,33 @@ type KubernetesAuthEngineRoleSpec struct {
 	// +kubebuilder:validation:Required
 	TargetNamespaces vaultutils.TargetNamespaceConfig `json:"targetNamespaces,omitempty"`
 
+	// ServiceAccountEntities, when set, makes the operator create a Vault entity and an entity alias on the auth engine mount for each target service account of the role in the target namespaces, instead of letting Vault create them at the first login.
+	// This way policies can use entity templating from day one. The entities are shared by the roles of the mount binding the service accounts, and are deleted when no such role owns them anymore, as the service accounts are deleted, are no longer bound to the roles, or the roles are deleted.
+	// +kubebuilder:validation:Optional
+	ServiceAccountEntities *ServiceAccountEntitiesConfig `json:"serviceAccountEntities,omitempty"`
+
 	// The name of the obejct created in Vault. If this is specified it takes precedence over {metatada.name}
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Pattern:=`[a-z0-9]([-a-z0-9]*[a-z0-9])?`
 	Name string `json:"name,omitempty"`
 }
 
+type ServiceAccountEntitiesConfig struct {
+	// Metadata is added to the metadata of the entities, along with service_account_namespace, service_account_name and service_account_uid.
+	// +kubebuilder:validation:Optional
+	// +mapType=granular
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Policies are the policies of the entities, granted in addition to the policies of the role.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Policies []string `json:"policies,omitempty"`
+}
+
 var _ vaultutils.VaultObject = &KubernetesAuthEngineRole{}
 var _ vaultutils.ConditionsAware = &KubernetesAuthEngineRole{}
+var _ vaultutils.VaultPostWriteReconciler = &KubernetesAuthEngineRole{}
+var _ vaultutils.VaultDependentsDeleter = &KubernetesAuthEngineRole{}
 
 func (d *KubernetesAuthEngineRole) GetVaultConnection() *vaultutils.VaultConnection {
 	return d.Spec.Connection
@@ -144,7 +171,7 @@ func (r *KubernetesAuthEngineRole) IsValid() (bool, error) {
 
 type VRole struct {
 
-	// TargetServiceAccounts is a list of service account names that will receive this role
+	// TargetServiceAccounts is a list of service account names that will receive this role. Names can contain * globs, for example app-*, matched as Vault does.
 	// +kubebuilder:validation:MinItems=1
 	// kubebuilder:validation:UniqueItems=true
 	// +kubebuilder:default={"default"}
@@ -219,8 +246,38 @@ type KubernetesAuthEngineRoleStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// ServiceAccountEntities are the entities created for the service accounts bound to this role.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	ServiceAccountEntities []ServiceAccountEntity `json:"serviceAccountEntities,omitempty"`
+}
+
+type ServiceAccountEntity struct {
+	// Namespace is the namespace of the service account.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the service account.
+	Name string `json:"name"`
+
+	// UID is the UID of the service account.
+	UID types.UID `json:"uid"`
+
+	// EntityName is the name of the Vault entity of the service account.
+	EntityName string `json:"entityName"`
+
+	// EntityID is the ID of the Vault entity of the service account.
+	EntityID string `json:"entityID"`
 }
 
+const (
+	// ServiceAccountEntitiesSynced is true when the entities of all the service accounts bound to the role are created.
+	ServiceAccountEntitiesSynced = "ServiceAccountEntitiesSynced"
+
+	ServiceAccountEntitiesSyncedReason    = "Synced"
+	ServiceAccountEntitiesNotSyncedReason = "SyncFailed"
+)
+
 func (m *KubernetesAuthEngineRole) GetConditions() []metav1.Condition {
 	return m.Status.Conditions
 }
@@ -296,6 +353,313 @@ func (r *KubernetesAuthEngineRole) validateEitherTargetNamespaceSelectorOrTarget
 	return nil
 }
 
+// ReconcilePostWrite creates the entities of the service accounts bound to the role, and deletes the entities of the service accounts that are gone, when ServiceAccountEntities is set.
+func (d *KubernetesAuthEngineRole) ReconcilePostWrite(ctx context.Context) error {
+	if d.Spec.ServiceAccountEntities == nil {
+		apimeta.RemoveStatusCondition(&d.Status.Conditions, ServiceAccountEntitiesSynced)
+		return d.DeleteDependents(ctx)
+	}
+	err := d.syncServiceAccountEntities(ctx)
+	condition := metav1.Condition{
+		Type:               ServiceAccountEntitiesSynced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: d.GetGeneration(),
+		Reason:             ServiceAccountEntitiesSyncedReason,
+		Message:            fmt.Sprintf("%d service account entities synced", len(d.Status.ServiceAccountEntities)),
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ServiceAccountEntitiesNotSyncedReason
+		condition.Message = err.Error()
+	}
+	apimeta.SetStatusCondition(&d.Status.Conditions, condition)
+	return err
+}
+
+// DeleteDependents releases the entities created for the service accounts bound to the role, deleting those no other role owns.
+func (d *KubernetesAuthEngineRole) DeleteDependents(ctx context.Context) error {
+	var errs []error
+	remaining := []ServiceAccountEntity{}
+	for _, entity := range d.Status.ServiceAccountEntities {
+		err := d.releaseServiceAccountEntity(ctx, entity)
+		if err != nil {
+			errs = append(errs, err)
+			remaining = append(remaining, entity)
+		}
+	}
+	d.Status.ServiceAccountEntities = remaining
+	if len(remaining) == 0 {
+		d.Status.ServiceAccountEntities = nil
+	}
+	return errors.Join(errs...)
+}
+
+// syncServiceAccountEntities creates or updates the entity and the alias of each target service account of the role in the target namespaces, and deletes the entities of the service accounts no longer bound to the role.
+func (d *KubernetesAuthEngineRole) syncServiceAccountEntities(ctx context.Context) error {
+	secret, found, err := vaultutils.ReadSecret(ctx, vaultutils.CleansePath("sys/auth/"+string(d.Spec.Path)))
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("auth engine not found at path %s", d.Spec.Path)
+	}
+	accessor, _ := secret.Data["accessor"].(string)
+	var errs []error
+	synced := []ServiceAccountEntity{}
+	for _, namespace := range d.Spec.namespaces {
+		serviceAccounts, err := d.listTargetServiceAccounts(ctx, namespace)
+		if err != nil {
+			errs = append(errs, err)
+			// the entities of the namespace are kept in status, so that they are not released because the service accounts could not be listed
+			for _, entity := range d.Status.ServiceAccountEntities {
+				if entity.Namespace == namespace {
+					synced = append(synced, entity)
+				}
+			}
+			continue
+		}
+		for _, serviceAccount := range serviceAccounts {
+			entity, err := d.syncServiceAccountEntity(ctx, &serviceAccount, accessor)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("unable to sync the entity of service account %s/%s: %w", namespace, serviceAccount.Name, err))
+				// the entity may exist from a previous reconcile, it is kept in status so that it is deleted eventually
+				if i := slices.IndexFunc(d.Status.ServiceAccountEntities, func(e ServiceAccountEntity) bool {
+					return e.Namespace == namespace && e.Name == serviceAccount.Name
+				}); i >= 0 {
+					synced = append(synced, d.Status.ServiceAccountEntities[i])
+				}
+				continue
+			}
+			synced = append(synced, entity)
+		}
+	}
+	for _, entity := range d.Status.ServiceAccountEntities {
+		if slices.ContainsFunc(synced, func(e ServiceAccountEntity) bool { return e.EntityName == entity.EntityName }) {
+			continue
+		}
+		err := d.releaseServiceAccountEntity(ctx, entity)
+		if err != nil {
+			errs = append(errs, err)
+			synced = append(synced, entity)
+		}
+	}
+	d.Status.ServiceAccountEntities = synced
+	if len(synced) == 0 {
+		d.Status.ServiceAccountEntities = nil
+	}
+	return errors.Join(errs...)
+}
+
+// syncServiceAccountEntity creates or updates the entity of the service account and its alias on the auth engine mount with the given accessor.
+// The entity is shared by the roles of the auth engine mount binding the service account, the role is added to the owners recorded in its metadata.
+// The entity of a service account recreated with the same name is recreated, so that it does not keep the alias of the previous service account.
+func (d *KubernetesAuthEngineRole) syncServiceAccountEntity(ctx context.Context, serviceAccount *corev1.ServiceAccount, accessor string) (ServiceAccountEntity, error) {
+	log := log.FromContext(ctx)
+	entity := ServiceAccountEntity{
+		Namespace:  serviceAccount.Namespace,
+		Name:       serviceAccount.Name,
+		UID:        serviceAccount.UID,
+		EntityName: getServiceAccountEntityName(serviceAccount, accessor),
+	}
+	vaultClient := vaultutils.VaultClientFromContext(ctx)
+	entityPath := vaultutils.CleansePath("identity/entity/name/" + entity.EntityName)
+	existingMetadata, found, err := readEntityMetadata(ctx, entityPath)
+	if err != nil {
+		return entity, err
+	}
+	owners := []string{}
+	if found {
+		if existingMetadata["service_account_uid"] != string(serviceAccount.UID) {
+			log.V(1).Info("service account was recreated, recreating its entity", "namespace", entity.Namespace, "name", entity.Name)
+			err := deleteServiceAccountEntity(ctx, entity)
+			if err != nil {
+				return entity, err
+			}
+		} else {
+			owners = parseEntityOwners(existingMetadata)
+		}
+	}
+	metadata := map[string]string{}
+	for key, value := range d.Spec.ServiceAccountEntities.Metadata {
+		metadata[key] = value
+	}
+	metadata["service_account_namespace"] = serviceAccount.Namespace
+	metadata["service_account_name"] = serviceAccount.Name
+	metadata["service_account_uid"] = string(serviceAccount.UID)
+	metadata[entityOwnersMetadataKey] = formatEntityOwners(append(owners, d.getEntityOwner()))
+	policies := d.Spec.ServiceAccountEntities.Policies
+	if policies == nil {
+		policies = []string{}
+	}
+	_, err = vaultClient.Logical().WriteWithContext(ctx, entityPath, map[string]any{
+		"metadata": metadata,
+		"policies": policies,
+	})
+	if err != nil {
+		return entity, err
+	}
+	id, found, err := readIdentityID(ctx, entityPath)
+	if err != nil {
+		return entity, err
+	}
+	if !found {
+		return entity, fmt.Errorf("entity %s not found after its creation", entity.EntityName)
+	}
+	entity.EntityID = id
+	aliasName := d.GetAliasName(serviceAccount)
+	secret, found, err := vaultutils.ReadSecretWithPayload(ctx, "identity/lookup/entity", map[string]string{
+		"alias_name":           aliasName,
+		"alias_mount_accessor": accessor,
+	})
+	if err != nil {
+		return entity, err
+	}
+	if found {
+		if existingID, _ := secret.Data["id"].(string); existingID != entity.EntityID {
+			return entity, fmt.Errorf("alias %s already belongs to entity %s", aliasName, existingID)
+		}
+		return entity, nil
+	}
+	_, err = vaultClient.Logical().WriteWithContext(ctx, "identity/entity-alias", map[string]any{
+		"name":           aliasName,
+		"canonical_id":   entity.EntityID,
+		"mount_accessor": accessor,
+	})
+	return entity, err
+}
+
+// getServiceAccountEntityName returns the name of the entity of the service account, unique per auth engine mount.
+// The mount is identified by its accessor, which unlike its path contains no separator, and namespace names cannot contain dots, so the name is not ambiguous.
+func getServiceAccountEntityName(serviceAccount *corev1.ServiceAccount, accessor string) string {
+	return accessor + "." + serviceAccount.Namespace + "." + serviceAccount.Name
+}
+
+// entityOwnersMetadataKey is the metadata key of the service account entities listing the roles sharing the entity.
+const entityOwnersMetadataKey = "kubernetes_auth_engine_roles"
+
+// getEntityOwner returns the identifier of the role in the owners of the service account entities.
+func (d *KubernetesAuthEngineRole) getEntityOwner() string {
+	return d.Namespace + "/" + d.Name
+}
+
+// parseEntityOwners returns the owners recorded in the metadata of a service account entity.
+func parseEntityOwners(metadata map[string]string) []string {
+	if metadata[entityOwnersMetadataKey] == "" {
+		return []string{}
+	}
+	return strings.Split(metadata[entityOwnersMetadataKey], ",")
+}
+
+// formatEntityOwners returns the metadata value of the given owners, sorted and without duplicates.
+func formatEntityOwners(owners []string) string {
+	owners = slices.Clone(owners)
+	slices.Sort(owners)
+	return strings.Join(slices.Compact(owners), ",")
+}
+
+// readEntityMetadata reads the metadata of the entity at the given path.
+func readEntityMetadata(ctx context.Context, path string) (map[string]string, bool, error) {
+	secret, found, err := vaultutils.ReadSecret(ctx, path)
+	if err != nil || !found {
+		return nil, found, err
+	}
+	metadata := map[string]string{}
+	if data, ok := secret.Data["metadata"].(map[string]any); ok {
+		for key, value := range data {
+			metadata[key], _ = value.(string)
+		}
+	}
+	return metadata, true, nil
+}
+
+// releaseServiceAccountEntity removes the role from the owners of the entity of a service account, and deletes the entity when no other role owns it.
+func (d *KubernetesAuthEngineRole) releaseServiceAccountEntity(ctx context.Context, entity ServiceAccountEntity) error {
+	entityPath := vaultutils.CleansePath("identity/entity/name/" + entity.EntityName)
+	metadata, found, err := readEntityMetadata(ctx, entityPath)
+	if err != nil {
+		return fmt.Errorf("unable to read entity %s: %w", entity.EntityName, err)
+	}
+	if !found {
+		return nil
+	}
+	// the entity of a service account recreated since is not the one the role synced
+	if metadata["service_account_uid"] != string(entity.UID) {
+		return nil
+	}
+	owners := slices.DeleteFunc(parseEntityOwners(metadata), func(owner string) bool { return owner == d.getEntityOwner() })
+	if len(owners) == 0 {
+		return deleteServiceAccountEntity(ctx, entity)
+	}
+	metadata[entityOwnersMetadataKey] = formatEntityOwners(owners)
+	_, err = vaultutils.VaultClientFromContext(ctx).Logical().WriteWithContext(ctx, entityPath, map[string]any{
+		"metadata": metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update the owners of entity %s: %w", entity.EntityName, err)
+	}
+	return nil
+}
+
+// deleteServiceAccountEntity deletes the entity of a service account, along with its aliases.
+func deleteServiceAccountEntity(ctx context.Context, entity ServiceAccountEntity) error {
+	vaultClient := vaultutils.VaultClientFromContext(ctx)
+	_, err := vaultClient.Logical().DeleteWithContext(ctx, vaultutils.CleansePath("identity/entity/name/"+entity.EntityName))
+	if err != nil {
+		if respErr, ok := err.(*vault.ResponseError); ok && respErr.StatusCode == 404 {
+			return nil
+		}
+		return fmt.Errorf("unable to delete entity %s: %w", entity.EntityName, err)
+	}
+	return nil
+}
+
+// BindsServiceAccount returns whether the role binds the service account, given the namespace of the service account.
+func (d *KubernetesAuthEngineRole) BindsServiceAccount(serviceAccount *corev1.ServiceAccount, namespace *corev1.Namespace) (bool, error) {
+	if !d.Spec.matchesServiceAccountName(serviceAccount.Name) {
+		return false, nil
+	}
+	if d.Spec.TargetNamespaces.TargetNamespaceSelector == nil {
+		return slices.Contains(d.Spec.TargetNamespaces.TargetNamespaces, serviceAccount.Namespace), nil
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(d.Spec.TargetNamespaces.TargetNamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	return labelSelector.Matches(labels.Set(namespace.GetLabels())), nil
+}
+
+// matchesServiceAccountName returns whether the name matches one of the target service accounts, which can contain * globs as in Vault.
+func (i *VRole) matchesServiceAccountName(name string) bool {
+	return slices.ContainsFunc(i.TargetServiceAccounts, func(pattern string) bool {
+		return glob.Glob(pattern, name)
+	})
+}
+
+// listTargetServiceAccounts returns the service accounts of the namespace matching the target service accounts of the role.
+func (d *KubernetesAuthEngineRole) listTargetServiceAccounts(ctx context.Context, namespace string) ([]corev1.ServiceAccount, error) {
+	kubeClient := vaultutils.KubeClientFromContext(ctx)
+	serviceAccountList := &corev1.ServiceAccountList{}
+	err := kubeClient.List(ctx, serviceAccountList, &client.ListOptions{Namespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+	result := []corev1.ServiceAccount{}
+	for _, serviceAccount := range serviceAccountList.Items {
+		if d.Spec.matchesServiceAccountName(serviceAccount.Name) {
+			result = append(result, serviceAccount)
+		}
+	}
+	return result, nil
+}
+
+// GetAliasName returns the name of the entity alias Vault creates when the given service account logs in with this role.
+func (d *KubernetesAuthEngineRole) GetAliasName(serviceAccount *corev1.ServiceAccount) string {
+	if d.Spec.AliasNameSource == "serviceaccount_name" {
+		return serviceAccount.Namespace + "/" + serviceAccount.Name
+	}
+	return string(serviceAccount.UID)
+}
+
 func (d *KubernetesAuthEngineRole) GetKubeAuthConfiguration() *vaultutils.KubeAuthConfiguration {
 	return &d.Spec.Authentication
 }
@@ -0,0 +1,343 @@
+package v1alpha1
+
+import (
+	"encoding/base64"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestMFAMethodIsValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  MFAMethodSpec
+		valid bool
+	}{
+		{
+			name:  "matching configuration",
+			spec:  MFAMethodSpec{Type: "totp", TOTP: &TOTPMFAConfig{Issuer: "vault"}},
+			valid: true,
+		},
+		{
+			name:  "missing configuration",
+			spec:  MFAMethodSpec{Type: "duo"},
+			valid: false,
+		},
+		{
+			name:  "other configuration",
+			spec:  MFAMethodSpec{Type: "okta", TOTP: &TOTPMFAConfig{Issuer: "vault"}},
+			valid: false,
+		},
+		{
+			name: "extra configuration",
+			spec: MFAMethodSpec{
+				Type:   "pingid",
+				PingID: &PingIDMFAConfig{SettingsFileSecret: corev1.LocalObjectReference{Name: "pingid"}},
+				Okta:   &OktaMFAConfig{OrgName: "org"},
+			},
+			valid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := &MFAMethod{Spec: tt.spec}
+			valid, err := method.IsValid()
+			if valid != tt.valid {
+				t.Errorf("IsValid() = %v, %v, expected %v", valid, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestMFAValidateUpdate(t *testing.T) {
+	totp := MFAMethodSpec{Type: "totp", TOTP: &TOTPMFAConfig{Issuer: "vault"}}
+	renamedTOTP := totp
+	renamedTOTP.Name = "renamed"
+	enforcement := MFALoginEnforcementSpec{MFAMethods: []string{"totp"}, AuthMethodTypes: []string{"userpass"}}
+	renamedEnforcement := enforcement
+	renamedEnforcement.Name = "renamed"
+
+	if _, err := (&MFAMethod{}).ValidateUpdate(t.Context(), &MFAMethod{Spec: totp}, &MFAMethod{Spec: renamedTOTP}); err == nil || !strings.Contains(err.Error(), "spec.name cannot be updated") {
+		t.Errorf("expected the method rename to be rejected, got %v", err)
+	}
+	if _, err := (&MFAMethod{}).ValidateUpdate(t.Context(), &MFAMethod{Spec: totp}, &MFAMethod{Spec: MFAMethodSpec{Type: "totp"}}); err == nil {
+		t.Error("expected an invalid method to be rejected")
+	}
+	if _, err := (&MFALoginEnforcement{}).ValidateUpdate(t.Context(), &MFALoginEnforcement{Spec: enforcement}, &MFALoginEnforcement{Spec: renamedEnforcement}); err == nil || !strings.Contains(err.Error(), "spec.name cannot be updated") {
+		t.Errorf("expected the enforcement rename to be rejected, got %v", err)
+	}
+	if _, err := (&MFALoginEnforcement{}).ValidateUpdate(t.Context(), &MFALoginEnforcement{Spec: enforcement}, &MFALoginEnforcement{Spec: enforcement}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMFAMethodToMap(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        MFAMethodSpec
+		credentials map[string]string
+		expected    map[string]any
+	}{
+		{
+			name: "totp",
+			spec: MFAMethodSpec{Type: "totp", TOTP: &TOTPMFAConfig{Issuer: "vault", Period: 30, KeySize: 20, QRSize: 200, Algorithm: "SHA256", Digits: 6, Skew: 1, MaxValidationAttempts: 5}},
+			expected: map[string]any{
+				"issuer": "vault", "period": 30, "key_size": 20, "qr_size": 200, "algorithm": "SHA256", "digits": 6, "skew": 1, "max_validation_attempts": 5,
+			},
+		},
+		{
+			name:        "duo",
+			spec:        MFAMethodSpec{Type: "duo", Duo: &DuoMFAConfig{APIHostname: "api.duo", UsernameFormat: "{{identity.entity.name}}"}},
+			credentials: map[string]string{"integration_key": "ikey", "secret_key": "skey"},
+			expected: map[string]any{
+				"api_hostname": "api.duo", "username_format": "{{identity.entity.name}}", "push_info": "", "use_passcode": false, "integration_key": "ikey", "secret_key": "skey",
+			},
+		},
+		{
+			name:        "okta",
+			spec:        MFAMethodSpec{Type: "okta", Okta: &OktaMFAConfig{OrgName: "org", PrimaryEmail: true}},
+			credentials: map[string]string{"api_token": "token"},
+			expected: map[string]any{
+				"org_name": "org", "base_url": "", "username_format": "", "primary_email": true, "api_token": "token",
+			},
+		},
+		{
+			name:        "pingid",
+			spec:        MFAMethodSpec{Type: "pingid", PingID: &PingIDMFAConfig{}},
+			credentials: map[string]string{"settings_file": "use_base64_key=true"},
+			expected: map[string]any{
+				"username_format": "", "settings_file_base64": base64.StdEncoding.EncodeToString([]byte("use_base64_key=true")),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.retrievedCredentials = tt.credentials
+			if result := tt.spec.toMap(); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("toMap() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMFAMethodIsEquivalentToDesiredState(t *testing.T) {
+	method := &MFAMethod{
+		Spec: MFAMethodSpec{Type: "okta", Okta: &OktaMFAConfig{OrgName: "org"}},
+	}
+	method.Spec.retrievedCredentials = map[string]string{"api_token": "token"}
+	method.Spec.retrievedCredentialsVersion = "1"
+	payload := map[string]any{"org_name": "org", "base_url": "", "username_format": "", "primary_email": false, "id": "abc"}
+
+	if method.IsEquivalentToDesiredState(payload) {
+		t.Error("expected the method not to be equivalent before the credentials are written")
+	}
+	if err := method.EnrichStatus(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !method.IsEquivalentToDesiredState(payload) {
+		t.Error("expected the method to be equivalent once the credentials are written")
+	}
+	method.Spec.retrievedCredentials = map[string]string{"api_token": "rotated"}
+	method.Spec.retrievedCredentialsVersion = "2"
+	if method.IsEquivalentToDesiredState(payload) {
+		t.Error("expected the method not to be equivalent after a credentials rotation")
+	}
+}
+
+func TestMFAMethodPrepareInternalValues(t *testing.T) {
+	t.Run("retrieves the credentials", func(t *testing.T) {
+		method := &MFAMethod{
+			ObjectMeta: metav1.ObjectMeta{Name: "duo", Namespace: "ns"},
+			Spec: MFAMethodSpec{
+				Type: "duo",
+				Duo:  &DuoMFAConfig{APIHostname: "api.duo", CredentialsSecret: corev1.LocalObjectReference{Name: "duo-creds"}},
+			},
+		}
+		secret := newK8sSecret("ns", "duo-creds", map[string][]byte{"integration_key": []byte("ikey"), "secret_key": []byte("skey")})
+		kubeClient := newFakeKubeClient(method, secret)
+		vc, ts := newFakeVaultClient(t, newFakeVaultHandler())
+		defer ts.Close()
+
+		if err := method.PrepareInternalValues(pivContext(kubeClient, vc), method); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if method.Status.ID != "" {
+			t.Errorf("Status.ID = %q, expected the method not to be created", method.Status.ID)
+		}
+		if method.Spec.retrievedCredentials["secret_key"] != "skey" {
+			t.Errorf("credentials not retrieved: %v", method.Spec.retrievedCredentials)
+		}
+		if method.Spec.retrievedCredentialsVersion == "" {
+			t.Error("expected the resource version of the secret to be retrieved")
+		}
+	})
+
+	t.Run("adopts the existing method", func(t *testing.T) {
+		method := &MFAMethod{
+			ObjectMeta: metav1.ObjectMeta{Name: "totp", Namespace: "ns"},
+			Spec:       MFAMethodSpec{Type: "totp", TOTP: &TOTPMFAConfig{Issuer: "vault"}},
+		}
+		kubeClient := newFakeKubeClient(method)
+		handler := newFakeVaultHandler()
+		handler.setGet("identity/mfa/method/totp", map[string]any{
+			"keys": []string{"other-id", "existing-id"},
+			"key_info": map[string]any{
+				"other-id":    map[string]any{"name": "other"},
+				"existing-id": map[string]any{"name": "totp"},
+			},
+		})
+		vc, ts := newFakeVaultClient(t, handler)
+		defer ts.Close()
+
+		if err := method.PrepareInternalValues(pivContext(kubeClient, vc), method); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if method.Status.ID != "existing-id" {
+			t.Errorf("Status.ID = %q, expected existing-id", method.Status.ID)
+		}
+	})
+
+	t.Run("missing secret key", func(t *testing.T) {
+		method := &MFAMethod{
+			ObjectMeta: metav1.ObjectMeta{Name: "okta", Namespace: "ns"},
+			Spec: MFAMethodSpec{
+				Type: "okta",
+				Okta: &OktaMFAConfig{OrgName: "org", CredentialsSecret: corev1.LocalObjectReference{Name: "okta-creds"}},
+			},
+		}
+		secret := newK8sSecret("ns", "okta-creds", map[string][]byte{"token": []byte("token")})
+		kubeClient := newFakeKubeClient(method, secret)
+		vc, ts := newFakeVaultClient(t, newFakeVaultHandler())
+		defer ts.Close()
+
+		err := method.PrepareInternalValues(pivContext(kubeClient, vc), method)
+		if err == nil || !strings.Contains(err.Error(), "key api_token not found in secret okta-creds") {
+			t.Errorf("expected a missing key error, got %v", err)
+		}
+	})
+}
+
+func TestMFAMethodCreateOrUpdate(t *testing.T) {
+	method := &MFAMethod{
+		ObjectMeta: metav1.ObjectMeta{Name: "totp", Namespace: "ns"},
+		Spec:       MFAMethodSpec{Type: "totp", TOTP: &TOTPMFAConfig{Issuer: "vault"}},
+	}
+	handler := &recordingVaultHandler{fakeVaultHandler: newFakeVaultHandler()}
+	handler.setGet("identity/mfa/method/totp", map[string]any{"method_id": "new-id"})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+	ctx := pivContext(newFakeKubeClient(method), vc)
+
+	if err := vaultutils.NewVaultEndpoint(method).CreateOrUpdate(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if method.Status.ID != "new-id" {
+		t.Errorf("Status.ID = %q, expected new-id", method.Status.ID)
+	}
+	if method.GetPath() != "identity/mfa/method/totp/new-id" {
+		t.Errorf("GetPath() = %q", method.GetPath())
+	}
+	create := handler.find(http.MethodPut, "identity/mfa/method/totp")
+	if create == nil || create.body["method_name"] != "totp" {
+		t.Fatalf("expected the method to be created with its name, got %v", handler.requests)
+	}
+}
+
+func TestMFAMethodDeleteNotCreated(t *testing.T) {
+	method := &MFAMethod{
+		ObjectMeta: metav1.ObjectMeta{Name: "totp", Namespace: "ns"},
+		Spec:       MFAMethodSpec{Type: "totp", TOTP: &TOTPMFAConfig{Issuer: "vault"}},
+	}
+	handler := &recordingVaultHandler{fakeVaultHandler: newFakeVaultHandler()}
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	if err := vaultutils.NewVaultEndpoint(method).DeleteIfExists(pivContext(newFakeKubeClient(method), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(handler.requests) != 0 {
+		t.Errorf("expected no request to Vault, got %v", handler.requests)
+	}
+}
+
+func TestMFALoginEnforcementPrepareInternalValues(t *testing.T) {
+	newEnforcement := func() *MFALoginEnforcement {
+		return &MFALoginEnforcement{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins", Namespace: "ns"},
+			Spec: MFALoginEnforcementSpec{
+				MFAMethods:       []string{"totp", "duo"},
+				AuthEngineMounts: []string{"oidc"},
+				AuthMethodTypes:  []string{"userpass", "ldap"},
+				Groups:           []string{"admins"},
+				Entities:         []string{"alice"},
+			},
+		}
+	}
+	objects := func(duoID string) []client.Object {
+		return []client.Object{
+			&MFAMethod{ObjectMeta: metav1.ObjectMeta{Name: "totp", Namespace: "ns"}, Status: MFAMethodStatus{ID: "totp-id"}},
+			&MFAMethod{ObjectMeta: metav1.ObjectMeta{Name: "duo", Namespace: "ns"}, Status: MFAMethodStatus{ID: duoID}},
+			&AuthEngineMount{ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: "ns"}, Status: AuthEngineMountStatus{Accessor: "auth_oidc_123"}},
+			&Group{ObjectMeta: metav1.ObjectMeta{Name: "admins", Namespace: "ns"}, Status: GroupStatus{ID: "group-id"}},
+			&Entity{ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "ns"}},
+		}
+	}
+
+	t.Run("resolves the references", func(t *testing.T) {
+		enforcement := newEnforcement()
+		kubeClient := newFakeKubeClient(objects("duo-id")...)
+		handler := newFakeVaultHandler()
+		handler.setGet("identity/entity/name/alice", map[string]any{"id": "entity-id"})
+		vc, ts := newFakeVaultClient(t, handler)
+		defer ts.Close()
+
+		if err := enforcement.PrepareInternalValues(pivContext(kubeClient, vc), enforcement); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := map[string]any{
+			"mfa_method_ids":        []string{"duo-id", "totp-id"},
+			"auth_method_accessors": []string{"auth_oidc_123"},
+			"auth_method_types":     []string{"ldap", "userpass"},
+			"identity_group_ids":    []string{"group-id"},
+			"identity_entity_ids":   []string{"entity-id"},
+		}
+		if payload := enforcement.GetPayload(); !reflect.DeepEqual(payload, expected) {
+			t.Errorf("GetPayload() = %v, expected %v", payload, expected)
+		}
+		if enforcement.GetPath() != "identity/mfa/login-enforcement/admins" {
+			t.Errorf("GetPath() = %q", enforcement.GetPath())
+		}
+	})
+
+	t.Run("method not created yet", func(t *testing.T) {
+		enforcement := newEnforcement()
+		kubeClient := newFakeKubeClient(objects("")...)
+		vc, ts := newFakeVaultClient(t, newFakeVaultHandler())
+		defer ts.Close()
+
+		err := enforcement.PrepareInternalValues(pivContext(kubeClient, vc), enforcement)
+		if err == nil || !strings.Contains(err.Error(), "MFAMethod duo has not been created in Vault yet") {
+			t.Errorf("expected a not created error, got %v", err)
+		}
+	})
+
+	t.Run("entity not created yet", func(t *testing.T) {
+		enforcement := newEnforcement()
+		kubeClient := newFakeKubeClient(objects("duo-id")...)
+		vc, ts := newFakeVaultClient(t, newFakeVaultHandler())
+		defer ts.Close()
+
+		err := enforcement.PrepareInternalValues(pivContext(kubeClient, vc), enforcement)
+		if err == nil || !strings.Contains(err.Error(), "Entity alice has not been created in Vault yet") {
+			t.Errorf("expected a not created error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// MFALoginEnforcementSpec defines the desired state of MFALoginEnforcement
+// +kubebuilder:validation:XValidation:rule="has(self.authEngineMounts) || has(self.authMethodTypes) || has(self.groups) || has(self.entities)",message="at least one of authEngineMounts, authMethodTypes, groups or entities must be specified"
+type MFALoginEnforcementSpec struct {
+	// Connection represents the information needed to connect to Vault. This operator uses the standard Vault environment variables to connect to Vault. If you need to override those settings and for example connect to a different Vault instance, you can do with this section of the CR.
+	// +kubebuilder:validation:Optional
+	Connection *vaultutils.VaultConnection `json:"connection,omitempty"`
+
+	// Authentication is the kube auth configuration to be used to execute this request
+	// +kubebuilder:validation:Required
+	Authentication vaultutils.KubeAuthConfiguration `json:"authentication,omitempty"`
+
+	// MFAMethods are the names of the MFAMethod resources, in the same namespace, to enforce.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	MFAMethods []string `json:"mfaMethods"`
+
+	// AuthEngineMounts are the names of the AuthEngineMount resources, in the same namespace, whose logins require MFA.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	AuthEngineMounts []string `json:"authEngineMounts,omitempty"`
+
+	// AuthMethodTypes are the types of the auth methods, for example userpass or ldap, whose logins require MFA.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	AuthMethodTypes []string `json:"authMethodTypes,omitempty"`
+
+	// Groups are the names of the Group resources, in the same namespace, whose members require MFA at login.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Groups []string `json:"groups,omitempty"`
+
+	// Entities are the names of the Entity resources, in the same namespace, that require MFA at login.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Entities []string `json:"entities,omitempty"`
+
+	// The name of the object created in Vault. If this is specified it takes precedence over {metadata.name}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern:=`[a-z0-9]([-a-z0-9]*[a-z0-9])?`
+	Name string `json:"name,omitempty"`
+
+	// these fields are for internal use and will not be serialized
+	retrievedMFAMethodIDs        []string `json:"-"`
+	retrievedAuthMethodAccessors []string `json:"-"`
+	retrievedGroupIDs            []string `json:"-"`
+	retrievedEntityIDs           []string `json:"-"`
+}
+
+// MFALoginEnforcementStatus defines the observed state of MFALoginEnforcement
+type MFALoginEnforcementStatus struct {
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// MFALoginEnforcement is the Schema for the mfaloginenforcements API
+type MFALoginEnforcement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MFALoginEnforcementSpec   `json:"spec,omitempty"`
+	Status MFALoginEnforcementStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MFALoginEnforcementList contains a list of MFALoginEnforcement
+type MFALoginEnforcementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MFALoginEnforcement `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MFALoginEnforcement{}, &MFALoginEnforcementList{})
+}
+
+var _ vaultutils.VaultObject = &MFALoginEnforcement{}
+var _ vaultutils.ConditionsAware = &MFALoginEnforcement{}
+
+func (m *MFALoginEnforcement) GetConditions() []metav1.Condition {
+	return m.Status.Conditions
+}
+
+func (m *MFALoginEnforcement) SetConditions(conditions []metav1.Condition) {
+	m.Status.Conditions = conditions
+}
+
+func (d *MFALoginEnforcement) IsDeletable() bool {
+	return true
+}
+
+func (d *MFALoginEnforcement) GetVaultConnection() *vaultutils.VaultConnection {
+	return d.Spec.Connection
+}
+
+func (d *MFALoginEnforcement) GetKubeAuthConfiguration() *vaultutils.KubeAuthConfiguration {
+	return &d.Spec.Authentication
+}
+
+func (d *MFALoginEnforcement) GetPath() string {
+	if d.Spec.Name != "" {
+		return vaultutils.CleansePath("identity/mfa/login-enforcement/" + d.Spec.Name)
+	}
+	return vaultutils.CleansePath("identity/mfa/login-enforcement/" + d.Name)
+}
+
+func (d *MFALoginEnforcement) GetPayload() map[string]any {
+	return d.Spec.toMap()
+}
+
+func (i *MFALoginEnforcementSpec) toMap() map[string]any {
+	payload := map[string]any{}
+	payload["mfa_method_ids"] = i.retrievedMFAMethodIDs
+	payload["auth_method_accessors"] = i.retrievedAuthMethodAccessors
+	payload["auth_method_types"] = sortedCopy(i.AuthMethodTypes)
+	payload["identity_group_ids"] = i.retrievedGroupIDs
+	payload["identity_entity_ids"] = i.retrievedEntityIDs
+	return payload
+}
+
+func (d *MFALoginEnforcement) IsEquivalentToDesiredState(payload map[string]any) bool {
+	desiredState := d.Spec.toMap()
+	return reflect.DeepEqual(desiredState, filterPayloadToDesiredKeys(desiredState, payload))
+}
+
+func (d *MFALoginEnforcement) IsInitialized() bool {
+	return true
+}
+
+// PrepareInternalValues resolves the referenced resources to the identifiers Vault expects.
+func (d *MFALoginEnforcement) PrepareInternalValues(context context.Context, object client.Object) error {
+	log := log.FromContext(context)
+	kubeClient := vaultutils.KubeClientFromContext(context)
+
+	methodIDs := []string{}
+	for _, name := range d.Spec.MFAMethods {
+		method := &MFAMethod{}
+		err := kubeClient.Get(context, types.NamespacedName{Namespace: d.Namespace, Name: name}, method)
+		if err != nil {
+			log.Error(err, "unable to retrieve MFAMethod", "name", name)
+			return err
+		}
+		if method.Status.ID == "" {
+			return fmt.Errorf("MFAMethod %s has not been created in Vault yet", name)
+		}
+		methodIDs = append(methodIDs, method.Status.ID)
+	}
+
+	accessors := []string{}
+	for _, name := range d.Spec.AuthEngineMounts {
+		mount := &AuthEngineMount{}
+		err := kubeClient.Get(context, types.NamespacedName{Namespace: d.Namespace, Name: name}, mount)
+		if err != nil {
+			log.Error(err, "unable to retrieve AuthEngineMount", "name", name)
+			return err
+		}
+		if mount.Status.Accessor == "" {
+			return fmt.Errorf("AuthEngineMount %s has not been mounted in Vault yet", name)
+		}
+		accessors = append(accessors, mount.Status.Accessor)
+	}
+
+	groupIDs := []string{}
+	for _, name := range d.Spec.Groups {
+		group := &Group{}
+		err := kubeClient.Get(context, types.NamespacedName{Namespace: d.Namespace, Name: name}, group)
+		if err != nil {
+			log.Error(err, "unable to retrieve Group", "name", name)
+			return err
+		}
+		id := group.Status.ID
+		if id == "" {
+			var found bool
+			id, found, err = readIdentityID(context, group.GetPath())
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("Group %s has not been created in Vault yet", name)
+			}
+		}
+		groupIDs = append(groupIDs, id)
+	}
+
+	entityIDs := []string{}
+	for _, name := range d.Spec.Entities {
+		entity := &Entity{}
+		err := kubeClient.Get(context, types.NamespacedName{Namespace: d.Namespace, Name: name}, entity)
+		if err != nil {
+			log.Error(err, "unable to retrieve Entity", "name", name)
+			return err
+		}
+		id, found, err := readIdentityID(context, entity.GetPath())
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("Entity %s has not been created in Vault yet", name)
+		}
+		entityIDs = append(entityIDs, id)
+	}
+
+	d.Spec.retrievedMFAMethodIDs = sortedCopy(methodIDs)
+	d.Spec.retrievedAuthMethodAccessors = sortedCopy(accessors)
+	d.Spec.retrievedGroupIDs = sortedCopy(groupIDs)
+	d.Spec.retrievedEntityIDs = sortedCopy(entityIDs)
+	return nil
+}
+
+func (d *MFALoginEnforcement) PrepareTLSConfig(context context.Context, object client.Object) error {
+	return nil
+}
+
+func (r *MFALoginEnforcement) IsValid() (bool, error) {
+	err := r.isValid()
+	return err == nil, err
+}
+
+func (r *MFALoginEnforcement) isValid() error {
+	if len(r.Spec.AuthEngineMounts)+len(r.Spec.AuthMethodTypes)+len(r.Spec.Groups)+len(r.Spec.Entities) == 0 {
+		return errors.New("at least one of authEngineMounts, authMethodTypes, groups or entities must be specified")
+	}
+	return nil
+}
+
+// sortedCopy returns a sorted copy of the list, never nil, so that it compares equal to the lists returned by Vault.
+func sortedCopy(list []string) []string {
+	result := append([]string{}, list...)
+	slices.Sort(result)
+	return result
+}
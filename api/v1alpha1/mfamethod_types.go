@@ -0,0 +1,449 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// MFAMethodSpec defines the desired state of MFAMethod
+// +kubebuilder:validation:XValidation:rule="has(self.totp) == (self.type == 'totp') && has(self.duo) == (self.type == 'duo') && has(self.okta) == (self.type == 'okta') && has(self.pingid) == (self.type == 'pingid')",message="exactly the configuration of the type of the method must be specified"
+type MFAMethodSpec struct {
+	// Connection represents the information needed to connect to Vault. This operator uses the standard Vault environment variables to connect to Vault. If you need to override those settings and for example connect to a different Vault instance, you can do with this section of the CR.
+	// +kubebuilder:validation:Optional
+	Connection *vaultutils.VaultConnection `json:"connection,omitempty"`
+
+	// Authentication is the kube auth configuration to be used to execute this request
+	// +kubebuilder:validation:Required
+	Authentication vaultutils.KubeAuthConfiguration `json:"authentication,omitempty"`
+
+	// Type is the type of the MFA method.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum:={"totp","duo","okta","pingid"}
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	Type string `json:"type"`
+
+	// TOTP configures a TOTP MFA method.
+	// +kubebuilder:validation:Optional
+	TOTP *TOTPMFAConfig `json:"totp,omitempty"`
+
+	// Duo configures a Duo MFA method.
+	// +kubebuilder:validation:Optional
+	Duo *DuoMFAConfig `json:"duo,omitempty"`
+
+	// Okta configures an Okta MFA method.
+	// +kubebuilder:validation:Optional
+	Okta *OktaMFAConfig `json:"okta,omitempty"`
+
+	// PingID configures a PingID MFA method.
+	// +kubebuilder:validation:Optional
+	PingID *PingIDMFAConfig `json:"pingid,omitempty"`
+
+	// The name of the object created in Vault. If this is specified it takes precedence over {metadata.name}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern:=`[a-z0-9]([-a-z0-9]*[a-z0-9])?`
+	Name string `json:"name,omitempty"`
+
+	// these fields are for internal use and will not be serialized
+	retrievedCredentials        map[string]string `json:"-"`
+	retrievedCredentialsVersion string            `json:"-"`
+}
+
+type TOTPMFAConfig struct {
+	// Issuer is the name of the key's issuing organization.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Issuer string `json:"issuer"`
+
+	// Period is the length of time in seconds used to generate a counter for the TOTP token calculation.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=30
+	Period int `json:"period,omitempty"`
+
+	// KeySize is the size in bytes of the generated key.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=20
+	KeySize int `json:"keySize,omitempty"`
+
+	// QRSize is the pixel size of the generated square QR code.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=200
+	QRSize int `json:"qrSize,omitempty"`
+
+	// Algorithm is the hashing algorithm used to generate the TOTP code.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:={"SHA1","SHA256","SHA512"}
+	// +kubebuilder:default="SHA1"
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Digits is the number of digits in the generated TOTP code.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:={6,8}
+	// +kubebuilder:default=6
+	Digits int `json:"digits,omitempty"`
+
+	// Skew is the number of delay periods that are allowed when validating a TOTP code.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:={0,1}
+	// +kubebuilder:default=1
+	Skew int `json:"skew"`
+
+	// MaxValidationAttempts is the maximum number of consecutive failed validation attempts.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	MaxValidationAttempts int `json:"maxValidationAttempts,omitempty"`
+}
+
+type DuoMFAConfig struct {
+	// APIHostname is the API hostname for Duo.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	APIHostname string `json:"apiHostname"`
+
+	// CredentialsSecret is the Kubernetes secret containing the integration key of Duo at the integration_key key and its secret key at the secret_key key.
+	// +kubebuilder:validation:Required
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret"`
+
+	// UsernameFormat is a template string for mapping identity names to MFA methods.
+	// +kubebuilder:validation:Optional
+	UsernameFormat string `json:"usernameFormat,omitempty"`
+
+	// PushInfo is a string of URL-encoded key/value pairs that provides additional context about the authentication attempt in the Duo Mobile application.
+	// +kubebuilder:validation:Optional
+	PushInfo string `json:"pushInfo,omitempty"`
+
+	// UsePasscode requires the user to enter a passcode instead of receiving a push notification.
+	// +kubebuilder:validation:Optional
+	UsePasscode bool `json:"usePasscode,omitempty"`
+}
+
+type OktaMFAConfig struct {
+	// OrgName is the name of the organization to be used in the Okta API.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	OrgName string `json:"orgName"`
+
+	// CredentialsSecret is the Kubernetes secret containing the Okta API token at the api_token key.
+	// +kubebuilder:validation:Required
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret"`
+
+	// BaseURL is the base domain to use for the Okta API. When not specified in the configuration, "okta.com" is used.
+	// +kubebuilder:validation:Optional
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// UsernameFormat is a template string for mapping identity names to MFA methods.
+	// +kubebuilder:validation:Optional
+	UsernameFormat string `json:"usernameFormat,omitempty"`
+
+	// PrimaryEmail uses the primary email of the Okta user for the lookup, instead of its username.
+	// +kubebuilder:validation:Optional
+	PrimaryEmail bool `json:"primaryEmail,omitempty"`
+}
+
+type PingIDMFAConfig struct {
+	// SettingsFileSecret is the Kubernetes secret containing the PingID properties file downloaded from the PingID admin console at the settings_file key.
+	// +kubebuilder:validation:Required
+	SettingsFileSecret corev1.LocalObjectReference `json:"settingsFileSecret"`
+
+	// UsernameFormat is a template string for mapping identity names to MFA methods.
+	// +kubebuilder:validation:Optional
+	UsernameFormat string `json:"usernameFormat,omitempty"`
+}
+
+// MFAMethodStatus defines the observed state of MFAMethod
+type MFAMethodStatus struct {
+	// ID is the Vault-assigned identifier of the MFA method.
+	// +kubebuilder:validation:Optional
+	ID string `json:"id,omitempty"`
+
+	// CredentialsSecretResourceVersion is the resource version of the credentials secret last written to Vault, so that the credentials are written again when the secret changes.
+	// +kubebuilder:validation:Optional
+	CredentialsSecretResourceVersion string `json:"credentialsSecretResourceVersion,omitempty"`
+
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// MFAMethod is the Schema for the mfamethods API
+type MFAMethod struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MFAMethodSpec   `json:"spec,omitempty"`
+	Status MFAMethodStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MFAMethodList contains a list of MFAMethod
+type MFAMethodList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MFAMethod `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MFAMethod{}, &MFAMethodList{})
+}
+
+var _ vaultutils.VaultObject = &MFAMethod{}
+var _ vaultutils.ConditionsAware = &MFAMethod{}
+var _ vaultutils.VaultStatusEnricher = &MFAMethod{}
+var _ vaultutils.VaultAssignedIDObject = &MFAMethod{}
+
+// mfaMethodCredentialKeys are the keys of the Kubernetes secrets of each type of MFA method.
+var mfaMethodCredentialKeys = map[string][]string{
+	"duo":    {"integration_key", "secret_key"},
+	"okta":   {"api_token"},
+	"pingid": {"settings_file"},
+}
+
+func (m *MFAMethod) GetConditions() []metav1.Condition {
+	return m.Status.Conditions
+}
+
+func (m *MFAMethod) SetConditions(conditions []metav1.Condition) {
+	m.Status.Conditions = conditions
+}
+
+func (d *MFAMethod) IsDeletable() bool {
+	return true
+}
+
+func (d *MFAMethod) GetVaultConnection() *vaultutils.VaultConnection {
+	return d.Spec.Connection
+}
+
+func (d *MFAMethod) GetKubeAuthConfiguration() *vaultutils.KubeAuthConfiguration {
+	return &d.Spec.Authentication
+}
+
+// GetPath returns the path of the method, which is only known once Vault has assigned its ID.
+func (d *MFAMethod) GetPath() string {
+	return vaultutils.CleansePath("identity/mfa/method/" + d.Spec.Type + "/" + d.Status.ID)
+}
+
+func (d *MFAMethod) GetPayload() map[string]any {
+	return d.Spec.toMap()
+}
+
+func (d *MFAMethod) getMethodName() string {
+	if d.Spec.Name != "" {
+		return d.Spec.Name
+	}
+	return d.Name
+}
+
+// GetCredentialsSecretName returns the name of the Kubernetes secret holding the credentials of the method, if its type requires one.
+func (d *MFAMethod) GetCredentialsSecretName() string {
+	switch {
+	case d.Spec.Duo != nil:
+		return d.Spec.Duo.CredentialsSecret.Name
+	case d.Spec.Okta != nil:
+		return d.Spec.Okta.CredentialsSecret.Name
+	case d.Spec.PingID != nil:
+		return d.Spec.PingID.SettingsFileSecret.Name
+	}
+	return ""
+}
+
+func (i *MFAMethodSpec) toMap() map[string]any {
+	payload := map[string]any{}
+	switch {
+	case i.TOTP != nil:
+		payload["issuer"] = i.TOTP.Issuer
+		payload["period"] = i.TOTP.Period
+		payload["key_size"] = i.TOTP.KeySize
+		payload["qr_size"] = i.TOTP.QRSize
+		payload["algorithm"] = i.TOTP.Algorithm
+		payload["digits"] = i.TOTP.Digits
+		payload["skew"] = i.TOTP.Skew
+		payload["max_validation_attempts"] = i.TOTP.MaxValidationAttempts
+	case i.Duo != nil:
+		payload["api_hostname"] = i.Duo.APIHostname
+		payload["username_format"] = i.Duo.UsernameFormat
+		payload["push_info"] = i.Duo.PushInfo
+		payload["use_passcode"] = i.Duo.UsePasscode
+		payload["integration_key"] = i.retrievedCredentials["integration_key"]
+		payload["secret_key"] = i.retrievedCredentials["secret_key"]
+	case i.Okta != nil:
+		payload["org_name"] = i.Okta.OrgName
+		payload["base_url"] = i.Okta.BaseURL
+		payload["username_format"] = i.Okta.UsernameFormat
+		payload["primary_email"] = i.Okta.PrimaryEmail
+		payload["api_token"] = i.retrievedCredentials["api_token"]
+	case i.PingID != nil:
+		payload["username_format"] = i.PingID.UsernameFormat
+		payload["settings_file_base64"] = base64.StdEncoding.EncodeToString([]byte(i.retrievedCredentials["settings_file"]))
+	}
+	return payload
+}
+
+// IsEquivalentToDesiredState compares the fields Vault returns, the credentials are never returned and are compared by the resource version of their secret instead.
+func (d *MFAMethod) IsEquivalentToDesiredState(payload map[string]any) bool {
+	if d.Status.CredentialsSecretResourceVersion != d.Spec.retrievedCredentialsVersion {
+		return false
+	}
+	desiredState := d.Spec.toMap()
+	for _, key := range []string{"integration_key", "secret_key", "api_token", "settings_file_base64"} {
+		delete(desiredState, key)
+	}
+	return reflect.DeepEqual(desiredState, filterPayloadToDesiredKeys(desiredState, payload))
+}
+
+func (d *MFAMethod) IsInitialized() bool {
+	return true
+}
+
+// PrepareInternalValues retrieves the credentials of the method and, as the Vault API assigns the ID of a method at its creation, adopts the existing method with the same name, if any.
+func (d *MFAMethod) PrepareInternalValues(context context.Context, object client.Object) error {
+	log := log.FromContext(context)
+	err := d.setInternalCredentials(context)
+	if err != nil {
+		return err
+	}
+	if d.Status.ID != "" {
+		return nil
+	}
+	id, err := d.findMethodID(context)
+	if err != nil {
+		log.Error(err, "unable to look up MFA method", "name", d.getMethodName())
+		return err
+	}
+	d.Status.ID = id
+	return nil
+}
+
+func (d *MFAMethod) GetVaultAssignedID() string {
+	return d.Status.ID
+}
+
+func (d *MFAMethod) GetCreationPath() string {
+	return "identity/mfa/method/" + d.Spec.Type
+}
+
+func (d *MFAMethod) GetCreationPayload() map[string]any {
+	payload := d.GetPayload()
+	payload["method_name"] = d.getMethodName()
+	return payload
+}
+
+// SetVaultAssignedID records the ID of the created method, persisted with the status at the end of the reconciliation.
+func (d *MFAMethod) SetVaultAssignedID(data map[string]any) error {
+	id, _ := data["method_id"].(string)
+	if id == "" {
+		return errors.New("no method_id returned by Vault at the creation of the MFA method")
+	}
+	d.Status.ID = id
+	return nil
+}
+
+// findMethodID returns the ID of the method of the same type and name, if any.
+func (d *MFAMethod) findMethodID(context context.Context) (string, error) {
+	vaultClient := vaultutils.VaultClientFromContext(context)
+	secret, err := vaultClient.Logical().ListWithContext(context, "identity/mfa/method/"+d.Spec.Type)
+	if err != nil {
+		return "", err
+	}
+	if secret == nil || secret.Data == nil {
+		return "", nil
+	}
+	keyInfo, _ := secret.Data["key_info"].(map[string]any)
+	for id, info := range keyInfo {
+		if info, ok := info.(map[string]any); ok && info["name"] == d.getMethodName() {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+func (d *MFAMethod) setInternalCredentials(context context.Context) error {
+	secretName := d.GetCredentialsSecretName()
+	if secretName == "" {
+		return nil
+	}
+	log := log.FromContext(context)
+	kubeClient := vaultutils.KubeClientFromContext(context)
+	secret := &corev1.Secret{}
+	err := kubeClient.Get(context, types.NamespacedName{
+		Namespace: d.Namespace,
+		Name:      secretName,
+	}, secret)
+	if err != nil {
+		log.Error(err, "unable to retrieve Secret", "instance", d)
+		return err
+	}
+	credentials := map[string]string{}
+	for _, key := range mfaMethodCredentialKeys[d.Spec.Type] {
+		value, ok := secret.Data[key]
+		if !ok {
+			return fmt.Errorf("key %s not found in secret %s", key, secretName)
+		}
+		credentials[key] = string(value)
+	}
+	d.Spec.retrievedCredentials = credentials
+	d.Spec.retrievedCredentialsVersion = secret.ResourceVersion
+	return nil
+}
+
+// EnrichStatus records the resource version of the credentials secret written to Vault.
+func (d *MFAMethod) EnrichStatus(ctx context.Context) error {
+	d.Status.CredentialsSecretResourceVersion = d.Spec.retrievedCredentialsVersion
+	return nil
+}
+
+func (d *MFAMethod) PrepareTLSConfig(context context.Context, object client.Object) error {
+	return nil
+}
+
+func (r *MFAMethod) IsValid() (bool, error) {
+	err := r.isValid()
+	return err == nil, err
+}
+
+func (r *MFAMethod) isValid() error {
+	configs := map[string]bool{
+		"totp":   r.Spec.TOTP != nil,
+		"duo":    r.Spec.Duo != nil,
+		"okta":   r.Spec.Okta != nil,
+		"pingid": r.Spec.PingID != nil,
+	}
+	for methodType, set := range configs {
+		if set != (methodType == r.Spec.Type) {
+			return fmt.Errorf("exactly the %s configuration must be specified for an MFA method of type %s", r.Spec.Type, r.Spec.Type)
+		}
+	}
+	return nil
+}
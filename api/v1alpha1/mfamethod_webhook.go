@@ -0,0 +1,73 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var mfamethodlog = logf.Log.WithName("mfamethod-resource")
+
+func (r *MFAMethod) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, r).
+		WithDefaulter(r).
+		WithValidator(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-redhatcop-redhat-io-v1alpha1-mfamethod,mutating=true,failurePolicy=fail,sideEffects=None,groups=redhatcop.redhat.io,resources=mfamethods,verbs=create,versions=v1alpha1,name=mmfamethod.kb.io,admissionReviewVersions=v1
+
+var _ admission.Defaulter[*MFAMethod] = &MFAMethod{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (r *MFAMethod) Default(ctx context.Context, obj *MFAMethod) error {
+	mfamethodlog.Info("default", "name", obj.Name)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-redhatcop-redhat-io-v1alpha1-mfamethod,mutating=false,failurePolicy=fail,sideEffects=None,groups=redhatcop.redhat.io,resources=mfamethods,verbs=create;update,versions=v1alpha1,name=vmfamethod.kb.io,admissionReviewVersions=v1
+
+var _ admission.Validator[*MFAMethod] = &MFAMethod{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *MFAMethod) ValidateCreate(ctx context.Context, obj *MFAMethod) (admission.Warnings, error) {
+	mfamethodlog.Info("validate create", "name", obj.Name)
+	return nil, obj.isValid()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *MFAMethod) ValidateUpdate(ctx context.Context, oldObj, newObj *MFAMethod) (admission.Warnings, error) {
+	mfamethodlog.Info("validate update", "name", newObj.Name)
+
+	// the name in Vault cannot be updated
+	if newObj.Spec.Name != oldObj.Spec.Name {
+		return nil, errors.New("spec.name cannot be updated")
+	}
+	return nil, newObj.isValid()
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *MFAMethod) ValidateDelete(ctx context.Context, obj *MFAMethod) (admission.Warnings, error) {
+	mfamethodlog.Info("validate delete", "name", obj.Name)
+	return nil, nil
+}
@@ -1,9 +1,12 @@
 package v1alpha1
 
 import (
+	"encoding/json"
+	"net/http"
 	"reflect"
 	"testing"
 
+	vault "github.com/hashicorp/vault/api"
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -124,3 +127,154 @@ func TestNamespaceConditions(t *testing.T) {
 		t.Errorf("expected condition status True, got %v", got[0].Status)
 	}
 }
+
+func TestNamespaceGetParentPath(t *testing.T) {
+	parent := &Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants", Namespace: "vault-admin"},
+		Status:     NamespaceStatus{Path: "tenants"},
+	}
+	pending := &Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "team-a"}}
+	vc, err := vault.NewClient(vault.DefaultConfig())
+	if err != nil {
+		t.Fatalf("unable to create vault client: %v", err)
+	}
+	vc.SetNamespace("admin")
+	ctx := pivContext(newFakeKubeClient(parent, pending), vc)
+
+	tests := []struct {
+		name      string
+		namespace *Namespace
+		expected  string
+		wantErr   bool
+	}{
+		{
+			name:      "no path",
+			namespace: &Namespace{},
+			expected:  "admin",
+		},
+		{
+			name:      "path under the namespace of the authentication",
+			namespace: &Namespace{Spec: NamespaceSpec{Path: "/tenants/"}},
+			expected:  "admin/tenants",
+		},
+		{
+			name:      "parent in another namespace",
+			namespace: &Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "team-a"}, Spec: NamespaceSpec{Parent: &NamespaceReference{Name: "tenants", Namespace: "vault-admin"}}},
+			expected:  "tenants",
+		},
+		{
+			name:      "parent not created in Vault yet",
+			namespace: &Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "team-a"}, Spec: NamespaceSpec{Parent: &NamespaceReference{Name: "pending"}}},
+			wantErr:   true,
+		},
+		{
+			name:      "deleted parent of an existing namespace",
+			namespace: &Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"}, Spec: NamespaceSpec{Parent: &NamespaceReference{Name: "deleted"}}, Status: NamespaceStatus{Path: "tenants/team-a/dev"}},
+			expected:  "tenants/team-a",
+		},
+		{
+			name:      "deleted parent of a new namespace",
+			namespace: &Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"}, Spec: NamespaceSpec{Parent: &NamespaceReference{Name: "deleted"}}},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.namespace.GetParentPath(ctx)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("GetParentPath() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNamespaceIsChildOf(t *testing.T) {
+	parent := &Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenants", Namespace: "vault-admin"}}
+	tests := []struct {
+		name     string
+		child    *Namespace
+		expected bool
+	}{
+		{name: "no parent", child: &Namespace{ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin"}}, expected: false},
+		{name: "parent in the same namespace", child: &Namespace{ObjectMeta: metav1.ObjectMeta{Namespace: "vault-admin"}, Spec: NamespaceSpec{Parent: &NamespaceReference{Name: "tenants"}}}, expected: true},
+		{name: "parent in another namespace", child: &Namespace{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"}, Spec: NamespaceSpec{Parent: &NamespaceReference{Name: "tenants", Namespace: "vault-admin"}}}, expected: true},
+		{name: "parent with the same name in the child namespace", child: &Namespace{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"}, Spec: NamespaceSpec{Parent: &NamespaceReference{Name: "tenants"}}}, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.child.IsChildOf(parent); got != tt.expected {
+				t.Errorf("IsChildOf() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// fakeNamespaceHandler serves a single namespace and records the patches of its custom metadata.
+type fakeNamespaceHandler struct {
+	data    map[string]any
+	patches []map[string]any
+}
+
+func (h *fakeNamespaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/sys/namespaces/dev" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r.Method == http.MethodPatch {
+		patch := map[string]any{}
+		_ = json.NewDecoder(r.Body).Decode(&patch) // test handler; decode error shows as an unexpected patch
+		h.patches = append(h.patches, patch)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": h.data}) // test handler; encode error is not actionable
+}
+
+func TestNamespaceEnrichStatus(t *testing.T) {
+	handler := &fakeNamespaceHandler{data: map[string]any{
+		"id":              "abc12",
+		"path":            "tenants/dev/",
+		"custom_metadata": map[string]any{"owner": "team-a", "stale": "true"},
+	}}
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	namespace := &Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev"},
+		Spec:       NamespaceSpec{CustomMetadata: map[string]string{"owner": "team-a", "cost-center": "42"}},
+	}
+	if err := namespace.EnrichStatus(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if namespace.Status.ID != "abc12" || namespace.Status.Path != "tenants/dev" {
+		t.Errorf("unexpected status %+v", namespace.Status)
+	}
+	if len(handler.patches) != 0 {
+		t.Errorf("expected EnrichStatus not to write, got %v", handler.patches)
+	}
+
+	if err := namespace.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []map[string]any{{"custom_metadata": map[string]any{"owner": "team-a", "cost-center": "42", "stale": nil}}}
+	if !reflect.DeepEqual(handler.patches, expected) {
+		t.Errorf("patches = %v, want %v", handler.patches, expected)
+	}
+
+	handler.patches = nil
+	namespace.Spec.CustomMetadata = map[string]string{"owner": "team-a", "stale": "true"}
+	if err := namespace.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(handler.patches) != 0 {
+		t.Errorf("expected no patch when the custom metadata is up to date, got %v", handler.patches)
+	}
+}
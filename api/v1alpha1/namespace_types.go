@@ -18,10 +18,16 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"path"
 	"reflect"
+	"strings"
 
+	vault "github.com/hashicorp/vault/api"
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -30,6 +36,8 @@ import (
 
 var _ vaultutils.VaultObject = &Namespace{}
 var _ vaultutils.ConditionsAware = &Namespace{}
+var _ vaultutils.VaultStatusEnricher = &Namespace{}
+var _ vaultutils.VaultPostWriteReconciler = &Namespace{}
 
 func (d *Namespace) GetVaultConnection() *vaultutils.VaultConnection {
 	return d.Spec.Connection
@@ -72,6 +80,7 @@ func (r *Namespace) IsValid() (bool, error) {
 }
 
 // NamespaceSpec defines the desired state of Namespace
+// +kubebuilder:validation:XValidation:rule="!(has(self.path) && has(self.parent))",message="path and parent are mutually exclusive"
 type NamespaceSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
@@ -95,6 +104,27 @@ type NamespaceSpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Path vaultutils.Path `json:"path,omitempty"`
+
+	// Parent is a reference to the Namespace CR of the parent Vault namespace, as an alternative to path for nested namespaces.
+	// The namespace is created once the parent is, under the path in Vault of the parent reported in its status.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	Parent *NamespaceReference `json:"parent,omitempty"`
+
+	// CustomMetadata is the custom metadata of the namespace in Vault.
+	// +kubebuilder:validation:Optional
+	CustomMetadata map[string]string `json:"customMetadata,omitempty"`
+}
+
+type NamespaceReference struct {
+	// Name is the name of the Namespace CR.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the Kubernetes namespace of the Namespace CR, it defaults to the namespace of the referencing CR.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // NamespaceStatus defines the observed state of Namespace
@@ -105,6 +135,14 @@ type NamespaceStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// ID is the id of the namespace in Vault.
+	// +kubebuilder:validation:Optional
+	ID string `json:"id,omitempty"`
+
+	// Path is the full path of the namespace in Vault, which can be used as the Vault namespace of other CRs.
+	// +kubebuilder:validation:Optional
+	Path string `json:"path,omitempty"`
 }
 
 func (m *Namespace) GetConditions() []metav1.Condition {
@@ -148,6 +186,103 @@ func (i *Namespace) toMap() map[string]interface{} {
 	payload := map[string]interface{}{}
 	payload["name"] = i.Spec.Name
 	payload["path"] = i.Spec.Path
+	if i.Spec.CustomMetadata != nil {
+		payload["custom_metadata"] = i.Spec.CustomMetadata
+	}
 
 	return payload
 }
+
+// GetParentPath returns the full path of the Vault namespace in which this namespace is created:
+// the path of the parent Namespace CR if any, else spec.path under the Vault namespace of the client.
+func (d *Namespace) GetParentPath(context context.Context) (string, error) {
+	if d.Spec.Parent == nil {
+		vaultClient := vaultutils.VaultClientFromContext(context)
+		return strings.Trim(path.Join(vaultClient.Namespace(), string(d.Spec.Path)), "/"), nil
+	}
+	reference := types.NamespacedName{Namespace: d.Spec.Parent.Namespace, Name: d.Spec.Parent.Name}
+	if reference.Namespace == "" {
+		reference.Namespace = d.Namespace
+	}
+	parent := &Namespace{}
+	err := vaultutils.KubeClientFromContext(context).Get(context, reference, parent)
+	if err != nil {
+		// The parent may be deleted before this namespace, the path of the parent is then derived from the last known path of this namespace
+		if apierrors.IsNotFound(err) && strings.Contains(d.Status.Path, "/") {
+			return path.Dir(d.Status.Path), nil
+		}
+		return "", err
+	}
+	if parent.Status.Path == "" {
+		return "", fmt.Errorf("parent Namespace %s has not been created in Vault yet", reference)
+	}
+	return parent.Status.Path, nil
+}
+
+// IsChildOf returns whether the parent of this namespace is the given Namespace CR.
+func (d *Namespace) IsChildOf(parent *Namespace) bool {
+	if d.Spec.Parent == nil || d.Spec.Parent.Name != parent.Name {
+		return false
+	}
+	if d.Spec.Parent.Namespace == "" {
+		return d.Namespace == parent.Namespace
+	}
+	return d.Spec.Parent.Namespace == parent.Namespace
+}
+
+// EnrichStatus records the id and the full path of the namespace.
+func (d *Namespace) EnrichStatus(context context.Context) error {
+	secret, err := d.readNamespace(context)
+	if err != nil {
+		return err
+	}
+	if id, ok := secret.Data["id"].(string); ok {
+		d.Status.ID = id
+	}
+	if fullPath, ok := secret.Data["path"].(string); ok {
+		d.Status.Path = strings.TrimSuffix(fullPath, "/")
+	}
+	return nil
+}
+
+// ReconcilePostWrite updates the custom metadata of the namespace, which Vault does not change when the namespace already exists.
+func (d *Namespace) ReconcilePostWrite(context context.Context) error {
+	if d.Spec.CustomMetadata == nil {
+		return nil
+	}
+	secret, err := d.readNamespace(context)
+	if err != nil {
+		return err
+	}
+	current, _ := secret.Data["custom_metadata"].(map[string]any)
+	desired := map[string]any{}
+	for key, value := range d.Spec.CustomMetadata {
+		desired[key] = value
+	}
+	if reflect.DeepEqual(current, desired) {
+		return nil
+	}
+	// Keys missing from spec.customMetadata are removed with null values, as per JSON merge patch
+	patch := map[string]any{}
+	for key := range current {
+		patch[key] = nil
+	}
+	for key, value := range desired {
+		patch[key] = value
+	}
+	vaultClient := vaultutils.VaultClientFromContext(context)
+	_, err = vaultClient.Logical().JSONMergePatch(context, d.GetPath(), map[string]any{"custom_metadata": patch})
+	return err
+}
+
+func (d *Namespace) readNamespace(context context.Context) (*vault.Secret, error) {
+	vaultClient := vaultutils.VaultClientFromContext(context)
+	secret, err := vaultClient.Logical().ReadWithContext(context, d.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("namespace %s not found after being written", d.GetPath())
+	}
+	return secret, nil
+}
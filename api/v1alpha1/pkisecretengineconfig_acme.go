@@ -0,0 +1,278 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PKIConfigCluster configures the URLs of the mount on this cluster of Vault, used by ACME and by the templated AIA URLs.
+type PKIConfigCluster struct {
+	// Path is the URL of the mount on this cluster of Vault, for example https://vault.example.com/v1/pki. Available as {{cluster_path}} in the templated AIA URLs. Required by ACME.
+	// +kubebuilder:validation:Optional
+	Path string `json:"path,omitempty"`
+
+	// AIAPath is the unauthenticated URL of the mount on this cluster of Vault, usually served over plain HTTP. Available as {{cluster_aia_path}} in the templated AIA URLs.
+	// +kubebuilder:validation:Optional
+	AIAPath string `json:"aiaPath,omitempty"`
+}
+
+// PKIACME configures the ACME server of the mount. Requires Vault 1.14 or later.
+type PKIACME struct {
+	// Enabled enables the ACME server of the mount.
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// AllowedIssuers are the names or IDs of the issuers which can issue certificates through ACME. Defaults to all issuers in Vault.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	AllowedIssuers []string `json:"allowedIssuers,omitempty"`
+
+	// AllowedRoles are the roles which can issue certificates through ACME. Defaults to all roles in Vault.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	AllowedRoles []string `json:"allowedRoles,omitempty"`
+
+	// AllowRoleExtKeyUsage allows the ext_key_usage of the roles to be used, instead of server authentication only.
+	// +kubebuilder:validation:Optional
+	AllowRoleExtKeyUsage bool `json:"allowRoleExtKeyUsage,omitempty"`
+
+	// DefaultDirectoryPolicy is the policy of the default ACME directory: forbid, sign-verbatim, role:<role_name>, external-policy or external-policy:<policy>. Defaults to sign-verbatim in Vault.
+	// +kubebuilder:validation:Optional
+	DefaultDirectoryPolicy string `json:"defaultDirectoryPolicy,omitempty"`
+
+	// EABPolicy is the external account binding policy of the ACME server. Defaults to not-required in Vault.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:={"not-required","new-account-required","always-required"}
+	EABPolicy string `json:"eabPolicy,omitempty"`
+
+	// DNSResolver is the DNS resolver, host:port, used to validate the dns-01 challenges.
+	// +kubebuilder:validation:Optional
+	DNSResolver string `json:"dnsResolver,omitempty"`
+
+	// EABSecret issues an external account binding token into a Kubernetes secret, with the keys id, key and directory, for ACME clients such as cert-manager.
+	// +kubebuilder:validation:Optional
+	EABSecret *PKIEABSecret `json:"eabSecret,omitempty"`
+}
+
+// PKIEABSecret is the Kubernetes secret an external account binding token is issued into.
+type PKIEABSecret struct {
+	// Name is the name of the secret, in the same namespace.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Issuer is the issuer of the ACME directory the token is bound to. Defaults to the default directory.
+	// +kubebuilder:validation:Optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// Role is the role of the ACME directory the token is bound to. Defaults to the default directory.
+	// +kubebuilder:validation:Optional
+	Role string `json:"role,omitempty"`
+}
+
+// PKIACMEStatus reports the external account binding token issued into the EAB secret.
+type PKIACMEStatus struct {
+	// EABKeyID is the key ID of the external account binding token.
+	// +kubebuilder:validation:Optional
+	EABKeyID string `json:"eabKeyID,omitempty"`
+
+	// Directory is the ACME directory the external account binding token is bound to.
+	// +kubebuilder:validation:Optional
+	Directory string `json:"directory,omitempty"`
+}
+
+func (p *PKISecretEngineConfig) GetConfigClusterPath() string {
+	return vaultutils.CleansePath(p.GetPath() + "/config/cluster")
+}
+
+// GetConfigClusterPayload returns nil when the cluster configuration of the mount is not managed.
+func (p *PKISecretEngineConfig) GetConfigClusterPayload() map[string]any {
+	if p.Spec.Cluster == nil {
+		return nil
+	}
+	return p.Spec.Cluster.toMap()
+}
+
+func (p *PKISecretEngineConfig) GetConfigACMEPath() string {
+	return vaultutils.CleansePath(p.GetPath() + "/config/acme")
+}
+
+// GetNewEABPath returns the path issuing the external account binding tokens of the ACME directory of the EAB secret.
+func (p *PKISecretEngineConfig) GetNewEABPath() string {
+	path := p.GetPath()
+	eabSecret := p.Spec.ACME.EABSecret
+	if eabSecret.Issuer != "" {
+		path += "/issuer/" + eabSecret.Issuer
+	}
+	if eabSecret.Role != "" {
+		path += "/roles/" + eabSecret.Role
+	}
+	return vaultutils.CleansePath(path + "/acme/new-eab")
+}
+
+func (p *PKISecretEngineConfig) GetEABPath(keyID string) string {
+	return vaultutils.CleansePath(p.GetPath() + "/eab/" + keyID)
+}
+
+func (i *PKIConfigCluster) toMap() map[string]any {
+	payload := map[string]any{}
+	payload["path"] = i.Path
+	payload["aia_path"] = i.AIAPath
+	return payload
+}
+
+func (i *PKIACME) toMap() map[string]any {
+	payload := map[string]any{}
+	payload["enabled"] = i.Enabled
+	if len(i.AllowedIssuers) > 0 {
+		payload["allowed_issuers"] = i.AllowedIssuers
+	}
+	if len(i.AllowedRoles) > 0 {
+		payload["allowed_roles"] = i.AllowedRoles
+	}
+	payload["allow_role_ext_key_usage"] = i.AllowRoleExtKeyUsage
+	if i.DefaultDirectoryPolicy != "" {
+		payload["default_directory_policy"] = i.DefaultDirectoryPolicy
+	}
+	if i.EABPolicy != "" {
+		payload["eab_policy"] = i.EABPolicy
+	}
+	payload["dns_resolver"] = i.DNSResolver
+	return payload
+}
+
+// ReconcileACME writes the ACME configuration of the mount and issues the external account binding token into the EAB secret.
+func (p *PKISecretEngineConfig) ReconcileACME(context context.Context) error {
+	if p.Spec.ACME == nil {
+		p.Status.ACME = nil
+		return nil
+	}
+	log := log.FromContext(context)
+	vaultClient := vaultutils.VaultClientFromContext(context)
+	_, err := vaultClient.Logical().WriteWithContext(context, p.GetConfigACMEPath(), p.Spec.ACME.toMap())
+	if err != nil {
+		log.Error(err, "unable to write ACME configuration", "path", p.GetConfigACMEPath())
+		return err
+	}
+	if p.Spec.ACME.EABSecret == nil {
+		p.Status.ACME = nil
+		return nil
+	}
+	return p.syncEABSecret(context)
+}
+
+// syncEABSecret issues a new external account binding token when the EAB secret does not hold the token recorded in status, as when the secret is created or was deleted.
+func (p *PKISecretEngineConfig) syncEABSecret(context context.Context) error {
+	log := log.FromContext(context)
+	kubeClient := vaultutils.KubeClientFromContext(context)
+	vaultClient := vaultutils.VaultClientFromContext(context)
+	name := p.Spec.ACME.EABSecret.Name
+
+	existing := &corev1.Secret{}
+	err := kubeClient.Get(context, types.NamespacedName{Namespace: p.Namespace, Name: name}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		if !metav1.IsControlledBy(existing, p) {
+			return fmt.Errorf("secret %s already exists and is not owned by the PKISecretEngineConfig", name)
+		}
+		if p.Status.ACME != nil && p.Status.ACME.EABKeyID != "" && string(existing.Data["id"]) == p.Status.ACME.EABKeyID {
+			return nil
+		}
+	}
+
+	// the previous token is no longer delivered to anyone, it is deleted if it was not used yet
+	if p.Status.ACME != nil && p.Status.ACME.EABKeyID != "" {
+		_, err := vaultClient.Logical().DeleteWithContext(context, p.GetEABPath(p.Status.ACME.EABKeyID))
+		if err != nil {
+			log.Error(err, "unable to delete previous EAB token", "id", p.Status.ACME.EABKeyID)
+		}
+	}
+
+	response, err := vaultClient.Logical().WriteWithContext(context, p.GetNewEABPath(), map[string]any{})
+	if err != nil {
+		log.Error(err, "unable to issue EAB token", "path", p.GetNewEABPath())
+		return err
+	}
+	if response == nil || response.Data == nil {
+		return errors.New("no EAB token returned by Vault")
+	}
+	id := vaultutils.ToString(response.Data["id"])
+	key := vaultutils.ToString(response.Data["key"])
+	if id == "" || key == "" {
+		return errors.New("no EAB token returned by Vault")
+	}
+	directory := vaultutils.ToString(response.Data["acme_directory"])
+	p.Status.ACME = &PKIACMEStatus{EABKeyID: id, Directory: directory}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: p.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(context, kubeClient, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels["redhatcop.redhat.io/pkisecretengineconfigs"] = p.Name
+		secret.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(p, GroupVersion.WithKind("PKISecretEngineConfig"))}
+		secret.Data = map[string][]byte{
+			"id":        []byte(id),
+			"key":       []byte(key),
+			"directory": []byte(directory),
+		}
+		return nil
+	})
+	return err
+}
+
+// isValidACME checks that the cluster configuration provides the URLs used by ACME and by the templated AIA URLs.
+func (p *PKISecretEngineConfig) isValidACME() error {
+	clusterPath, clusterAIAPath := "", ""
+	if p.Spec.Cluster != nil {
+		clusterPath, clusterAIAPath = p.Spec.Cluster.Path, p.Spec.Cluster.AIAPath
+	}
+	if p.Spec.ACME != nil && p.Spec.ACME.Enabled && clusterPath == "" {
+		return errors.New("ACME requires cluster.path")
+	}
+	if !p.Spec.EnableTemplating {
+		return nil
+	}
+	urls := append(append(append([]string{}, p.Spec.IssuingCertificates...), p.Spec.CRLDistributionPoints...), p.Spec.OcspServers...)
+	for _, url := range urls {
+		if strings.Contains(url, "{{cluster_path}}") && clusterPath == "" {
+			return fmt.Errorf("URL %s requires cluster.path", url)
+		}
+		if strings.Contains(url, "{{cluster_aia_path}}") && clusterAIAPath == "" {
+			return fmt.Errorf("URL %s requires cluster.aiaPath", url)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package v1alpha1
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestPKISecretEngineConfigIsValidACME(t *testing.T) {
+	tests := []struct {
+		name string
+		spec PKISecretEngineConfigSpec
+		err  string
+	}{
+		{
+			name: "ACME with cluster path",
+			spec: PKISecretEngineConfigSpec{
+				Cluster: &PKIConfigCluster{Path: "https://vault.example.com/v1/pki"},
+				ACME:    &PKIACME{Enabled: true},
+			},
+		},
+		{
+			name: "ACME without cluster path",
+			spec: PKISecretEngineConfigSpec{ACME: &PKIACME{Enabled: true}},
+			err:  "ACME requires cluster.path",
+		},
+		{
+			name: "disabled ACME without cluster path",
+			spec: PKISecretEngineConfigSpec{ACME: &PKIACME{}},
+		},
+		{
+			name: "templated URLs with cluster AIA path",
+			spec: PKISecretEngineConfigSpec{
+				PKIConfig: PKIConfig{PKIConfigUrls: PKIConfigUrls{
+					EnableTemplating:    true,
+					IssuingCertificates: []string{"{{cluster_aia_path}}/issuer/{{issuer_id}}/der"},
+				}},
+				Cluster: &PKIConfigCluster{AIAPath: "http://vault.example.com/v1/pki"},
+			},
+		},
+		{
+			name: "templated URLs without cluster AIA path",
+			spec: PKISecretEngineConfigSpec{
+				PKIConfig: PKIConfig{PKIConfigUrls: PKIConfigUrls{
+					EnableTemplating:      true,
+					CRLDistributionPoints: []string{"{{cluster_aia_path}}/issuer/{{issuer_id}}/crl/der"},
+				}},
+				Cluster: &PKIConfigCluster{Path: "https://vault.example.com/v1/pki"},
+			},
+			err: "requires cluster.aiaPath",
+		},
+		{
+			name: "templated URLs without cluster",
+			spec: PKISecretEngineConfigSpec{
+				PKIConfig: PKIConfig{PKIConfigUrls: PKIConfigUrls{
+					EnableTemplating: true,
+					OcspServers:      []string{"{{cluster_path}}/ocsp"},
+				}},
+			},
+			err: "requires cluster.path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &PKISecretEngineConfig{Spec: tt.spec}
+			err := config.isValidACME()
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("isValidACME() = %v, expected error containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestPKISecretEngineConfigPayloads(t *testing.T) {
+	config := &PKISecretEngineConfig{Spec: PKISecretEngineConfigSpec{Path: "pki"}}
+	if config.GetConfigClusterPayload() != nil {
+		t.Error("did not expect a cluster payload when cluster is unset")
+	}
+	config.Spec.Cluster = &PKIConfigCluster{Path: "https://vault/v1/pki", AIAPath: "http://vault/v1/pki"}
+	if payload := config.GetConfigClusterPayload(); payload["path"] != "https://vault/v1/pki" || payload["aia_path"] != "http://vault/v1/pki" {
+		t.Errorf("unexpected cluster payload %v", payload)
+	}
+
+	config.Spec.EnableTemplating = true
+	if payload := config.GetConfigUrlsPayload(); payload["enable_templating"] != true {
+		t.Errorf("unexpected urls payload %v", payload)
+	}
+
+	acme := (&PKIACME{Enabled: true, EABPolicy: "always-required"}).toMap()
+	if acme["enabled"] != true || acme["eab_policy"] != "always-required" {
+		t.Errorf("unexpected ACME payload %v", acme)
+	}
+	if _, ok := acme["allowed_issuers"]; ok {
+		t.Error("did not expect allowed_issuers when unset")
+	}
+
+	config.Spec.ACME = &PKIACME{EABSecret: &PKIEABSecret{Name: "eab"}}
+	if path := config.GetNewEABPath(); path != "pki/acme/new-eab" {
+		t.Errorf("GetNewEABPath() = %q", path)
+	}
+	config.Spec.ACME.EABSecret = &PKIEABSecret{Name: "eab", Issuer: "root-2", Role: "web"}
+	if path := config.GetNewEABPath(); path != "pki/issuer/root-2/roles/web/acme/new-eab" {
+		t.Errorf("GetNewEABPath() = %q", path)
+	}
+}
+
+func TestPKISecretEngineConfigReconcileACMEEABSecret(t *testing.T) {
+	handler := &recordingVaultHandler{fakeVaultHandler: newFakeVaultHandler()}
+	handler.setGet("pki/config/acme", map[string]any{})
+	handler.setGet("pki/roles/web/acme/new-eab", map[string]any{
+		"id":             "eab-1",
+		"key":            "c2VjcmV0",
+		"acme_directory": "roles/web/acme/directory",
+	})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	kubeClient := newFakeKubeClient()
+	config := &PKISecretEngineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pki", Namespace: "ns", UID: types.UID("pki-uid")},
+		Spec: PKISecretEngineConfigSpec{
+			Path:    "pki",
+			Cluster: &PKIConfigCluster{Path: "https://vault/v1/pki"},
+			ACME:    &PKIACME{Enabled: true, EABPolicy: "new-account-required", EABSecret: &PKIEABSecret{Name: "eab", Role: "web"}},
+		},
+	}
+	ctx := pivContext(kubeClient, vc)
+	if err := config.ReconcileACME(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if acme := handler.find(http.MethodPut, "pki/config/acme"); acme == nil || acme.body["eab_policy"] != "new-account-required" {
+		t.Errorf("unexpected ACME configuration request %v", acme)
+	}
+	if config.Status.ACME == nil || config.Status.ACME.EABKeyID != "eab-1" || config.Status.ACME.Directory != "roles/web/acme/directory" {
+		t.Fatalf("unexpected ACME status %+v", config.Status.ACME)
+	}
+	secret := &corev1.Secret{}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "eab"}, secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(secret.Data["id"]) != "eab-1" || string(secret.Data["key"]) != "c2VjcmV0" || !metav1.IsControlledBy(secret, config) {
+		t.Errorf("unexpected EAB secret %+v", secret)
+	}
+
+	// the token is not issued again while the secret holds it
+	handler.requests = nil
+	if err := config.ReconcileACME(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler.find(http.MethodPut, "pki/roles/web/acme/new-eab") != nil {
+		t.Error("did not expect another EAB token")
+	}
+
+	// a deleted secret gets a new token, and the previous one is deleted
+	if err := kubeClient.Delete(ctx, secret); err != nil {
+		t.Fatalf("unable to delete secret: %v", err)
+	}
+	handler.requests = nil
+	if err := config.ReconcileACME(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler.find(http.MethodDelete, "pki/eab/eab-1") == nil {
+		t.Error("expected the previous EAB token to be deleted")
+	}
+	if handler.find(http.MethodPut, "pki/roles/web/acme/new-eab") == nil {
+		t.Error("expected a new EAB token")
+	}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "eab"}, &corev1.Secret{}); err != nil {
+		t.Errorf("expected the EAB secret to be created again: %v", err)
+	}
+}
+
+func TestPKISecretEngineConfigReconcileACMERefusesForeignSecret(t *testing.T) {
+	handler := &recordingVaultHandler{fakeVaultHandler: newFakeVaultHandler()}
+	handler.setGet("pki/config/acme", map[string]any{})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	config := &PKISecretEngineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pki", Namespace: "ns", UID: types.UID("pki-uid")},
+		Spec: PKISecretEngineConfigSpec{
+			Path: "pki",
+			ACME: &PKIACME{EABSecret: &PKIEABSecret{Name: "eab"}},
+		},
+	}
+	err := config.ReconcileACME(pivContext(newFakeKubeClient(newK8sSecret("ns", "eab", map[string][]byte{"id": []byte("other")})), vc))
+	if err == nil || !strings.Contains(err.Error(), "not owned") {
+		t.Errorf("expected an ownership error, got %v", err)
+	}
+	if handler.find(http.MethodPut, "pki/acme/new-eab") != nil {
+		t.Error("did not expect an EAB token")
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CAExpiringSoon is true when the CA certificate of the mount expires within the expiring soon threshold, or has expired.
+	CAExpiringSoon = "CAExpiringSoon"
+
+	CAExpiringReason = "Expiring"
+	CAExpiredReason  = "Expired"
+	CAValidReason    = "Valid"
+
+	defaultCAExpiringSoonThreshold = 30 * 24 * time.Hour
+)
+
+// PKICAStatus describes the CA certificate of the mount, as read from Vault.
+type PKICAStatus struct {
+	// SerialNumber is the serial number of the CA certificate, in the colon separated hexadecimal format used by Vault.
+	// +kubebuilder:validation:Optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// Subject is the subject of the CA certificate.
+	// +kubebuilder:validation:Optional
+	Subject string `json:"subject,omitempty"`
+
+	// Issuer is the subject of the certificate that signed the CA certificate.
+	// +kubebuilder:validation:Optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// NotBefore is the start of the validity of the CA certificate.
+	// +kubebuilder:validation:Optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the expiry of the CA certificate.
+	// +kubebuilder:validation:Optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// IssuerChain are the subjects of the certificates of the chain of the CA certificate, from its issuer up to the root.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	IssuerChain []string `json:"issuerChain,omitempty"`
+}
+
+func (p *PKISecretEngineConfig) GetCAPath() string {
+	return vaultutils.CleansePath(p.GetPath() + "/cert/ca")
+}
+
+func (p *PKISecretEngineConfig) getExpiringSoonThreshold() time.Duration {
+	if p.Spec.ExpiringSoonThreshold == nil {
+		return defaultCAExpiringSoonThreshold
+	}
+	return p.Spec.ExpiringSoonThreshold.Duration
+}
+
+// EnrichStatus reads the CA certificate of the mount back from Vault, records it in status and sets the CAExpiringSoon condition.
+func (p *PKISecretEngineConfig) EnrichStatus(ctx context.Context) error {
+	ca, err := p.readCA(ctx)
+	if err != nil {
+		return err
+	}
+	p.Status.CA = ca
+	p.setCAExpiringSoonCondition(time.Now())
+	return nil
+}
+
+// readCA returns the CA certificate of the mount, or nil when the mount has no CA certificate yet, as is the case for an intermediate waiting for its signed certificate.
+func (p *PKISecretEngineConfig) readCA(ctx context.Context) (*PKICAStatus, error) {
+	secret, found, err := vaultutils.ReadSecret(ctx, p.GetCAPath())
+	if err != nil {
+		return nil, err
+	}
+	if !found || secret == nil || secret.Data == nil {
+		return nil, nil
+	}
+	certificate, _ := secret.Data["certificate"].(string)
+	if strings.TrimSpace(certificate) == "" {
+		return nil, nil
+	}
+	cert, err := parseCertificatePEM(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the CA certificate of %s: %w", p.GetPath(), err)
+	}
+
+	chain := []string{}
+	if caChain, ok := secret.Data["ca_chain"].([]any); ok {
+		for _, entry := range caChain {
+			pem, _ := entry.(string)
+			chainCert, err := parseCertificatePEM(pem)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse the CA chain of %s: %w", p.GetPath(), err)
+			}
+			if bytes.Equal(chainCert.Raw, cert.Raw) {
+				continue
+			}
+			chain = append(chain, chainCert.Subject.String())
+		}
+	}
+
+	return &PKICAStatus{
+		SerialNumber: formatSerialNumber(cert),
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		NotBefore:    &metav1.Time{Time: cert.NotBefore},
+		NotAfter:     &metav1.Time{Time: cert.NotAfter},
+		IssuerChain:  chain,
+	}, nil
+}
+
+func (p *PKISecretEngineConfig) setCAExpiringSoonCondition(now time.Time) {
+	if p.Status.CA == nil || p.Status.CA.NotAfter == nil {
+		apimeta.RemoveStatusCondition(&p.Status.Conditions, CAExpiringSoon)
+		return
+	}
+	notAfter := p.Status.CA.NotAfter.Time
+	condition := metav1.Condition{
+		Type:               CAExpiringSoon,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: p.GetGeneration(),
+		Reason:             CAValidReason,
+		Message:            fmt.Sprintf("CA certificate expires at %s", notAfter.UTC().Format(time.RFC3339)),
+	}
+	switch {
+	case !now.Before(notAfter):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = CAExpiredReason
+		condition.Message = fmt.Sprintf("CA certificate expired at %s", notAfter.UTC().Format(time.RFC3339))
+	case !now.Add(p.getExpiringSoonThreshold()).Before(notAfter):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = CAExpiringReason
+	}
+	apimeta.SetStatusCondition(&p.Status.Conditions, condition)
+}
+
+// GetNextExpiryCheck returns when the CAExpiringSoon condition changes next, that is when the CA certificate enters the expiring soon threshold or expires,
+// or when the default issuer is to be rotated as it enters renewBefore, whichever comes first. It returns nil when none of them is ahead.
+func (p *PKISecretEngineConfig) GetNextExpiryCheck(now time.Time) *time.Time {
+	checks := []time.Time{}
+	if p.Status.CA != nil && p.Status.CA.NotAfter != nil {
+		notAfter := p.Status.CA.NotAfter.Time
+		checks = append(checks, notAfter.Add(-p.getExpiringSoonThreshold()), notAfter)
+	}
+	if renewAt := p.getIssuerRenewalTime(); renewAt != nil {
+		checks = append(checks, *renewAt)
+	}
+	var next *time.Time
+	for _, check := range checks {
+		if now.Before(check) && (next == nil || check.Before(*next)) {
+			next = &check
+		}
+	}
+	return next
+}
+
+// formatSerialNumber formats the serial number of the certificate the way Vault does, for example 1f:2a:03.
+func formatSerialNumber(cert *x509.Certificate) string {
+	serial := cert.SerialNumber.Bytes()
+	parts := make([]string, len(serial))
+	for i, b := range serial {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,131 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPKISecretEngineConfigEnrichStatusCA(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ca := newTestCertificatePEM(t, "intermediate", now.Add(-time.Hour), now.Add(365*24*time.Hour))
+	root := newTestCertificatePEM(t, "root", now.Add(-time.Hour), now.Add(3650*24*time.Hour))
+
+	handler := newFakeVaultHandler()
+	handler.setGet("pki/cert/ca", map[string]any{"certificate": ca, "ca_chain": []string{ca, root}})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	config := &PKISecretEngineConfig{Spec: PKISecretEngineConfigSpec{Path: "pki"}}
+	if err := config.EnrichStatus(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status := config.Status.CA
+	if status == nil {
+		t.Fatal("expected the CA to be recorded in status")
+	}
+	if status.SerialNumber != "2a" || status.Subject != "CN=intermediate" || status.Issuer != "CN=intermediate" {
+		t.Errorf("unexpected CA %+v", status)
+	}
+	if !status.NotBefore.Time.Equal(now.Add(-time.Hour)) || !status.NotAfter.Time.Equal(now.Add(365*24*time.Hour)) {
+		t.Errorf("unexpected validity %v - %v", status.NotBefore, status.NotAfter)
+	}
+	if len(status.IssuerChain) != 1 || status.IssuerChain[0] != "CN=root" {
+		t.Errorf("IssuerChain = %v, expected [CN=root]", status.IssuerChain)
+	}
+	if !apimeta.IsStatusConditionFalse(config.Status.Conditions, CAExpiringSoon) {
+		t.Errorf("expected %s to be false, got %v", CAExpiringSoon, config.Status.Conditions)
+	}
+}
+
+func TestPKISecretEngineConfigEnrichStatusWithoutCA(t *testing.T) {
+	handler := newFakeVaultHandler()
+	handler.setGet("pki/cert/ca", map[string]any{"certificate": ""})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	config := &PKISecretEngineConfig{
+		Spec: PKISecretEngineConfigSpec{Path: "pki"},
+		Status: PKISecretEngineConfigStatus{
+			CA:         &PKICAStatus{SerialNumber: "2a"},
+			Conditions: []metav1.Condition{{Type: CAExpiringSoon, Status: metav1.ConditionTrue, Reason: CAExpiringReason}},
+		},
+	}
+	if err := config.EnrichStatus(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Status.CA != nil {
+		t.Errorf("expected no CA, got %+v", config.Status.CA)
+	}
+	if apimeta.FindStatusCondition(config.Status.Conditions, CAExpiringSoon) != nil {
+		t.Errorf("expected %s to be removed", CAExpiringSoon)
+	}
+}
+
+func TestPKISecretEngineConfigCAExpiringSoonCondition(t *testing.T) {
+	now := time.Now()
+	week := &metav1.Duration{Duration: 7 * 24 * time.Hour}
+
+	tests := []struct {
+		name      string
+		threshold *metav1.Duration
+		notAfter  time.Time
+		status    metav1.ConditionStatus
+		reason    string
+	}{
+		{name: "valid", notAfter: now.Add(60 * 24 * time.Hour), status: metav1.ConditionFalse, reason: CAValidReason},
+		{name: "within default threshold", notAfter: now.Add(20 * 24 * time.Hour), status: metav1.ConditionTrue, reason: CAExpiringReason},
+		{name: "outside custom threshold", threshold: week, notAfter: now.Add(20 * 24 * time.Hour), status: metav1.ConditionFalse, reason: CAValidReason},
+		{name: "within custom threshold", threshold: week, notAfter: now.Add(24 * time.Hour), status: metav1.ConditionTrue, reason: CAExpiringReason},
+		{name: "expired", notAfter: now.Add(-time.Hour), status: metav1.ConditionTrue, reason: CAExpiredReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &PKISecretEngineConfig{
+				Spec:   PKISecretEngineConfigSpec{ExpiringSoonThreshold: tt.threshold},
+				Status: PKISecretEngineConfigStatus{CA: &PKICAStatus{NotAfter: &metav1.Time{Time: tt.notAfter}}},
+			}
+			config.setCAExpiringSoonCondition(now)
+			condition := apimeta.FindStatusCondition(config.Status.Conditions, CAExpiringSoon)
+			if condition == nil || condition.Status != tt.status || condition.Reason != tt.reason {
+				t.Errorf("unexpected condition %+v, expected %s/%s", condition, tt.status, tt.reason)
+			}
+		})
+	}
+}
+
+func TestPKISecretEngineConfigGetNextExpiryCheck(t *testing.T) {
+	now := time.Now()
+	week := &metav1.Duration{Duration: 7 * 24 * time.Hour}
+	notAfter := now.Add(20 * 24 * time.Hour)
+	expiringSoon := notAfter.Add(-week.Duration)
+
+	tests := []struct {
+		name      string
+		threshold *metav1.Duration
+		ca        *PKICAStatus
+		expected  *time.Time
+	}{
+		{name: "no CA"},
+		{name: "before the threshold", threshold: week, ca: &PKICAStatus{NotAfter: &metav1.Time{Time: notAfter}}, expected: &expiringSoon},
+		{name: "within the threshold", ca: &PKICAStatus{NotAfter: &metav1.Time{Time: notAfter}}, expected: &notAfter},
+		{name: "expired", ca: &PKICAStatus{NotAfter: &metav1.Time{Time: now.Add(-time.Hour)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &PKISecretEngineConfig{
+				Spec:   PKISecretEngineConfigSpec{ExpiringSoonThreshold: tt.threshold},
+				Status: PKISecretEngineConfigStatus{CA: tt.ca},
+			}
+			next := config.GetNextExpiryCheck(now)
+			if (next == nil) != (tt.expected == nil) || (next != nil && !next.Equal(*tt.expected)) {
+				t.Errorf("GetNextExpiryCheck() = %v, expected %v", next, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// PrivateKeyLost is true when the exported private key was deleted or changed. Vault does not keep exported private keys, so the condition is terminal.
+	PrivateKeyLost = "PrivateKeyLost"
+
+	PrivateKeySecretDeletedReason = "SecretDeleted"
+	PrivateKeyChangedReason       = "PrivateKeyChanged"
+
+	// ExportedSecretHashAnnotation is the hash of the data of the exported secret, as last written by the operator.
+	ExportedSecretHashAnnotation = "pkisecretengineconfig.redhatcop.redhat.io/secret-hash"
+)
+
+// PKIExportedSecret configures the secret the private key is exported to, when privateKeyType is exported.
+type PKIExportedSecret struct {
+	// Name is the name of the secret, in the same namespace. Defaults to the name of the PKISecretEngineConfig. Changing it after the private key was exported has no effect.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+
+	// Type is the layout of the secret: Opaque, with the keys returned by Vault, or kubernetes.io/tls, with the keys tls.crt, tls.key and ca.crt. Changing it after the private key was exported has no effect.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:={"Opaque","kubernetes.io/tls"}
+	// +kubebuilder:default="Opaque"
+	Type corev1.SecretType `json:"type,omitempty"`
+}
+
+// PKIExportedSecretStatus records the secret the private key was exported to.
+type PKIExportedSecretStatus struct {
+	// Name is the name of the secret.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+
+	// Type is the layout of the secret.
+	// +kubebuilder:validation:Optional
+	Type corev1.SecretType `json:"type,omitempty"`
+
+	// Hash is the hash of the data of the secret, as last written by the operator.
+	// +kubebuilder:validation:Optional
+	Hash string `json:"hash,omitempty"`
+
+	// PrivateKeyHash is the hash of the exported private key, to detect its loss.
+	// +kubebuilder:validation:Optional
+	PrivateKeyHash string `json:"privateKeyHash,omitempty"`
+}
+
+// GetExportedSecretName returns the name of the exported secret, as recorded at export when it already happened.
+func (p *PKISecretEngineConfig) GetExportedSecretName() string {
+	if p.Status.ExportedSecret != nil && p.Status.ExportedSecret.Name != "" {
+		return p.Status.ExportedSecret.Name
+	}
+	if p.Spec.ExportedSecret != nil && p.Spec.ExportedSecret.Name != "" {
+		return p.Spec.ExportedSecret.Name
+	}
+	return p.Name
+}
+
+func (p *PKISecretEngineConfig) getExportedSecretType() corev1.SecretType {
+	if p.Status.ExportedSecret != nil && p.Status.ExportedSecret.Type != "" {
+		return p.Status.ExportedSecret.Type
+	}
+	if p.Spec.ExportedSecret != nil && p.Spec.ExportedSecret.Type != "" {
+		return p.Spec.ExportedSecret.Type
+	}
+	return corev1.SecretTypeOpaque
+}
+
+// getPrivateKeyKeys returns the keys of the exported secret holding the private key.
+func (p *PKISecretEngineConfig) getPrivateKeyKeys() []string {
+	if p.getExportedSecretType() == corev1.SecretTypeTLS {
+		return []string{corev1.TLSPrivateKeyKey}
+	}
+	return []string{"private_key", "private_key_type"}
+}
+
+// getExportedSecretData lays out the payload returned by Vault at generation for the type of the exported secret.
+func (p *PKISecretEngineConfig) getExportedSecretData(payload map[string]string) map[string][]byte {
+	data := map[string][]byte{}
+	if p.getExportedSecretType() != corev1.SecretTypeTLS {
+		for key, value := range payload {
+			data[key] = []byte(value)
+		}
+		return data
+	}
+	// the certificate of an intermediate is added once it is signed
+	data[corev1.TLSCertKey] = []byte(payload["certificate"])
+	data[corev1.TLSPrivateKeyKey] = []byte(payload["private_key"])
+	if p.Spec.Type == "intermediate" {
+		data["csr"] = []byte(payload["csr"])
+	} else {
+		data["ca.crt"] = []byte(payload["issuing_ca"])
+	}
+	return data
+}
+
+// writeExportedSecret writes the data to the exported secret and records it in status.
+func (p *PKISecretEngineConfig) writeExportedSecret(context context.Context, data map[string][]byte) error {
+	kubeClient := vaultutils.KubeClientFromContext(context)
+	hash := hashSecretData(data)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.GetExportedSecretName(),
+			Namespace: p.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(context, kubeClient, secret, func() error {
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, p) {
+			return fmt.Errorf("secret %s already exists and is not owned by the PKISecretEngineConfig", secret.Name)
+		}
+		if secret.ResourceVersion == "" {
+			secret.Type = p.getExportedSecretType()
+		}
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels["redhatcop.redhat.io/pkisecretengineconfigs"] = p.Name
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[ExportedSecretHashAnnotation] = hash
+		secret.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(p, GroupVersion.WithKind("PKISecretEngineConfig"))}
+		secret.Data = data
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if p.Status.ExportedSecret == nil {
+		p.Status.ExportedSecret = &PKIExportedSecretStatus{
+			Name:           p.GetExportedSecretName(),
+			Type:           p.getExportedSecretType(),
+			PrivateKeyHash: p.hashPrivateKey(data),
+		}
+	}
+	p.Status.ExportedSecret.Hash = hash
+	return nil
+}
+
+// ReconcileExported restores the exported secret when it was deleted or changed. The certificates are read back from Vault, the private key cannot be, so its loss is recorded with the PrivateKeyLost condition.
+func (p *PKISecretEngineConfig) ReconcileExported(context context.Context) error {
+	if !p.Status.Exported {
+		return nil
+	}
+	log := log.FromContext(context)
+	kubeClient := vaultutils.KubeClientFromContext(context)
+
+	publicData, err := p.getExportedPublicData(context)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{}
+	err = kubeClient.Get(context, types.NamespacedName{Namespace: p.Namespace, Name: p.GetExportedSecretName()}, secret)
+	if apierrors.IsNotFound(err) {
+		log.Info("exported secret deleted, the private key is lost", "secret", p.GetExportedSecretName())
+		p.setPrivateKeyLost(PrivateKeySecretDeletedReason, fmt.Sprintf("the exported secret %s was deleted, the private key cannot be recovered from Vault", p.GetExportedSecretName()))
+		data := publicData
+		if p.getExportedSecretType() == corev1.SecretTypeTLS {
+			data[corev1.TLSPrivateKeyKey] = []byte{}
+		}
+		return p.writeExportedSecret(context, data)
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(secret, p) {
+		return fmt.Errorf("secret %s is not owned by the PKISecretEngineConfig", secret.Name)
+	}
+
+	// the private key was exported before it was tracked, it is trusted as it is
+	if p.Status.ExportedSecret == nil || p.Status.ExportedSecret.PrivateKeyHash == "" {
+		p.Status.ExportedSecret = &PKIExportedSecretStatus{
+			Name: p.GetExportedSecretName(),
+			Type: secret.Type,
+		}
+		p.Status.ExportedSecret.PrivateKeyHash = p.hashPrivateKey(secret.Data)
+	}
+	if p.hashPrivateKey(secret.Data) != p.Status.ExportedSecret.PrivateKeyHash {
+		log.Info("exported private key changed, the private key is lost", "secret", secret.Name)
+		p.setPrivateKeyLost(PrivateKeyChangedReason, fmt.Sprintf("the private key of the exported secret %s was changed, the private key cannot be recovered from Vault", secret.Name))
+	}
+
+	data := maps.Clone(secret.Data)
+	if data == nil {
+		data = map[string][]byte{}
+	}
+	maps.Copy(data, publicData)
+	hash := hashSecretData(data)
+	if secret.Annotations[ExportedSecretHashAnnotation] == hash && maps.EqualFunc(data, secret.Data, bytes.Equal) {
+		p.Status.ExportedSecret.Hash = hash
+		return nil
+	}
+	log.Info("restoring exported secret", "secret", secret.Name)
+	return p.writeExportedSecret(context, data)
+}
+
+// getExportedPublicData returns the certificates of the exported secret, read back from Vault. It is empty for an intermediate waiting for its signed certificate.
+func (p *PKISecretEngineConfig) getExportedPublicData(context context.Context) (map[string][]byte, error) {
+	data := map[string][]byte{}
+	secret, found, err := vaultutils.ReadSecret(context, p.GetCAPath())
+	if err != nil {
+		return nil, err
+	}
+	if !found || secret == nil || secret.Data == nil {
+		return data, nil
+	}
+	certificate := strings.TrimSpace(vaultutils.ToString(secret.Data["certificate"]))
+	if certificate == "" {
+		return data, nil
+	}
+	cert, err := parseCertificatePEM(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the CA certificate of %s: %w", p.GetPath(), err)
+	}
+	issuingCA := certificate
+	if p.Spec.Type == "intermediate" {
+		chain := []string{}
+		if caChain, ok := secret.Data["ca_chain"].([]any); ok {
+			for _, entry := range caChain {
+				pem := strings.TrimSpace(vaultutils.ToString(entry))
+				if pem != "" && pem != certificate {
+					chain = append(chain, pem)
+				}
+			}
+		}
+		issuingCA = strings.Join(chain, "\n")
+	}
+
+	if p.getExportedSecretType() == corev1.SecretTypeTLS {
+		data[corev1.TLSCertKey] = []byte(certificate)
+		data["ca.crt"] = []byte(issuingCA)
+		return data, nil
+	}
+	// the legacy layout of an intermediate only holds the csr and the private key
+	if p.Spec.Type == "root" {
+		data["certificate"] = []byte(certificate)
+		data["issuing_ca"] = []byte(issuingCA)
+		data["serial_number"] = []byte(formatSerialNumber(cert))
+		data["expiration"] = []byte(strconv.FormatInt(cert.NotAfter.Unix(), 10))
+	}
+	return data, nil
+}
+
+// setPrivateKeyLost sets the PrivateKeyLost condition, keeping the first cause of the loss.
+func (p *PKISecretEngineConfig) setPrivateKeyLost(reason string, message string) {
+	if apimeta.IsStatusConditionTrue(p.Status.Conditions, PrivateKeyLost) {
+		return
+	}
+	apimeta.SetStatusCondition(&p.Status.Conditions, metav1.Condition{
+		Type:               PrivateKeyLost,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: p.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// hashPrivateKey returns a hash of the private key held in the data of the exported secret.
+func (p *PKISecretEngineConfig) hashPrivateKey(data map[string][]byte) string {
+	hash := sha256.New()
+	for _, key := range p.getPrivateKeyKeys() {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(data[key])
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// hashSecretData returns a hash of the data of a secret, independent of the order of its keys.
+func hashSecretData(data map[string][]byte) string {
+	hash := sha256.New()
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(data[key])
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,200 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newExportedPKISecretEngineConfig(secretType corev1.SecretType) *PKISecretEngineConfig {
+	return &PKISecretEngineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pki", Namespace: "ns", UID: types.UID("pki-uid")},
+		Spec: PKISecretEngineConfigSpec{
+			Path:           "pki",
+			PKIType:        PKIType{Type: "root", PrivateKeyType: "exported"},
+			ExportedSecret: &PKIExportedSecret{Name: "pki-ca", Type: secretType},
+		},
+	}
+}
+
+func getExportedSecret(t *testing.T, kubeClient client.Client) *corev1.Secret {
+	t.Helper()
+	secret := &corev1.Secret{}
+	if err := kubeClient.Get(t.Context(), types.NamespacedName{Namespace: "ns", Name: "pki-ca"}, secret); err != nil {
+		t.Fatalf("unable to get the exported secret: %v", err)
+	}
+	return secret
+}
+
+func TestPKISecretEngineConfigCreateExportedTLS(t *testing.T) {
+	kubeClient := newFakeKubeClient()
+	config := newExportedPKISecretEngineConfig(corev1.SecretTypeTLS)
+	generated := &vault.Secret{Data: map[string]any{
+		"certificate":      "CERT",
+		"issuing_ca":       "CERT",
+		"expiration":       json.Number("1790000000"),
+		"serial_number":    "2a",
+		"private_key":      "KEY",
+		"private_key_type": "rsa",
+	}}
+
+	exported, err := config.CreateExported(pivContext(kubeClient, nil), generated)
+	if err != nil || !exported {
+		t.Fatalf("CreateExported() = %v, %v", exported, err)
+	}
+
+	secret := getExportedSecret(t, kubeClient)
+	if secret.Type != corev1.SecretTypeTLS || string(secret.Data["tls.crt"]) != "CERT" || string(secret.Data["tls.key"]) != "KEY" || string(secret.Data["ca.crt"]) != "CERT" {
+		t.Errorf("unexpected exported secret %+v", secret)
+	}
+	if _, ok := secret.Data["private_key"]; ok {
+		t.Error("did not expect the Vault keys in the TLS layout")
+	}
+	status := config.Status.ExportedSecret
+	if status == nil || status.Name != "pki-ca" || status.Type != corev1.SecretTypeTLS || status.PrivateKeyHash == "" {
+		t.Fatalf("unexpected exported secret status %+v", status)
+	}
+	if secret.Annotations[ExportedSecretHashAnnotation] != status.Hash || status.Hash != hashSecretData(secret.Data) {
+		t.Errorf("unexpected hash %q, expected %q", secret.Annotations[ExportedSecretHashAnnotation], status.Hash)
+	}
+}
+
+func TestPKISecretEngineConfigReconcileExported(t *testing.T) {
+	now := time.Now()
+	certificate := strings.TrimSpace(newTestCertificatePEM(t, "root", now.Add(-time.Hour), now.Add(24*time.Hour)))
+	handler := newFakeVaultHandler()
+	handler.setGet("pki/cert/ca", map[string]any{"certificate": certificate})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	kubeClient := newFakeKubeClient()
+	ctx := pivContext(kubeClient, vc)
+	config := newExportedPKISecretEngineConfig(corev1.SecretTypeTLS)
+	_, err := config.CreateExported(ctx, &vault.Secret{Data: map[string]any{
+		"certificate": certificate,
+		"issuing_ca":  certificate,
+		"expiration":  json.Number("1790000000"),
+		"private_key": "KEY",
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config.Status.Exported = true
+
+	// an unchanged secret is not written again
+	resourceVersion := getExportedSecret(t, kubeClient).ResourceVersion
+	if err := config.ReconcileExported(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if getExportedSecret(t, kubeClient).ResourceVersion != resourceVersion {
+		t.Error("did not expect the exported secret to be written")
+	}
+
+	// a changed certificate is restored from Vault
+	secret := getExportedSecret(t, kubeClient)
+	secret.Data["tls.crt"] = []byte("TAMPERED")
+	if err := kubeClient.Update(ctx, secret); err != nil {
+		t.Fatalf("unable to update secret: %v", err)
+	}
+	if err := config.ReconcileExported(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	secret = getExportedSecret(t, kubeClient)
+	if string(secret.Data["tls.crt"]) != certificate || string(secret.Data["tls.key"]) != "KEY" {
+		t.Errorf("expected the certificate to be restored, got %+v", secret.Data)
+	}
+	if apimeta.FindStatusCondition(config.Status.Conditions, PrivateKeyLost) != nil {
+		t.Errorf("did not expect %s", PrivateKeyLost)
+	}
+
+	// a changed private key is lost
+	secret.Data["tls.key"] = []byte("OTHER")
+	if err := kubeClient.Update(ctx, secret); err != nil {
+		t.Fatalf("unable to update secret: %v", err)
+	}
+	if err := config.ReconcileExported(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	condition := apimeta.FindStatusCondition(config.Status.Conditions, PrivateKeyLost)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != PrivateKeyChangedReason {
+		t.Fatalf("unexpected condition %+v", condition)
+	}
+
+	// a deleted secret is created again without the private key, the first cause of the loss is kept
+	if err := kubeClient.Delete(ctx, secret); err != nil {
+		t.Fatalf("unable to delete secret: %v", err)
+	}
+	if err := config.ReconcileExported(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	secret = getExportedSecret(t, kubeClient)
+	if string(secret.Data["tls.crt"]) != certificate || len(secret.Data["tls.key"]) != 0 {
+		t.Errorf("unexpected restored secret %+v", secret.Data)
+	}
+	condition = apimeta.FindStatusCondition(config.Status.Conditions, PrivateKeyLost)
+	if condition == nil || condition.Reason != PrivateKeyChangedReason {
+		t.Errorf("unexpected condition %+v", condition)
+	}
+}
+
+func TestPKISecretEngineConfigReconcileExportedDeletedSecret(t *testing.T) {
+	now := time.Now()
+	certificate := newTestCertificatePEM(t, "root", now.Add(-time.Hour), now.Add(24*time.Hour))
+	handler := newFakeVaultHandler()
+	handler.setGet("pki/cert/ca", map[string]any{"certificate": certificate})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	kubeClient := newFakeKubeClient()
+	config := newExportedPKISecretEngineConfig(corev1.SecretTypeOpaque)
+	config.Status.Exported = true
+	config.Status.ExportedSecret = &PKIExportedSecretStatus{Name: "pki-ca", Type: corev1.SecretTypeOpaque, Hash: "h", PrivateKeyHash: "k"}
+
+	if err := config.ReconcileExported(pivContext(kubeClient, vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	condition := apimeta.FindStatusCondition(config.Status.Conditions, PrivateKeyLost)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != PrivateKeySecretDeletedReason {
+		t.Fatalf("unexpected condition %+v", condition)
+	}
+	secret := getExportedSecret(t, kubeClient)
+	if string(secret.Data["certificate"]) != strings.TrimSpace(certificate) || string(secret.Data["serial_number"]) != "2a" {
+		t.Errorf("unexpected restored secret %+v", secret.Data)
+	}
+	if _, ok := secret.Data["private_key"]; ok {
+		t.Error("did not expect a private key in the restored secret")
+	}
+}
+
+func TestPKISecretEngineConfigReconcileExportedAdoptsSecret(t *testing.T) {
+	handler := newFakeVaultHandler()
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	config := newExportedPKISecretEngineConfig("")
+	config.Spec.ExportedSecret = nil
+	config.Spec.Type = "intermediate"
+	config.Status.Exported = true
+	existing := newK8sSecret("ns", "pki", map[string][]byte{"csr": []byte("CSR"), "private_key": []byte("KEY"), "private_key_type": []byte("rsa")})
+	existing.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(config, GroupVersion.WithKind("PKISecretEngineConfig"))}
+	kubeClient := newFakeKubeClient(existing)
+
+	if err := config.ReconcileExported(pivContext(kubeClient, vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := config.Status.ExportedSecret
+	if status == nil || status.Name != "pki" || status.PrivateKeyHash == "" || status.Hash != hashSecretData(existing.Data) {
+		t.Errorf("unexpected exported secret status %+v", status)
+	}
+	if apimeta.FindStatusCondition(config.Status.Conditions, PrivateKeyLost) != nil {
+		t.Errorf("did not expect %s", PrivateKeyLost)
+	}
+}
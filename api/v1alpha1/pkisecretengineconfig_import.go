@@ -0,0 +1,261 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PKIImport imports an externally generated CA certificate and its private key from a Kubernetes secret, instead of generating them in Vault.
+type PKIImport struct {
+	// Secret is the secret holding the PEM encoded CA certificate and private key, in the same namespace.
+	// +kubebuilder:validation:Required
+	Secret corev1.LocalObjectReference `json:"secret"`
+
+	// CertificateKey is the key of the secret holding the CA certificate, optionally followed by its issuer chain.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="tls.crt"
+	CertificateKey string `json:"certificateKey,omitempty"`
+
+	// PrivateKeyKey is the key of the secret holding the private key of the CA certificate. Encrypted private keys are not supported.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="tls.key"
+	PrivateKeyKey string `json:"privateKeyKey,omitempty"`
+
+	// CAKey is the key of the secret holding the issuer chain of the CA certificate, when it is not in the certificate key. It is ignored when the secret does not have it.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="ca.crt"
+	CAKey string `json:"caKey,omitempty"`
+}
+
+// IsImport returns whether the CA of the mount is imported instead of generated.
+func (p *PKISecretEngineConfig) IsImport() bool {
+	return p.Spec.Import != nil
+}
+
+// GetImportPath returns issuers/import/bundle when the issuers are managed, config/ca otherwise.
+func (p *PKISecretEngineConfig) GetImportPath() string {
+	if p.Spec.Issuers != nil {
+		return vaultutils.CleansePath(p.GetPath() + "/issuers/import/bundle")
+	}
+	return vaultutils.CleansePath(p.GetPath() + "/config/ca")
+}
+
+// ImportCA verifies the bundle of the import secret and imports it into the mount. The bundle holds the private key, it is neither logged nor kept.
+func (p *PKISecretEngineConfig) ImportCA(context context.Context) error {
+	log := log.FromContext(context)
+	kubeClient := vaultutils.KubeClientFromContext(context)
+	vaultClient := vaultutils.VaultClientFromContext(context)
+
+	secret := &corev1.Secret{}
+	err := kubeClient.Get(context, types.NamespacedName{Namespace: p.Namespace, Name: p.Spec.Import.Secret.Name}, secret)
+	if err != nil {
+		log.Error(err, "unable to retrieve import secret", "name", p.Spec.Import.Secret.Name)
+		return err
+	}
+	bundle, err := p.getImportBundle(secret.Data, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid CA bundle in secret %s: %w", secret.Name, err)
+	}
+
+	response, err := vaultClient.Logical().WriteWithContext(context, p.GetImportPath(), map[string]any{"pem_bundle": bundle})
+	if err != nil {
+		log.Error(err, "unable to import CA bundle", "path", p.GetImportPath())
+		return err
+	}
+	if p.Spec.Issuers == nil {
+		return nil
+	}
+
+	// the imported CA is the first generation of the managed issuers
+	id, err := getImportedKeyIssuerID(response)
+	if err != nil {
+		return err
+	}
+	err = p.nameIssuer(context, id, 1)
+	if err != nil {
+		return err
+	}
+	err = p.setDefaultIssuer(context, id)
+	if err != nil {
+		return err
+	}
+	p.Status.IssuerGeneration = 1
+	return nil
+}
+
+// getImportBundle returns the private key followed by the CA certificate and its issuer chain, after verifying that the key matches the certificate and that each certificate of the chain is signed by the next one.
+func (p *PKISecretEngineConfig) getImportBundle(data map[string][]byte, now time.Time) (string, error) {
+	var certificates []*x509.Certificate
+	var keyBlock *pem.Block
+	for _, key := range []string{p.getImportCertificateKey(), p.getImportCAKey(), p.getImportPrivateKeyKey()} {
+		rest := data[key]
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			switch block.Type {
+			case "CERTIFICATE":
+				certificate, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					return "", fmt.Errorf("unable to parse certificate in key %s: %w", key, err)
+				}
+				// the chain may be repeated in the CA key
+				if !slices.ContainsFunc(certificates, certificate.Equal) {
+					certificates = append(certificates, certificate)
+				}
+			case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+				if keyBlock != nil && !bytes.Equal(keyBlock.Bytes, block.Bytes) {
+					return "", errors.New("more than one private key found")
+				}
+				keyBlock = block
+			case "ENCRYPTED PRIVATE KEY":
+				return "", errors.New("encrypted private keys are not supported")
+			}
+		}
+	}
+	if len(certificates) == 0 {
+		return "", fmt.Errorf("no PEM certificate found in key %s", p.getImportCertificateKey())
+	}
+	if keyBlock == nil {
+		return "", fmt.Errorf("no PEM private key found in key %s", p.getImportPrivateKeyKey())
+	}
+
+	privateKey, err := parsePrivateKey(keyBlock)
+	if err != nil {
+		return "", err
+	}
+	certificate := certificates[0]
+	publicKey, ok := privateKey.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(certificate.PublicKey) {
+		return "", errors.New("the private key does not match the CA certificate")
+	}
+	if !certificate.BasicConstraintsValid || !certificate.IsCA {
+		return "", fmt.Errorf("certificate %s is not a CA certificate", certificate.Subject)
+	}
+	if now.Before(certificate.NotBefore) || now.After(certificate.NotAfter) {
+		return "", fmt.Errorf("certificate %s is not valid at %s", certificate.Subject, now.UTC().Format(time.RFC3339))
+	}
+	for i := 0; i+1 < len(certificates); i++ {
+		if err := certificates[i].CheckSignatureFrom(certificates[i+1]); err != nil {
+			return "", fmt.Errorf("certificate %s is not signed by %s, the chain must be ordered from the CA certificate to the root: %w", certificates[i].Subject, certificates[i+1].Subject, err)
+		}
+	}
+	selfSigned := certificate.CheckSignatureFrom(certificate) == nil
+	if p.Spec.Type == "root" && !selfSigned {
+		return "", fmt.Errorf("certificate %s of a root must be self-signed", certificate.Subject)
+	}
+	if p.Spec.Type == "intermediate" && (selfSigned || len(certificates) < 2) {
+		return "", fmt.Errorf("certificate %s of an intermediate must be followed by its issuer chain", certificate.Subject)
+	}
+
+	bundle := pem.EncodeToMemory(keyBlock)
+	for _, certificate := range certificates {
+		bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})...)
+	}
+	return string(bundle), nil
+}
+
+func (p *PKISecretEngineConfig) getImportCertificateKey() string {
+	if p.Spec.Import.CertificateKey != "" {
+		return p.Spec.Import.CertificateKey
+	}
+	return corev1.TLSCertKey
+}
+
+func (p *PKISecretEngineConfig) getImportPrivateKeyKey() string {
+	if p.Spec.Import.PrivateKeyKey != "" {
+		return p.Spec.Import.PrivateKeyKey
+	}
+	return corev1.TLSPrivateKeyKey
+}
+
+func (p *PKISecretEngineConfig) getImportCAKey() string {
+	if p.Spec.Import.CAKey != "" {
+		return p.Spec.Import.CAKey
+	}
+	return "ca.crt"
+}
+
+// isValidImport checks that the imported CA is not also generated, signed or rotated by Vault.
+func (p *PKISecretEngineConfig) isValidImport() error {
+	if p.Spec.Import == nil {
+		return nil
+	}
+	if p.Spec.PrivateKeyType == "exported" {
+		return errors.New("import requires privateKeyType internal")
+	}
+	if p.Spec.InternalSign != nil || p.Spec.ExternalSignSecret != nil {
+		return errors.New("import cannot be combined with internalSign or externalSignSecret")
+	}
+	if p.Spec.Issuers != nil && p.Spec.Issuers.Rotation != nil {
+		return errors.New("issuer rotation is not supported with an imported CA")
+	}
+	return nil
+}
+
+// getImportedKeyIssuerID returns the ID of the imported issuer having a key, the other imported issuers being its issuer chain.
+func getImportedKeyIssuerID(secret *vault.Secret) (string, error) {
+	if secret != nil {
+		if mapping, ok := secret.Data["mapping"].(map[string]any); ok {
+			for id, keyID := range mapping {
+				if vaultutils.ToString(keyID) != "" {
+					return id, nil
+				}
+			}
+		}
+	}
+	return "", errors.New("no issuer with a key imported by Vault")
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	// the parse errors do not hold the key
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+	return signer, nil
+}
@@ -0,0 +1,420 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"cmp"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PKIIssuers configures the issuers of a multi-issuer mount, available since Vault 1.11.
+type PKIIssuers struct {
+	// IssuerName is the name of the issuer generated by this configuration. The issuers generated by the following rotations are named {issuerName}-{generation}.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	IssuerName string `json:"issuerName"`
+
+	// KeyName is the name of the key generated with the issuer, named after the generation like the issuer. Defaults to the issuer name.
+	// +kubebuilder:validation:Optional
+	KeyName string `json:"keyName,omitempty"`
+
+	// DefaultIssuer is the name or the ID of the default issuer of the mount. If not specified, the last issuer generated by this configuration is the default issuer.
+	// +kubebuilder:validation:Optional
+	DefaultIssuer string `json:"defaultIssuer,omitempty"`
+
+	// Rotation configures the generation of new issuers alongside the current ones.
+	// +kubebuilder:validation:Optional
+	Rotation *PKIIssuerRotation `json:"rotation,omitempty"`
+}
+
+// PKIIssuerRotation configures the generation of new issuers. A new issuer is generated when the generation is increased, or when the default issuer is about to expire. The new issuer becomes the default issuer, unless defaultIssuer is set, and the previous issuers are kept so that the certificates they issued can still be verified.
+type PKIIssuerRotation struct {
+	// Generation is the desired generation of the issuer. Increasing it generates a new issuer.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	Generation int `json:"generation,omitempty"`
+
+	// RenewBefore generates a new issuer when the default issuer expires within this duration. It is ignored when defaultIssuer is set.
+	// +kubebuilder:validation:Optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// CrossSign cross-signs the key of each new root issuer with the previous default issuer, so that the clients trusting the previous issuer trust the certificates issued by the new one. Only available for roots.
+	// +kubebuilder:validation:Optional
+	CrossSign bool `json:"crossSign,omitempty"`
+}
+
+// PKIIssuerStatus is an issuer of the mount.
+type PKIIssuerStatus struct {
+	// ID is the Vault-assigned identifier of the issuer.
+	ID string `json:"id"`
+
+	// Name is the name of the issuer.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+
+	// KeyID is the identifier of the key of the issuer.
+	// +kubebuilder:validation:Optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// Default is true for the default issuer of the mount.
+	// +kubebuilder:validation:Optional
+	Default bool `json:"default,omitempty"`
+
+	// NotAfter is the expiry of the certificate of the issuer.
+	// +kubebuilder:validation:Optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+}
+
+// getIssuerName returns the name of the issuer of the given generation.
+func (i *PKIIssuers) getIssuerName(generation int) string {
+	return generationName(i.IssuerName, generation)
+}
+
+// getKeyName returns the name of the key of the given generation.
+func (i *PKIIssuers) getKeyName(generation int) string {
+	if i.KeyName == "" {
+		return i.getIssuerName(generation)
+	}
+	return generationName(i.KeyName, generation)
+}
+
+func (i *PKIIssuers) getGeneration() int {
+	if i.Rotation == nil || i.Rotation.Generation < 1 {
+		return 1
+	}
+	return i.Rotation.Generation
+}
+
+func generationName(name string, generation int) string {
+	if generation <= 1 {
+		return name
+	}
+	return fmt.Sprintf("%s-%d", name, generation)
+}
+
+func (p *PKISecretEngineConfig) GetIssuersPath() string {
+	return string(p.Spec.Path) + "/issuers"
+}
+
+func (p *PKISecretEngineConfig) GetIssuerPath(ref string) string {
+	return string(p.Spec.Path) + "/issuer/" + ref
+}
+
+func (p *PKISecretEngineConfig) GetConfigIssuersPath() string {
+	return string(p.Spec.Path) + "/config/issuers"
+}
+
+// GetGeneratePayload returns the payload generating the CA, with the names of the issuer and the key of the given generation when the issuers are managed.
+func (p *PKISecretEngineConfig) GetGeneratePayload() map[string]any {
+	return p.getGeneratePayload(1)
+}
+
+func (p *PKISecretEngineConfig) getGeneratePayload(generation int) map[string]any {
+	payload := p.GetPayload()
+	if p.Spec.Issuers != nil {
+		payload["key_name"] = p.Spec.Issuers.getKeyName(generation)
+		// intermediates are named once signed
+		if p.Spec.Type == "root" {
+			payload["issuer_name"] = p.Spec.Issuers.getIssuerName(generation)
+		}
+	}
+	return payload
+}
+
+// ReconcileIssuers names the issuer of an existing mount, rotates the issuer when requested or when the default issuer is about to expire, sets the default issuer, and records the issuers of the mount in status.
+func (p *PKISecretEngineConfig) ReconcileIssuers(context context.Context) error {
+	if p.Spec.Issuers == nil {
+		p.Status.Issuers = nil
+		p.Status.IssuerGeneration = 0
+		return nil
+	}
+	issuers, defaultID, err := p.readIssuers(context)
+	if err != nil {
+		return err
+	}
+
+	// the mount was generated before its issuers were managed, the current default issuer is the first generation
+	if p.Status.IssuerGeneration == 0 {
+		if defaultID != "" {
+			err := p.nameIssuer(context, defaultID, 1)
+			if err != nil {
+				return err
+			}
+		}
+		p.Status.IssuerGeneration = 1
+	}
+
+	if p.needsRotation(issuers, defaultID, time.Now()) {
+		generation := p.Status.IssuerGeneration + 1
+		newID, err := p.rotateIssuer(context, generation, defaultID)
+		// the generation is recorded as soon as the issuer exists, so that a failure of the following steps does not generate another one
+		if newID != "" {
+			p.Status.IssuerGeneration = generation
+		}
+		if err != nil {
+			return err
+		}
+		if p.Spec.Issuers.DefaultIssuer == "" {
+			err := p.setDefaultIssuer(context, newID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if p.Spec.Issuers.DefaultIssuer != "" {
+		if !slices.ContainsFunc(issuers, func(issuer PKIIssuerStatus) bool {
+			return issuer.Default && (issuer.ID == p.Spec.Issuers.DefaultIssuer || issuer.Name == p.Spec.Issuers.DefaultIssuer)
+		}) {
+			err := p.setDefaultIssuer(context, p.Spec.Issuers.DefaultIssuer)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	issuers, _, err = p.readIssuers(context)
+	if err != nil {
+		return err
+	}
+	p.Status.Issuers = issuers
+	return nil
+}
+
+// needsRotation returns whether the generation was increased, or the default issuer expires within renewBefore.
+func (p *PKISecretEngineConfig) needsRotation(issuers []PKIIssuerStatus, defaultID string, now time.Time) bool {
+	if p.Spec.Issuers.getGeneration() > p.Status.IssuerGeneration {
+		return true
+	}
+	rotation := p.Spec.Issuers.Rotation
+	if rotation == nil || rotation.RenewBefore == nil || p.Spec.Issuers.DefaultIssuer != "" {
+		return false
+	}
+	for _, issuer := range issuers {
+		if issuer.ID == defaultID && issuer.NotAfter != nil {
+			return !now.Add(rotation.RenewBefore.Duration).Before(issuer.NotAfter.Time)
+		}
+	}
+	return false
+}
+
+// getIssuerRenewalTime returns when the default issuer recorded in status enters renewBefore, or nil when the issuer is not rotated on expiry.
+func (p *PKISecretEngineConfig) getIssuerRenewalTime() *time.Time {
+	if p.Spec.Issuers == nil {
+		return nil
+	}
+	rotation := p.Spec.Issuers.Rotation
+	if rotation == nil || rotation.RenewBefore == nil || p.Spec.Issuers.DefaultIssuer != "" {
+		return nil
+	}
+	for _, issuer := range p.Status.Issuers {
+		if issuer.Default && issuer.NotAfter != nil {
+			renewAt := issuer.NotAfter.Add(-rotation.RenewBefore.Duration)
+			return &renewAt
+		}
+	}
+	return nil
+}
+
+// rotateIssuer generates the issuer of the given generation alongside the current ones and returns its ID.
+func (p *PKISecretEngineConfig) rotateIssuer(context context.Context, generation int, previousID string) (string, error) {
+	log := log.FromContext(context)
+	log.Info("generating a new issuer", "generation", generation)
+	if p.Spec.Type == "root" {
+		secret, err := p.writeIssuerRequest(context, string(p.Spec.Path)+"/root/rotate/"+p.Spec.PrivateKeyType, p.getGeneratePayload(generation))
+		if err != nil {
+			return "", err
+		}
+		id := vaultutils.ToString(secret.Data["issuer_id"])
+		if id == "" {
+			return "", errors.New("no issuer_id returned by Vault at the rotation of the root")
+		}
+		if p.Spec.Issuers.Rotation != nil && p.Spec.Issuers.Rotation.CrossSign && previousID != "" {
+			err := p.crossSign(context, vaultutils.ToString(secret.Data["key_id"]), previousID, generation)
+			if err != nil {
+				return id, err
+			}
+		}
+		return id, nil
+	}
+
+	secret, err := p.writeIssuerRequest(context, string(p.Spec.Path)+"/intermediate/generate/"+p.Spec.PrivateKeyType, p.getGeneratePayload(generation))
+	if err != nil {
+		return "", err
+	}
+	signPayload := p.GetPayload()
+	signPayload["csr"] = vaultutils.ToString(secret.Data["csr"])
+	signed, err := p.writeIssuerRequest(context, p.GetSignIntermediatePath(), signPayload)
+	if err != nil {
+		return "", err
+	}
+	imported, err := p.writeIssuerRequest(context, p.GetIntermediateSetSignedPath(), map[string]any{"certificate": vaultutils.ToString(signed.Data["certificate"])})
+	if err != nil {
+		return "", err
+	}
+	id, err := getImportedIssuerID(imported)
+	if err != nil {
+		return "", err
+	}
+	return id, p.nameIssuer(context, id, generation)
+}
+
+// crossSign signs the key of the new root with the previous issuer and imports the resulting certificate as an issuer named {issuer}-cross-signed.
+func (p *PKISecretEngineConfig) crossSign(context context.Context, keyID string, signerID string, generation int) error {
+	payload := p.GetPayload()
+	payload["key_ref"] = keyID
+	csr, err := p.writeIssuerRequest(context, string(p.Spec.Path)+"/intermediate/cross-sign", payload)
+	if err != nil {
+		return err
+	}
+	signPayload := p.GetPayload()
+	signPayload["csr"] = vaultutils.ToString(csr.Data["csr"])
+	signPayload["use_csr_values"] = true
+	signed, err := p.writeIssuerRequest(context, p.GetIssuerPath(signerID)+"/sign-intermediate", signPayload)
+	if err != nil {
+		return err
+	}
+	imported, err := p.writeIssuerRequest(context, string(p.Spec.Path)+"/issuers/import/cert", map[string]any{"pem_bundle": vaultutils.ToString(signed.Data["certificate"])})
+	if err != nil {
+		return err
+	}
+	id, err := getImportedIssuerID(imported)
+	if err != nil {
+		return err
+	}
+	_, err = vaultutils.VaultClientFromContext(context).Logical().JSONMergePatch(context, p.GetIssuerPath(id), map[string]any{
+		"issuer_name": p.Spec.Issuers.getIssuerName(generation) + "-cross-signed",
+	})
+	return err
+}
+
+// nameIssuer gives the issuer and its key the names of the given generation.
+func (p *PKISecretEngineConfig) nameIssuer(context context.Context, id string, generation int) error {
+	vaultClient := vaultutils.VaultClientFromContext(context)
+	issuer, err := vaultClient.Logical().JSONMergePatch(context, p.GetIssuerPath(id), map[string]any{
+		"issuer_name": p.Spec.Issuers.getIssuerName(generation),
+	})
+	if err != nil {
+		return err
+	}
+	if issuer == nil || vaultutils.ToString(issuer.Data["key_id"]) == "" {
+		return nil
+	}
+	_, err = vaultClient.Logical().JSONMergePatch(context, string(p.Spec.Path)+"/key/"+vaultutils.ToString(issuer.Data["key_id"]), map[string]any{
+		"key_name": p.Spec.Issuers.getKeyName(generation),
+	})
+	return err
+}
+
+func (p *PKISecretEngineConfig) setDefaultIssuer(context context.Context, ref string) error {
+	vaultClient := vaultutils.VaultClientFromContext(context)
+	_, err := vaultClient.Logical().WriteWithContext(context, p.GetConfigIssuersPath(), map[string]any{"default": ref})
+	return err
+}
+
+// readIssuers returns the issuers of the mount with the ID of the default issuer.
+func (p *PKISecretEngineConfig) readIssuers(context context.Context) ([]PKIIssuerStatus, string, error) {
+	vaultClient := vaultutils.VaultClientFromContext(context)
+	config, _, err := vaultutils.ReadSecret(context, p.GetConfigIssuersPath())
+	if err != nil {
+		return nil, "", err
+	}
+	defaultID := ""
+	if config != nil {
+		defaultID = vaultutils.ToString(config.Data["default"])
+	}
+	list, err := vaultClient.Logical().ListWithContext(context, p.GetIssuersPath())
+	if err != nil {
+		return nil, "", err
+	}
+	issuers := []PKIIssuerStatus{}
+	if list == nil {
+		return issuers, defaultID, nil
+	}
+	keys, _ := list.Data["keys"].([]any)
+	for _, key := range keys {
+		id := vaultutils.ToString(key)
+		issuer, found, err := vaultutils.ReadSecret(context, p.GetIssuerPath(id))
+		if err != nil {
+			return nil, "", err
+		}
+		if !found {
+			continue
+		}
+		status := PKIIssuerStatus{
+			ID:      id,
+			Name:    vaultutils.ToString(issuer.Data["issuer_name"]),
+			KeyID:   vaultutils.ToString(issuer.Data["key_id"]),
+			Default: id == defaultID,
+		}
+		certificate, err := parseCertificatePEM(vaultutils.ToString(issuer.Data["certificate"]))
+		if err == nil {
+			status.NotAfter = &metav1.Time{Time: certificate.NotAfter}
+		}
+		issuers = append(issuers, status)
+	}
+	slices.SortFunc(issuers, func(a, b PKIIssuerStatus) int {
+		if a.Name != b.Name {
+			return cmp.Compare(a.Name, b.Name)
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return issuers, defaultID, nil
+}
+
+func (p *PKISecretEngineConfig) writeIssuerRequest(context context.Context, path string, payload map[string]any) (*vault.Secret, error) {
+	vaultClient := vaultutils.VaultClientFromContext(context)
+	secret, err := vaultClient.Logical().WriteWithContext(context, path, payload)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no data returned by Vault at %s", path)
+	}
+	return secret, nil
+}
+
+// getImportedIssuerID returns the ID of the issuer imported by set-signed or import requests.
+func getImportedIssuerID(secret *vault.Secret) (string, error) {
+	if secret != nil {
+		if imported, ok := secret.Data["imported_issuers"].([]any); ok && len(imported) > 0 {
+			return vaultutils.ToString(imported[0]), nil
+		}
+	}
+	return "", errors.New("no issuer imported by Vault")
+}
+
+// parseCertificatePEM parses the first certificate of a PEM bundle.
+func parseCertificatePEM(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,400 @@
+package v1alpha1
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newTestCertificatePEM returns a self-signed PEM certificate valid between notBefore and notAfter.
+func newTestCertificatePEM(t *testing.T, commonName string, notBefore, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(42),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+type recordedVaultRequest struct {
+	method string
+	path   string
+	body   map[string]any
+}
+
+// recordingVaultHandler records the requests before serving them with the fake handler.
+type recordingVaultHandler struct {
+	*fakeVaultHandler
+	requests []recordedVaultRequest
+}
+
+func (h *recordingVaultHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := recordedVaultRequest{method: r.Method, path: r.URL.Path[len("/v1/"):]}
+	if data, err := io.ReadAll(r.Body); err == nil && len(data) > 0 {
+		_ = json.Unmarshal(data, &request.body) // test handler; a non-JSON body is recorded as empty
+	}
+	h.requests = append(h.requests, request)
+	h.fakeVaultHandler.ServeHTTP(w, r)
+}
+
+func (h *recordingVaultHandler) find(method, path string) *recordedVaultRequest {
+	for i := range h.requests {
+		if h.requests[i].method == method && h.requests[i].path == path {
+			return &h.requests[i]
+		}
+	}
+	return nil
+}
+
+func TestPKIIssuersNames(t *testing.T) {
+	issuers := &PKIIssuers{IssuerName: "root"}
+	if name := issuers.getIssuerName(1); name != "root" {
+		t.Errorf("getIssuerName(1) = %q, expected root", name)
+	}
+	if name := issuers.getIssuerName(3); name != "root-3" {
+		t.Errorf("getIssuerName(3) = %q, expected root-3", name)
+	}
+	if name := issuers.getKeyName(2); name != "root-2" {
+		t.Errorf("getKeyName(2) = %q, expected root-2", name)
+	}
+	issuers.KeyName = "root-key"
+	if name := issuers.getKeyName(2); name != "root-key-2" {
+		t.Errorf("getKeyName(2) = %q, expected root-key-2", name)
+	}
+}
+
+func TestPKISecretEngineConfigGetGeneratePayload(t *testing.T) {
+	config := &PKISecretEngineConfig{
+		Spec: PKISecretEngineConfigSpec{
+			PKIType:   PKIType{Type: "root"},
+			PKICommon: PKICommon{CommonName: "ca"},
+		},
+	}
+	if _, ok := config.GetGeneratePayload()["issuer_name"]; ok {
+		t.Error("did not expect issuer_name without managed issuers")
+	}
+
+	config.Spec.Issuers = &PKIIssuers{IssuerName: "root"}
+	payload := config.getGeneratePayload(2)
+	if payload["issuer_name"] != "root-2" || payload["key_name"] != "root-2" {
+		t.Errorf("unexpected names in %v", payload)
+	}
+
+	config.Spec.Type = "intermediate"
+	payload = config.getGeneratePayload(1)
+	if _, ok := payload["issuer_name"]; ok {
+		t.Error("did not expect issuer_name for an intermediate")
+	}
+	if payload["key_name"] != "root" {
+		t.Errorf("key_name = %v, expected root", payload["key_name"])
+	}
+}
+
+func TestPKISecretEngineConfigNeedsRotation(t *testing.T) {
+	now := time.Now()
+	expiring := []PKIIssuerStatus{{ID: "id-1", NotAfter: &metav1.Time{Time: now.Add(24 * time.Hour)}}}
+	valid := []PKIIssuerStatus{{ID: "id-1", NotAfter: &metav1.Time{Time: now.Add(90 * 24 * time.Hour)}}}
+	renewBefore := &metav1.Duration{Duration: 30 * 24 * time.Hour}
+
+	tests := []struct {
+		name       string
+		issuers    PKIIssuers
+		generation int
+		current    []PKIIssuerStatus
+		expected   bool
+	}{
+		{
+			name:       "no rotation",
+			issuers:    PKIIssuers{IssuerName: "root"},
+			generation: 1,
+			current:    expiring,
+			expected:   false,
+		},
+		{
+			name:       "generation increased",
+			issuers:    PKIIssuers{IssuerName: "root", Rotation: &PKIIssuerRotation{Generation: 2}},
+			generation: 1,
+			current:    valid,
+			expected:   true,
+		},
+		{
+			name:       "generation already generated",
+			issuers:    PKIIssuers{IssuerName: "root", Rotation: &PKIIssuerRotation{Generation: 2}},
+			generation: 3,
+			current:    valid,
+			expected:   false,
+		},
+		{
+			name:       "default issuer expiring",
+			issuers:    PKIIssuers{IssuerName: "root", Rotation: &PKIIssuerRotation{Generation: 1, RenewBefore: renewBefore}},
+			generation: 1,
+			current:    expiring,
+			expected:   true,
+		},
+		{
+			name:       "default issuer valid",
+			issuers:    PKIIssuers{IssuerName: "root", Rotation: &PKIIssuerRotation{Generation: 1, RenewBefore: renewBefore}},
+			generation: 1,
+			current:    valid,
+			expected:   false,
+		},
+		{
+			name:       "explicit default issuer",
+			issuers:    PKIIssuers{IssuerName: "root", DefaultIssuer: "root", Rotation: &PKIIssuerRotation{Generation: 1, RenewBefore: renewBefore}},
+			generation: 1,
+			current:    expiring,
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &PKISecretEngineConfig{
+				Spec:   PKISecretEngineConfigSpec{Issuers: &tt.issuers},
+				Status: PKISecretEngineConfigStatus{IssuerGeneration: tt.generation},
+			}
+			if result := config.needsRotation(tt.current, "id-1", now); result != tt.expected {
+				t.Errorf("needsRotation() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPKISecretEngineConfigGetNextExpiryCheckIssuerRenewal(t *testing.T) {
+	now := time.Now()
+	renewBefore := &metav1.Duration{Duration: 30 * 24 * time.Hour}
+	issuerNotAfter := now.Add(60 * 24 * time.Hour)
+	renewAt := issuerNotAfter.Add(-renewBefore.Duration)
+	caNotAfter := now.Add(365 * 24 * time.Hour)
+	current := []PKIIssuerStatus{
+		{ID: "id-0", NotAfter: &metav1.Time{Time: now.Add(10 * 24 * time.Hour)}},
+		{ID: "id-1", Default: true, NotAfter: &metav1.Time{Time: issuerNotAfter}},
+	}
+
+	tests := []struct {
+		name     string
+		issuers  PKIIssuers
+		current  []PKIIssuerStatus
+		expected time.Time
+	}{
+		{
+			name:     "default issuer renewal",
+			issuers:  PKIIssuers{IssuerName: "root", Rotation: &PKIIssuerRotation{RenewBefore: renewBefore}},
+			current:  current,
+			expected: renewAt,
+		},
+		{
+			name:     "no renewBefore",
+			issuers:  PKIIssuers{IssuerName: "root", Rotation: &PKIIssuerRotation{}},
+			current:  current,
+			expected: caNotAfter.Add(-defaultCAExpiringSoonThreshold),
+		},
+		{
+			name:     "explicit default issuer",
+			issuers:  PKIIssuers{IssuerName: "root", DefaultIssuer: "root", Rotation: &PKIIssuerRotation{RenewBefore: renewBefore}},
+			current:  current,
+			expected: caNotAfter.Add(-defaultCAExpiringSoonThreshold),
+		},
+		{
+			name:     "default issuer already within renewBefore",
+			issuers:  PKIIssuers{IssuerName: "root", Rotation: &PKIIssuerRotation{RenewBefore: renewBefore}},
+			current:  []PKIIssuerStatus{{ID: "id-1", Default: true, NotAfter: &metav1.Time{Time: now.Add(24 * time.Hour)}}},
+			expected: caNotAfter.Add(-defaultCAExpiringSoonThreshold),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &PKISecretEngineConfig{
+				Spec: PKISecretEngineConfigSpec{Issuers: &tt.issuers},
+				Status: PKISecretEngineConfigStatus{
+					CA:      &PKICAStatus{NotAfter: &metav1.Time{Time: caNotAfter}},
+					Issuers: tt.current,
+				},
+			}
+			next := config.GetNextExpiryCheck(now)
+			if next == nil || !next.Equal(tt.expected) {
+				t.Errorf("GetNextExpiryCheck() = %v, expected %v", next, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPKISecretEngineConfigIsValidIssuers(t *testing.T) {
+	tests := []struct {
+		name string
+		spec PKISecretEngineConfigSpec
+		err  string
+	}{
+		{
+			name: "root rotation",
+			spec: PKISecretEngineConfigSpec{
+				PKIType: PKIType{Type: "root", PrivateKeyType: "internal"},
+				Issuers: &PKIIssuers{IssuerName: "root", Rotation: &PKIIssuerRotation{CrossSign: true}},
+			},
+		},
+		{
+			name: "exported private key",
+			spec: PKISecretEngineConfigSpec{
+				PKIType: PKIType{Type: "root", PrivateKeyType: "exported"},
+				Issuers: &PKIIssuers{IssuerName: "root", Rotation: &PKIIssuerRotation{}},
+			},
+			err: "not supported with exported private keys",
+		},
+		{
+			name: "intermediate without internal sign",
+			spec: PKISecretEngineConfigSpec{
+				PKIType: PKIType{Type: "intermediate", PrivateKeyType: "internal"},
+				Issuers: &PKIIssuers{IssuerName: "int", Rotation: &PKIIssuerRotation{}},
+			},
+			err: "requires internalSign",
+		},
+		{
+			name: "intermediate cross-sign",
+			spec: PKISecretEngineConfigSpec{
+				PKIType:         PKIType{Type: "intermediate", PrivateKeyType: "internal"},
+				PKIIntermediate: PKIIntermediate{InternalSign: &corev1.LocalObjectReference{Name: "pki-root"}},
+				Issuers:         &PKIIssuers{IssuerName: "int", Rotation: &PKIIssuerRotation{CrossSign: true}},
+			},
+			err: "only available for roots",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &PKISecretEngineConfig{Spec: tt.spec}
+			valid, err := config.IsValid()
+			if tt.err == "" {
+				if !valid || err != nil {
+					t.Errorf("IsValid() = %v, %v, expected valid", valid, err)
+				}
+				return
+			}
+			if valid || err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("IsValid() = %v, %v, expected error containing %q", valid, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestPKISecretEngineConfigReconcileIssuersRootRotation(t *testing.T) {
+	now := time.Now()
+	handler := &recordingVaultHandler{fakeVaultHandler: newFakeVaultHandler()}
+	handler.setGet("pki/config/issuers", map[string]any{"default": "id-1"})
+	handler.setGet("pki/issuers", map[string]any{"keys": []string{"id-1"}})
+	handler.setGet("pki/issuer/id-1", map[string]any{
+		"issuer_name": "root",
+		"key_id":      "key-1",
+		"certificate": newTestCertificatePEM(t, "root", now.Add(-time.Hour), now.Add(time.Hour)),
+	})
+	handler.setGet("pki/root/rotate/internal", map[string]any{"issuer_id": "id-2", "key_id": "key-2"})
+	handler.setGet("pki/intermediate/cross-sign", map[string]any{"csr": "CSR"})
+	handler.setGet("pki/issuer/id-1/sign-intermediate", map[string]any{"certificate": "CROSS-SIGNED"})
+	handler.setGet("pki/issuers/import/cert", map[string]any{"imported_issuers": []string{"id-3"}})
+	handler.setGet("pki/issuer/id-3", map[string]any{})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	config := &PKISecretEngineConfig{
+		Spec: PKISecretEngineConfigSpec{
+			Path:      "pki",
+			PKIType:   PKIType{Type: "root", PrivateKeyType: "internal"},
+			PKICommon: PKICommon{CommonName: "ca"},
+			Issuers:   &PKIIssuers{IssuerName: "root", Rotation: &PKIIssuerRotation{Generation: 2, CrossSign: true}},
+		},
+		Status: PKISecretEngineConfigStatus{IssuerGeneration: 1},
+	}
+	if err := config.ReconcileIssuers(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if config.Status.IssuerGeneration != 2 {
+		t.Errorf("IssuerGeneration = %d, expected 2", config.Status.IssuerGeneration)
+	}
+	rotate := handler.find(http.MethodPut, "pki/root/rotate/internal")
+	if rotate == nil || rotate.body["issuer_name"] != "root-2" || rotate.body["key_name"] != "root-2" {
+		t.Errorf("unexpected rotation request %v", rotate)
+	}
+	crossSign := handler.find(http.MethodPut, "pki/intermediate/cross-sign")
+	if crossSign == nil || crossSign.body["key_ref"] != "key-2" {
+		t.Errorf("unexpected cross-sign request %v", crossSign)
+	}
+	if handler.find(http.MethodPut, "pki/issuer/id-1/sign-intermediate") == nil {
+		t.Error("expected the previous issuer to sign the new key")
+	}
+	rename := handler.find(http.MethodPatch, "pki/issuer/id-3")
+	if rename == nil || rename.body["issuer_name"] != "root-2-cross-signed" {
+		t.Errorf("unexpected cross-signed issuer naming %v", rename)
+	}
+	setDefault := handler.find(http.MethodPut, "pki/config/issuers")
+	if setDefault == nil || setDefault.body["default"] != "id-2" {
+		t.Errorf("unexpected default issuer request %v", setDefault)
+	}
+	expected := []PKIIssuerStatus{{ID: "id-1", Name: "root", KeyID: "key-1", Default: true}}
+	if len(config.Status.Issuers) != 1 || config.Status.Issuers[0].NotAfter == nil {
+		t.Fatalf("unexpected issuers %v", config.Status.Issuers)
+	}
+	config.Status.Issuers[0].NotAfter = nil
+	if !reflect.DeepEqual(config.Status.Issuers, expected) {
+		t.Errorf("Issuers = %v, expected %v", config.Status.Issuers, expected)
+	}
+}
+
+func TestPKISecretEngineConfigReconcileIssuersAdoptsExistingMount(t *testing.T) {
+	handler := &recordingVaultHandler{fakeVaultHandler: newFakeVaultHandler()}
+	handler.setGet("pki/config/issuers", map[string]any{"default": "id-1"})
+	handler.setGet("pki/issuers", map[string]any{"keys": []string{"id-1"}})
+	handler.setGet("pki/issuer/id-1", map[string]any{"key_id": "key-1"})
+	handler.setGet("pki/key/key-1", map[string]any{})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	config := &PKISecretEngineConfig{
+		Spec: PKISecretEngineConfigSpec{
+			Path:    "pki",
+			PKIType: PKIType{Type: "root", PrivateKeyType: "internal"},
+			Issuers: &PKIIssuers{IssuerName: "root", KeyName: "root-key"},
+		},
+	}
+	if err := config.ReconcileIssuers(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Status.IssuerGeneration != 1 {
+		t.Errorf("IssuerGeneration = %d, expected 1", config.Status.IssuerGeneration)
+	}
+	if rename := handler.find(http.MethodPatch, "pki/issuer/id-1"); rename == nil || rename.body["issuer_name"] != "root" {
+		t.Errorf("unexpected issuer naming %v", rename)
+	}
+	if rename := handler.find(http.MethodPatch, "pki/key/key-1"); rename == nil || rename.body["key_name"] != "root-key" {
+		t.Errorf("unexpected key naming %v", rename)
+	}
+	if handler.find(http.MethodPut, "pki/root/rotate/internal") != nil {
+		t.Error("did not expect a rotation")
+	}
+}
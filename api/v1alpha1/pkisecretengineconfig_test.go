@@ -88,10 +88,10 @@ func TestPKIConfigUrlsToMap(t *testing.T) {
 		OcspServers:           []string{"http://ocsp.example"},
 	}
 	result := u.toMap()
-	if len(result) != 3 {
-		t.Errorf("expected 3 keys, got %d", len(result))
+	if len(result) != 4 {
+		t.Errorf("expected 4 keys, got %d", len(result))
 	}
-	for _, key := range []string{"issuing_certificates", "crl_distribution_points", "ocsp_servers"} {
+	for _, key := range []string{"issuing_certificates", "crl_distribution_points", "ocsp_servers", "enable_templating"} {
 		if _, ok := result[key]; !ok {
 			t.Errorf("expected key %q", key)
 		}
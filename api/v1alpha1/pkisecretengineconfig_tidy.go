@@ -0,0 +1,220 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// TidyAnnotation requests an on-demand tidy of the mount. A tidy is started every time its value changes, for example to the current timestamp.
+const TidyAnnotation = "redhatcop.redhat.io/tidy"
+
+// PKITidyRunningState is the state reported by Vault while a tidy operation is running.
+const PKITidyRunningState = "Running"
+
+// PKITidy configures the tidy operations of the mount, which remove the expired certificates and issuers from the storage of the mount.
+type PKITidy struct {
+	// TidyCertStore removes the expired certificates from the certificate store.
+	// +kubebuilder:validation:Optional
+	TidyCertStore bool `json:"tidyCertStore,omitempty"`
+
+	// TidyRevokedCerts removes the expired certificates from the revoked certificates and the CRL.
+	// +kubebuilder:validation:Optional
+	TidyRevokedCerts bool `json:"tidyRevokedCerts,omitempty"`
+
+	// TidyRevokedCertIssuerAssociations associates the revoked certificates with their issuers.
+	// +kubebuilder:validation:Optional
+	TidyRevokedCertIssuerAssociations bool `json:"tidyRevokedCertIssuerAssociations,omitempty"`
+
+	// TidyExpiredIssuers removes the expired issuers which are not the default issuer.
+	// +kubebuilder:validation:Optional
+	TidyExpiredIssuers bool `json:"tidyExpiredIssuers,omitempty"`
+
+	// SafetyBuffer is how long after their expiry the certificates are removed. Defaults to 72h in Vault.
+	// +kubebuilder:validation:Optional
+	SafetyBuffer *metav1.Duration `json:"safetyBuffer,omitempty"`
+
+	// IssuerSafetyBuffer is how long after their expiry the issuers are removed. Defaults to 8760h (365 days) in Vault.
+	// +kubebuilder:validation:Optional
+	IssuerSafetyBuffer *metav1.Duration `json:"issuerSafetyBuffer,omitempty"`
+
+	// AutoTidy enables the periodic tidy of the mount by Vault, with the above operations.
+	// +kubebuilder:validation:Optional
+	AutoTidy bool `json:"autoTidy,omitempty"`
+
+	// Interval is the interval between two automatic tidy operations. Defaults to 12h in Vault.
+	// +kubebuilder:validation:Optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// PKITidyStatus reports the last tidy operation of the mount, as read from Vault.
+type PKITidyStatus struct {
+	// LastRequest is the value of the tidy annotation which started the last on-demand tidy.
+	// +kubebuilder:validation:Optional
+	LastRequest string `json:"lastRequest,omitempty"`
+
+	// State is the state of the last tidy operation: Inactive, Running, Finished, Error, Cancelling or Cancelled.
+	// +kubebuilder:validation:Optional
+	State string `json:"state,omitempty"`
+
+	// Error is the error of the last tidy operation, if it failed.
+	// +kubebuilder:validation:Optional
+	Error string `json:"error,omitempty"`
+
+	// Message is the progress message of the last tidy operation.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// TimeStarted is the start of the last tidy operation.
+	// +kubebuilder:validation:Optional
+	TimeStarted *metav1.Time `json:"timeStarted,omitempty"`
+
+	// TimeFinished is the end of the last tidy operation.
+	// +kubebuilder:validation:Optional
+	TimeFinished *metav1.Time `json:"timeFinished,omitempty"`
+
+	// CertStoreDeletedCount is the number of certificates removed from the certificate store by the last tidy operation.
+	// +kubebuilder:validation:Optional
+	CertStoreDeletedCount int64 `json:"certStoreDeletedCount,omitempty"`
+
+	// RevokedCertDeletedCount is the number of revoked certificates removed by the last tidy operation.
+	// +kubebuilder:validation:Optional
+	RevokedCertDeletedCount int64 `json:"revokedCertDeletedCount,omitempty"`
+
+	// CurrentCertStoreCount is the number of certificates in the certificate store.
+	// +kubebuilder:validation:Optional
+	CurrentCertStoreCount int64 `json:"currentCertStoreCount,omitempty"`
+
+	// CurrentRevokedCertCount is the number of revoked certificates.
+	// +kubebuilder:validation:Optional
+	CurrentRevokedCertCount int64 `json:"currentRevokedCertCount,omitempty"`
+}
+
+func (p *PKISecretEngineConfig) GetConfigAutoTidyPath() string {
+	return vaultutils.CleansePath(p.GetPath() + "/config/auto-tidy")
+}
+
+func (p *PKISecretEngineConfig) GetTidyPath() string {
+	return vaultutils.CleansePath(p.GetPath() + "/tidy")
+}
+
+func (p *PKISecretEngineConfig) GetTidyStatusPath() string {
+	return vaultutils.CleansePath(p.GetPath() + "/tidy-status")
+}
+
+func (i *PKITidy) toMap() map[string]any {
+	payload := map[string]any{}
+	payload["tidy_cert_store"] = i.TidyCertStore
+	payload["tidy_revoked_certs"] = i.TidyRevokedCerts
+	payload["tidy_revoked_cert_issuer_associations"] = i.TidyRevokedCertIssuerAssociations
+	payload["tidy_expired_issuers"] = i.TidyExpiredIssuers
+	if i.SafetyBuffer != nil {
+		payload["safety_buffer"] = i.SafetyBuffer
+	}
+	if i.IssuerSafetyBuffer != nil {
+		payload["issuer_safety_buffer"] = i.IssuerSafetyBuffer
+	}
+	return payload
+}
+
+func (i *PKITidy) toAutoTidyMap() map[string]any {
+	payload := i.toMap()
+	payload["enabled"] = i.AutoTidy
+	if i.Interval != nil {
+		payload["interval_duration"] = i.Interval
+	}
+	return payload
+}
+
+// IsTidyRunning returns true when the last tidy operation of the mount was running at the last reconcile.
+func (p *PKISecretEngineConfig) IsTidyRunning() bool {
+	return p.Status.Tidy != nil && p.Status.Tidy.State == PKITidyRunningState
+}
+
+// ReconcileTidy writes the auto-tidy configuration of the mount, starts the on-demand tidy requested with the tidy annotation and reports the tidy status.
+func (p *PKISecretEngineConfig) ReconcileTidy(context context.Context) error {
+	if p.Spec.Tidy == nil {
+		p.Status.Tidy = nil
+		return nil
+	}
+	log := log.FromContext(context)
+	vaultClient := vaultutils.VaultClientFromContext(context)
+
+	_, err := vaultClient.Logical().WriteWithContext(context, p.GetConfigAutoTidyPath(), p.Spec.Tidy.toAutoTidyMap())
+	if err != nil {
+		log.Error(err, "unable to write auto-tidy configuration", "path", p.GetConfigAutoTidyPath())
+		return err
+	}
+
+	status := &PKITidyStatus{}
+	if p.Status.Tidy != nil {
+		status.LastRequest = p.Status.Tidy.LastRequest
+	}
+	if request := p.GetAnnotations()[TidyAnnotation]; request != "" && request != status.LastRequest {
+		_, err := vaultClient.Logical().WriteWithContext(context, p.GetTidyPath(), p.Spec.Tidy.toMap())
+		if err != nil {
+			log.Error(err, "unable to start tidy", "path", p.GetTidyPath())
+			return err
+		}
+		// the request is recorded before reading the status back, so that a failure to read it does not start another tidy
+		status.LastRequest = request
+		p.Status.Tidy = status
+	}
+
+	tidyStatus, found, err := vaultutils.ReadSecret(context, p.GetTidyStatusPath())
+	if err != nil {
+		return err
+	}
+	if found && tidyStatus != nil {
+		data := tidyStatus.Data
+		status.State = vaultutils.ToString(data["state"])
+		status.Error = vaultutils.ToString(data["error"])
+		status.Message = vaultutils.ToString(data["message"])
+		status.TimeStarted = toTime(data["time_started"])
+		status.TimeFinished = toTime(data["time_finished"])
+		status.CertStoreDeletedCount = toInt64(data["cert_store_deleted_count"])
+		status.RevokedCertDeletedCount = toInt64(data["revoked_cert_deleted_count"])
+		status.CurrentCertStoreCount = toInt64(data["current_cert_store_count"])
+		status.CurrentRevokedCertCount = toInt64(data["current_revoked_cert_count"])
+	}
+	p.Status.Tidy = status
+	return nil
+}
+
+// toTime parses a RFC 3339 time returned by Vault, returning nil when it is not set.
+func toTime(value any) *metav1.Time {
+	t, err := time.Parse(time.RFC3339Nano, vaultutils.ToString(value))
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
+
+// toInt64 parses a number returned by Vault, returning 0 when it is not set.
+func toInt64(value any) int64 {
+	i, err := strconv.ParseInt(vaultutils.ToString(value), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return i
+}
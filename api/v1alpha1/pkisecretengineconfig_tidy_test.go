@@ -0,0 +1,115 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPKITidyPayloads(t *testing.T) {
+	tidy := &PKITidy{
+		TidyCertStore: true,
+		SafetyBuffer:  &metav1.Duration{Duration: 24 * time.Hour},
+		AutoTidy:      true,
+	}
+	payload := tidy.toMap()
+	if payload["tidy_cert_store"] != true || payload["tidy_revoked_certs"] != false {
+		t.Errorf("unexpected operations in %v", payload)
+	}
+	if _, ok := payload["issuer_safety_buffer"]; ok {
+		t.Error("did not expect issuer_safety_buffer when unset")
+	}
+	if _, ok := payload["enabled"]; ok {
+		t.Error("did not expect enabled in the tidy payload")
+	}
+
+	autoTidy := tidy.toAutoTidyMap()
+	if autoTidy["enabled"] != true || autoTidy["safety_buffer"] == nil {
+		t.Errorf("unexpected auto-tidy payload %v", autoTidy)
+	}
+	if _, ok := autoTidy["interval_duration"]; ok {
+		t.Error("did not expect interval_duration when unset")
+	}
+}
+
+func TestPKISecretEngineConfigReconcileTidy(t *testing.T) {
+	handler := &recordingVaultHandler{fakeVaultHandler: newFakeVaultHandler()}
+	handler.setGet("pki/config/auto-tidy", map[string]any{})
+	handler.setGet("pki/tidy", map[string]any{})
+	handler.setGet("pki/tidy-status", map[string]any{
+		"state":                      "Running",
+		"time_started":               "2026-10-19T10:00:00Z",
+		"time_finished":              nil,
+		"cert_store_deleted_count":   json.Number("1200"),
+		"revoked_cert_deleted_count": json.Number("3"),
+		"current_cert_store_count":   json.Number("50"),
+	})
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	config := &PKISecretEngineConfig{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{TidyAnnotation: "1"}},
+		Spec: PKISecretEngineConfigSpec{
+			Path: "pki",
+			Tidy: &PKITidy{TidyCertStore: true, AutoTidy: true, Interval: &metav1.Duration{Duration: time.Hour}},
+		},
+	}
+	ctx := pivContext(newFakeKubeClient(), vc)
+	if err := config.ReconcileTidy(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	autoTidy := handler.find(http.MethodPut, "pki/config/auto-tidy")
+	if autoTidy == nil || autoTidy.body["enabled"] != true || autoTidy.body["interval_duration"] != "1h0m0s" {
+		t.Errorf("unexpected auto-tidy request %v", autoTidy)
+	}
+	tidy := handler.find(http.MethodPut, "pki/tidy")
+	if tidy == nil || tidy.body["tidy_cert_store"] != true {
+		t.Errorf("unexpected tidy request %v", tidy)
+	}
+
+	status := config.Status.Tidy
+	if status == nil || status.LastRequest != "1" || status.State != "Running" || !config.IsTidyRunning() {
+		t.Fatalf("unexpected tidy status %+v", status)
+	}
+	if status.TimeStarted == nil || !status.TimeStarted.Time.Equal(time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)) || status.TimeFinished != nil {
+		t.Errorf("unexpected tidy times %v - %v", status.TimeStarted, status.TimeFinished)
+	}
+	if status.CertStoreDeletedCount != 1200 || status.RevokedCertDeletedCount != 3 || status.CurrentCertStoreCount != 50 {
+		t.Errorf("unexpected tidy counts %+v", status)
+	}
+
+	// the same request does not start another tidy
+	handler.requests = nil
+	if err := config.ReconcileTidy(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler.find(http.MethodPut, "pki/tidy") != nil {
+		t.Error("did not expect another tidy for the same request")
+	}
+	if config.Status.Tidy.LastRequest != "1" {
+		t.Errorf("LastRequest = %q, expected 1", config.Status.Tidy.LastRequest)
+	}
+
+	// a new request starts another tidy
+	config.Annotations[TidyAnnotation] = "2"
+	if err := config.ReconcileTidy(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler.find(http.MethodPut, "pki/tidy") == nil || config.Status.Tidy.LastRequest != "2" {
+		t.Errorf("expected a tidy for the new request, got %+v", config.Status.Tidy)
+	}
+}
+
+func TestPKISecretEngineConfigReconcileTidyUnset(t *testing.T) {
+	config := &PKISecretEngineConfig{Status: PKISecretEngineConfigStatus{Tidy: &PKITidyStatus{State: "Finished"}}}
+	if err := config.ReconcileTidy(pivContext(newFakeKubeClient(), nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Status.Tidy != nil {
+		t.Errorf("expected no tidy status, got %+v", config.Status.Tidy)
+	}
+}
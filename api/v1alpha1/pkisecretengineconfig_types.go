@@ -58,6 +58,34 @@ type PKISecretEngineConfigSpec struct {
 	PKIConfig `json:",inline"`
 
 	PKIIntermediate `json:",inline"`
+
+	// Issuers manages the named issuers and keys of the mount, the default issuer and the rotation of the issuer. Requires Vault 1.11 or later.
+	// +kubebuilder:validation:Optional
+	Issuers *PKIIssuers `json:"issuers,omitempty"`
+
+	// ExpiringSoonThreshold is how long before the expiry of the CA certificate the CAExpiringSoon condition becomes true. Defaults to 720h (30 days).
+	// +kubebuilder:validation:Optional
+	ExpiringSoonThreshold *metav1.Duration `json:"expiringSoonThreshold,omitempty"`
+
+	// Tidy configures the auto-tidy of the mount and the on-demand tidy started with the redhatcop.redhat.io/tidy annotation.
+	// +kubebuilder:validation:Optional
+	Tidy *PKITidy `json:"tidy,omitempty"`
+
+	// Cluster configures the URLs of the mount on this cluster of Vault, written to config/cluster.
+	// +kubebuilder:validation:Optional
+	Cluster *PKIConfigCluster `json:"cluster,omitempty"`
+
+	// ACME configures the ACME server of the mount, written to config/acme. Requires cluster.path.
+	// +kubebuilder:validation:Optional
+	ACME *PKIACME `json:"acme,omitempty"`
+
+	// ExportedSecret configures the secret the private key is exported to, when privateKeyType is exported. The secret is restored when it is deleted or changed, except for the private key which Vault does not keep.
+	// +kubebuilder:validation:Optional
+	ExportedSecret *PKIExportedSecret `json:"exportedSecret,omitempty"`
+
+	// Import imports an externally generated CA certificate and its private key from a Kubernetes secret into the mount, instead of generating them. The import happens once, like the generation.
+	// +kubebuilder:validation:Optional
+	Import *PKIImport `json:"import,omitempty"`
 }
 
 type PKIType struct {
@@ -196,6 +224,10 @@ type PKIConfigUrls struct {
 	// +listType=set
 	// kubebuilder:validation:UniqueItems=true
 	OcspServers []string `json:"ocspServers,omitempty"`
+
+	// Enables the templating of the above URLs with {{issuer_id}}, {{cluster_path}} and {{cluster_aia_path}}, so that each issuer of the mount gets its own AIA URLs. The cluster URLs are configured with cluster.
+	// +kubebuilder:validation:Optional
+	EnableTemplating bool `json:"enableTemplating,omitempty"`
 }
 
 type PKIConfigCRL struct {
@@ -230,6 +262,12 @@ type PKIIntermediate struct {
 
 var _ vaultutils.VaultObject = &PKISecretEngineConfig{}
 var _ vaultutils.VaultPKIEngineObject = &PKISecretEngineConfig{}
+var _ vaultutils.VaultPKIIssuerObject = &PKISecretEngineConfig{}
+var _ vaultutils.VaultPKITidyObject = &PKISecretEngineConfig{}
+var _ vaultutils.VaultPKIACMEObject = &PKISecretEngineConfig{}
+var _ vaultutils.VaultPKIExportedObject = &PKISecretEngineConfig{}
+var _ vaultutils.VaultPKIImportObject = &PKISecretEngineConfig{}
+var _ vaultutils.VaultStatusEnricher = &PKISecretEngineConfig{}
 
 func (d *PKISecretEngineConfig) GetVaultConnection() *vaultutils.VaultConnection {
 	return d.Spec.Connection
@@ -281,24 +319,9 @@ func (p *PKISecretEngineConfig) CreateExported(context context.Context, secret *
 	exported := p.Spec.PrivateKeyType == "exported"
 
 	if exported {
-		kubeClient := vaultutils.KubeClientFromContext(context)
-		kubeSecret := &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      p.Name,
-				Namespace: p.Namespace,
-				Labels: map[string]string{
-					"redhatcop.redhat.io/pkisecretengineconfigs": p.Name,
-				},
-				OwnerReferences: []metav1.OwnerReference{
-					*metav1.NewControllerRef(p, p.GroupVersionKind()),
-				},
-			},
-			StringData: payload,
-		}
-
-		err := kubeClient.Create(context, kubeSecret)
+		err := p.writeExportedSecret(context, p.getExportedSecretData(payload))
 		if err != nil {
-			log.Error(err, "unable to create exported secret", "path", kubeSecret)
+			log.Error(err, "unable to create exported secret", "name", p.GetExportedSecretName())
 			return false, err
 		}
 	}
@@ -328,7 +351,7 @@ func (p *PKISecretEngineConfig) SetIntermediate(context context.Context) error {
 				secret := &corev1.Secret{}
 
 				err := kubeClient.Get(context, types.NamespacedName{
-					Name:      p.Name,
+					Name:      p.GetExportedSecretName(),
 					Namespace: p.Namespace,
 				}, secret)
 
@@ -453,6 +476,27 @@ func (p *PKISecretEngineConfig) IsValid() (bool, error) {
 }
 
 func (p *PKISecretEngineConfig) isValid() error {
+	if err := p.isValidACME(); err != nil {
+		return err
+	}
+	if err := p.isValidImport(); err != nil {
+		return err
+	}
+	if p.Spec.ExportedSecret != nil && p.Spec.PrivateKeyType != "exported" {
+		return errors.New("exportedSecret requires privateKeyType exported")
+	}
+	if p.Spec.Issuers == nil || p.Spec.Issuers.Rotation == nil {
+		return nil
+	}
+	if p.Spec.PrivateKeyType == "exported" {
+		return errors.New("issuer rotation is not supported with exported private keys")
+	}
+	if p.Spec.Type == "intermediate" && (p.Spec.InternalSign == nil || p.Spec.InternalSign.Name == "") {
+		return errors.New("issuer rotation of an intermediate requires internalSign")
+	}
+	if p.Spec.Type == "intermediate" && p.Spec.Issuers.Rotation.CrossSign {
+		return errors.New("crossSign is only available for roots")
+	}
 	return nil
 }
 func (p *PKISecretEngineConfig) PrepareInternalValues(context context.Context, object client.Object) error {
@@ -485,6 +529,31 @@ type PKISecretEngineConfigStatus struct {
 
 	// +kubebuilder:validation:Optional
 	Signed bool `json:"signed,omitempty"`
+
+	// IssuerGeneration is the generation of the last issuer generated by the operator, when the issuers are managed.
+	// +kubebuilder:validation:Optional
+	IssuerGeneration int `json:"issuerGeneration,omitempty"`
+
+	// Issuers are the issuers of the mount, when the issuers are managed.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	Issuers []PKIIssuerStatus `json:"issuers,omitempty"`
+
+	// CA is the CA certificate of the mount, read back from Vault on every reconcile.
+	// +kubebuilder:validation:Optional
+	CA *PKICAStatus `json:"ca,omitempty"`
+
+	// Tidy is the status of the last tidy operation of the mount, when tidy is configured.
+	// +kubebuilder:validation:Optional
+	Tidy *PKITidyStatus `json:"tidy,omitempty"`
+
+	// ACME is the external account binding token issued into the EAB secret, when it is configured.
+	// +kubebuilder:validation:Optional
+	ACME *PKIACMEStatus `json:"acme,omitempty"`
+
+	// ExportedSecret is the secret the private key was exported to, when privateKeyType is exported.
+	// +kubebuilder:validation:Optional
+	ExportedSecret *PKIExportedSecretStatus `json:"exportedSecret,omitempty"`
 }
 
 var _ vaultutils.ConditionsAware = &PKISecretEngineConfig{}
@@ -557,6 +626,7 @@ func (i *PKIConfigUrls) toMap() map[string]any {
 	payload["issuing_certificates"] = i.IssuingCertificates
 	payload["crl_distribution_points"] = i.CRLDistributionPoints
 	payload["ocsp_servers"] = i.OcspServers
+	payload["enable_templating"] = i.EnableTemplating
 
 	return payload
 }
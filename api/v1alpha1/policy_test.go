@@ -1,12 +1,15 @@
 package v1alpha1
 
 import (
+	"encoding/json"
 	"net/http"
 	"reflect"
 	"strings"
 	"testing"
 
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestPolicyGetPath(t *testing.T) {
@@ -391,3 +394,580 @@ func TestPolicy_PrepareInternalValues_NilSecretReturnsError(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestPolicy_PrepareInternalValues_ParametersAndNamespace(t *testing.T) {
+	policy := &Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"},
+		Spec: PolicySpec{
+			Policy:     `path "${parameter:mount}/data/${kubernetesNamespace}/*" { capabilities = ["read"] }`,
+			Parameters: map[string]string{"mount": "kv-${kubernetesNamespace}"},
+		},
+	}
+	vc, ts := newFakeVaultClient(t, newFakeVaultHandler())
+	defer ts.Close()
+	ctx := pivContext(newFakeKubeClient(), vc)
+
+	if err := policy.PrepareInternalValues(ctx, policy); err != nil {
+		t.Fatalf("PrepareInternalValues: %v", err)
+	}
+	want := `path "kv-team-a/data/team-a/*" { capabilities = ["read"] }`
+	if policy.Spec.Policy != want {
+		t.Fatalf("policy: got %q want %q", policy.Spec.Policy, want)
+	}
+}
+
+func TestPolicy_PrepareInternalValues_UndefinedParameter(t *testing.T) {
+	original := `path "${parameter:mount}/*" { capabilities = ["read"] }`
+	policy := &Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"},
+		Spec:       PolicySpec{Policy: original},
+	}
+	vc, ts := newFakeVaultClient(t, newFakeVaultHandler())
+	defer ts.Close()
+	ctx := pivContext(newFakeKubeClient(), vc)
+
+	err := policy.PrepareInternalValues(ctx, policy)
+	if err == nil || !strings.Contains(err.Error(), "parameter mount") {
+		t.Fatalf("expected undefined parameter error, got: %v", err)
+	}
+	if policy.Spec.Policy != original {
+		t.Fatalf("policy text should be unchanged on error: got %q want %q", policy.Spec.Policy, original)
+	}
+}
+
+func TestPolicy_PrepareInternalValues_References(t *testing.T) {
+	mount := &SecretEngineMount{
+		ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "team-a"},
+		Spec:       SecretEngineMountSpec{Path: "team-a"},
+		Status:     SecretEngineMountStatus{Accessor: "kv_1a2b3c"},
+	}
+	group := &Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "team-a"},
+		Spec:       GroupSpec{Name: "team-a-developers"},
+		Status:     GroupStatus{ID: "4f5e8a3c-0d1b-4d2e-9c3f-7a6b5c4d3e2f"},
+	}
+	policy := &Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"},
+		Spec: PolicySpec{Policy: `path "${secretEngineMount:kv.path}/*" { capabilities = ["read"] }
+# accessor ${secretEngineMount:kv.accessor}
+path "identity/group/id/${group:developers.id}" { capabilities = ["read"] }
+path "identity/group/name/${group:team-a/developers.name}" { capabilities = ["read"] }`},
+	}
+	vc, ts := newFakeVaultClient(t, newFakeVaultHandler())
+	defer ts.Close()
+	ctx := pivContext(newFakeKubeClient(mount, group), vc)
+
+	if err := policy.PrepareInternalValues(ctx, policy); err != nil {
+		t.Fatalf("PrepareInternalValues: %v", err)
+	}
+	want := `path "team-a/kv/*" { capabilities = ["read"] }
+# accessor kv_1a2b3c
+path "identity/group/id/4f5e8a3c-0d1b-4d2e-9c3f-7a6b5c4d3e2f" { capabilities = ["read"] }
+path "identity/group/name/team-a-developers" { capabilities = ["read"] }`
+	if policy.Spec.Policy != want {
+		t.Fatalf("policy: got %q want %q", policy.Spec.Policy, want)
+	}
+}
+
+func TestPolicy_PrepareInternalValues_UnresolvedReference(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []client.Object
+		policy  string
+	}{
+		{
+			name:   "missing group",
+			policy: `path "identity/group/id/${group:developers.id}" { capabilities = ["read"] }`,
+		},
+		{
+			name: "group id not known yet",
+			objects: []client.Object{&Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "team-a"},
+			}},
+			policy: `path "identity/group/id/${group:developers.id}" { capabilities = ["read"] }`,
+		},
+		{
+			name: "unsupported field",
+			objects: []client.Object{&SecretEngineMount{
+				ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "team-a"},
+			}},
+			policy: `path "${secretEngineMount:kv.type}/*" { capabilities = ["read"] }`,
+		},
+		{
+			name: "group in another namespace",
+			objects: []client.Object{&Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "team-b"},
+				Status:     GroupStatus{ID: "4f5e8a3c-0d1b-4d2e-9c3f-7a6b5c4d3e2f"},
+			}},
+			policy: `path "identity/group/id/${group:team-b/developers.id}" { capabilities = ["read"] }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &Policy{
+				ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"},
+				Spec:       PolicySpec{Policy: tt.policy},
+			}
+			vc, ts := newFakeVaultClient(t, newFakeVaultHandler())
+			defer ts.Close()
+			ctx := pivContext(newFakeKubeClient(tt.objects...), vc)
+
+			if err := policy.PrepareInternalValues(ctx, policy); err == nil {
+				t.Fatal("expected an error for an unresolved reference")
+			}
+			if policy.Spec.Policy != tt.policy {
+				t.Fatalf("policy text should be unchanged on error: got %q want %q", policy.Spec.Policy, tt.policy)
+			}
+		})
+	}
+}
+
+func TestPolicyReferences(t *testing.T) {
+	policy := &Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"},
+		Spec: PolicySpec{
+			Policy:     `path "${secretEngineMount:kv.path}/*" { capabilities = ["read"] }` + "\n" + `path "${secretEngineMount:team-a/kv2.path}/*" { capabilities = ["read"] }`,
+			Parameters: map[string]string{"group": "${group:developers.id}", "other": "${group:team-b/developers.id}"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		obj      client.Object
+		expected bool
+	}{
+		{
+			name:     "mount in the policy namespace",
+			obj:      &SecretEngineMount{ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "team-a"}},
+			expected: true,
+		},
+		{
+			name:     "mount referenced with its namespace",
+			obj:      &SecretEngineMount{ObjectMeta: metav1.ObjectMeta{Name: "kv2", Namespace: "team-a"}},
+			expected: true,
+		},
+		{
+			name:     "mount with the same name in another namespace",
+			obj:      &SecretEngineMount{ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "vault-admin"}},
+			expected: false,
+		},
+		{
+			name:     "group referenced in another namespace",
+			obj:      &Group{ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "team-b"}},
+			expected: false,
+		},
+		{
+			name:     "group referenced from a parameter",
+			obj:      &Group{ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "team-a"}},
+			expected: true,
+		},
+		{
+			name:     "unsupported kind",
+			obj:      &Policy{ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "vault-admin"}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.References(tt.obj); got != tt.expected {
+				t.Errorf("References() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPolicyValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           string
+		parameters       map[string]string
+		tests            []PolicyTest
+		wantErr          string
+		expectedWarnings []string
+	}{
+		{
+			name: "valid policy with placeholders",
+			policy: `path "secret/data/${kubernetesNamespace}/*" {
+  capabilities = ["create", "read", "update", "patch", "delete", "list"]
+}
+path "secret/data/{{identity.entity.aliases.${auth/kubernetes/@accessor}.metadata.service_account_namespace}}/*" {
+  capabilities = ["read"]
+  allowed_parameters = {
+    "*" = []
+  }
+}
+path "${parameter:mount}/*" {
+  policy = "read"
+}`,
+			parameters: map[string]string{"mount": "kv"},
+		},
+		{
+			name: "valid policy with HCL1 only constructs",
+			policy: `path "secret/data/app" {
+  capabilities = ["read"]
+  control_group = {
+    factor "approvers" {
+      identity {
+        group_names = ["approvers"]
+        approvals = 1
+      }
+    }
+  }
+}`,
+		},
+		{
+			name:   "valid JSON policy",
+			policy: `{"path": {"secret/*": {"capabilities": ["read"]}}}`,
+		},
+		{
+			name:    "invalid HCL",
+			policy:  `path "secret/*" { capabilities = ["read"]`,
+			wantErr: "unable to parse spec.policy",
+		},
+		{
+			name:    "unknown top level block",
+			policy:  `paths "secret/*" { capabilities = ["read"] }`,
+			wantErr: "invalid spec.policy",
+		},
+		{
+			name:    "unknown capability",
+			policy:  `path "secret/*" { capabilities = ["read", "write"] }`,
+			wantErr: `unknown capability "write" for path "secret/*"`,
+		},
+		{
+			name:    "capabilities is not a list of strings",
+			policy:  `path "secret/*" { capabilities = "read" }`,
+			wantErr: `invalid path "secret/*"`,
+		},
+		{
+			name:    "unknown legacy policy",
+			policy:  `path "secret/*" { policy = "list" }`,
+			wantErr: `unknown policy "list" for path "secret/*"`,
+		},
+		{
+			name:    "malformed auth accessor placeholder",
+			policy:  `path "secret/{{identity.entity.aliases.${auth/kubernetes/accessor}.name}}" { capabilities = ["read"] }`,
+			wantErr: "malformed placeholder ${auth/kubernetes/accessor}",
+		},
+		{
+			name:    "undefined parameter",
+			policy:  `path "${parameter:mount}/*" { capabilities = ["read"] }`,
+			wantErr: "parameter mount used in placeholder ${parameter:mount} is not defined",
+		},
+		{
+			name:    "unsupported reference field",
+			policy:  `path "${secretEngineMount:kv.id}/*" { capabilities = ["read"] }`,
+			wantErr: "unsupported field id in placeholder ${secretEngineMount:kv.id}",
+		},
+		{
+			name:   "references with and without the namespace of the policy",
+			policy: `path "${secretEngineMount:kv.path}/*" { capabilities = ["read"] }` + "\n" + `path "${secretEngineMount:team-a/kv.path}/*" { capabilities = ["read"] }`,
+		},
+		{
+			name:    "reference to another namespace",
+			policy:  `path "${secretEngineMount:vault-admin/kv.path}/*" { capabilities = ["read"] }`,
+			wantErr: "reference vault-admin/kv is not in the namespace of the policy",
+		},
+		{
+			name:    "malformed namespaced reference",
+			policy:  `path "${secretEngineMount:/kv.path}/*" { capabilities = ["read"] }`,
+			wantErr: "malformed reference /kv, expected <name> or <namespace>/<name>",
+		},
+		{
+			name:    "unknown capability in a test",
+			policy:  `path "secret/*" { capabilities = ["read"] }`,
+			tests:   []PolicyTest{{Path: "secret/app", Capabilities: []string{"write"}}},
+			wantErr: `unknown capability "write" in the test of path "secret/app"`,
+		},
+		{
+			name:             "unknown placeholder",
+			policy:           `path "secret/${team}/*" { capabilities = ["read"] }`,
+			expectedWarnings: []string{"placeholder ${team} is not recognized and will be written to Vault as is"},
+		},
+		{
+			name:   "sudo grant",
+			policy: `path "auth/token/accessors" { capabilities = ["list", "sudo"] }`,
+			expectedWarnings: []string{
+				`path "auth/token/accessors" grants sudo, which gives access to root-protected endpoints`,
+			},
+		},
+		{
+			name:   "wildcards covering sys",
+			policy: `path "*" { capabilities = ["read"] }` + "\n" + `path "/sys/*" { capabilities = ["update"] }` + "\n" + `path "+/*" { capabilities = ["read"] }`,
+			expectedWarnings: []string{
+				`path "*" grants [read] on every sys/ endpoint, which is root-equivalent`,
+				`path "/sys/*" grants [update] on every sys/ endpoint, which is root-equivalent`,
+				`path "+/*" grants [read] on every sys/ endpoint, which is root-equivalent`,
+			},
+		},
+		{
+			name:   "wildcards not covering all of sys",
+			policy: `path "sys/policies/*" { capabilities = ["read"] }` + "\n" + `path "secret/*" { capabilities = ["read"] }` + "\n" + `path "*" { capabilities = ["deny"] }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &Policy{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "team-a"},
+				Spec:       PolicySpec{Policy: tt.policy, Parameters: tt.parameters, Tests: tt.tests},
+			}
+			warnings, err := policy.validatePolicy()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(warnings, tt.expectedWarnings) {
+				t.Errorf("warnings = %q, want %q", warnings, tt.expectedWarnings)
+			}
+		})
+	}
+}
+
+// fakeCapabilitiesHandler serves the token endpoints and sys/capabilities for the policy tests.
+type fakeCapabilitiesHandler struct {
+	capabilities  map[string][]string
+	createdTokens []map[string]any
+	revoked       []string
+}
+
+func (h *fakeCapabilitiesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body := map[string]any{}
+	_ = json.NewDecoder(r.Body).Decode(&body) // test handler; an empty body is fine
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/v1/auth/token/create":
+		h.createdTokens = append(h.createdTokens, body)
+		_ = json.NewEncoder(w).Encode(map[string]any{"auth": map[string]any{"client_token": "test-token"}})
+	case "/v1/auth/token/revoke":
+		h.revoked = append(h.revoked, body["token"].(string))
+		w.WriteHeader(http.StatusNoContent)
+	case "/v1/sys/capabilities":
+		if body["token"] != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		path := body["path"].(string)
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"capabilities": h.capabilities[path]}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPolicyReconcilePostWrite_Tests(t *testing.T) {
+	handler := &fakeCapabilitiesHandler{capabilities: map[string][]string{
+		"secret/data/team-a/app": {"read", "list"},
+		"sys/mounts":             {"deny"},
+	}}
+	vc, ts := newFakeVaultClient(t, handler)
+	defer ts.Close()
+
+	policy := &Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "team-a", Generation: 2},
+		Spec: PolicySpec{
+			Tests: []PolicyTest{
+				{Path: "secret/data/team-a/app", Capabilities: []string{"list", "read"}},
+				{Path: "sys/mounts", Capabilities: []string{"read"}},
+			},
+		},
+	}
+	if err := policy.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(handler.createdTokens) != 1 {
+		t.Fatalf("expected one token to be created, got %d", len(handler.createdTokens))
+	}
+	created := handler.createdTokens[0]
+	if !reflect.DeepEqual(created["policies"], []any{"team-a"}) || created["no_default_policy"] != true {
+		t.Errorf("unexpected token request %v", created)
+	}
+	if !reflect.DeepEqual(handler.revoked, []string{"test-token"}) {
+		t.Errorf("expected the test token to be revoked, got %v", handler.revoked)
+	}
+
+	expected := []PolicyTestResult{
+		{Path: "secret/data/team-a/app", ExpectedCapabilities: []string{"list", "read"}, Capabilities: []string{"list", "read"}, Passed: true},
+		{Path: "sys/mounts", ExpectedCapabilities: []string{"read"}, Capabilities: []string{"deny"}, Passed: false},
+	}
+	if !reflect.DeepEqual(policy.Status.TestResults, expected) {
+		t.Errorf("TestResults = %+v, want %+v", policy.Status.TestResults, expected)
+	}
+	condition := apimeta.FindStatusCondition(policy.Status.Conditions, PolicyTestsFailed)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.ObservedGeneration != 2 {
+		t.Fatalf("unexpected condition %+v", condition)
+	}
+	if condition.Message != "sys/mounts: expected [read], got [deny]" {
+		t.Errorf("unexpected condition message %q", condition.Message)
+	}
+
+	// the tests are not run again while the policy and the tests are unchanged
+	policy.Generation = 3
+	if err := policy.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(handler.createdTokens) != 1 {
+		t.Fatalf("expected no token to be created for an unchanged policy, got %d", len(handler.createdTokens))
+	}
+	if !reflect.DeepEqual(policy.Status.TestResults, expected) || !apimeta.IsStatusConditionTrue(policy.Status.Conditions, PolicyTestsFailed) {
+		t.Errorf("expected the last results to be kept, got %+v", policy.Status.TestResults)
+	}
+
+	policy.Spec.Policy = `path "sys/mounts" { capabilities = ["read"] }`
+	if err := policy.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(handler.createdTokens) != 2 {
+		t.Fatalf("expected the tests to run again for a changed policy, got %d token(s)", len(handler.createdTokens))
+	}
+
+	policy.Spec.Tests = policy.Spec.Tests[:1]
+	if err := policy.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(handler.createdTokens) != 3 {
+		t.Fatalf("expected the tests to run again for changed tests, got %d token(s)", len(handler.createdTokens))
+	}
+	if !apimeta.IsStatusConditionFalse(policy.Status.Conditions, PolicyTestsFailed) {
+		t.Errorf("expected PolicyTestsFailed to be false once the tests pass")
+	}
+
+	policy.Spec.Tests = nil
+	if err := policy.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy.Status.TestResults != nil || policy.Status.TestedPolicyHash != "" || apimeta.FindStatusCondition(policy.Status.Conditions, PolicyTestsFailed) != nil {
+		t.Errorf("expected the test results and condition to be removed without tests")
+	}
+}
+
+func TestPolicyReconcilePostWrite_TokenCreationError(t *testing.T) {
+	vc, ts := newFakeVaultClient(t, newFakeVaultHandler())
+	defer ts.Close()
+
+	policy := &Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+		Spec:       PolicySpec{Tests: []PolicyTest{{Path: "secret/data/app", Capabilities: []string{"read"}}}},
+	}
+	if err := policy.ReconcilePostWrite(pivContext(newFakeKubeClient(), vc)); err == nil {
+		t.Fatal("expected an error when the token cannot be created")
+	}
+	condition := apimeta.FindStatusCondition(policy.Status.Conditions, PolicyTestsFailed)
+	if condition == nil || condition.Status != metav1.ConditionUnknown || condition.Reason != PolicyTestsErrorReason {
+		t.Errorf("unexpected condition %+v", condition)
+	}
+}
+
+func TestPolicySentinelPayloadAndEquivalence(t *testing.T) {
+	egp := &Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "business-hours"},
+		Spec: PolicySpec{
+			Type:             "egp",
+			Policy:           "main = rule { true }",
+			EnforcementLevel: "soft-mandatory",
+			Paths:            []string{"secret/*", "transit/keys/*"},
+		},
+	}
+	if path := egp.GetPath(); path != "sys/policies/egp/business-hours" {
+		t.Errorf("GetPath() = %q", path)
+	}
+	expected := map[string]any{
+		"policy":            "main = rule { true }",
+		"enforcement_level": "soft-mandatory",
+		"paths":             []any{"secret/*", "transit/keys/*"},
+	}
+	if payload := egp.GetPayload(); !reflect.DeepEqual(payload, expected) {
+		t.Errorf("GetPayload() = %v, want %v", payload, expected)
+	}
+
+	// Vault returns the name of the policy and decodes the paths as []any
+	read := map[string]any{
+		"name":              "business-hours",
+		"policy":            "main = rule { true }",
+		"enforcement_level": "soft-mandatory",
+		"paths":             []any{"secret/*", "transit/keys/*"},
+	}
+	if !egp.IsEquivalentToDesiredState(read) {
+		t.Error("expected the EGP to be equivalent to the policy read from Vault")
+	}
+	read["enforcement_level"] = "advisory"
+	if egp.IsEquivalentToDesiredState(read) {
+		t.Error("expected a different enforcement level not to be equivalent")
+	}
+
+	rgp := &Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-only"},
+		Spec:       PolicySpec{Type: "rgp", Policy: "main = rule { true }", EnforcementLevel: "hard-mandatory"},
+	}
+	if payload := rgp.GetPayload(); !reflect.DeepEqual(payload, map[string]any{"policy": "main = rule { true }", "enforcement_level": "hard-mandatory"}) {
+		t.Errorf("GetPayload() = %v", payload)
+	}
+	if !rgp.IsEquivalentToDesiredState(map[string]any{"name": "team-only", "policy": "main = rule { true }", "enforcement_level": "hard-mandatory"}) {
+		t.Error("expected the RGP to be equivalent to the policy read from Vault")
+	}
+}
+
+func TestPolicyValidateSentinelFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    PolicySpec
+		wantErr string
+	}{
+		{
+			name: "valid egp, the Sentinel code is not parsed as HCL",
+			spec: PolicySpec{Type: "egp", Policy: "main = rule { true }", EnforcementLevel: "advisory", Paths: []string{"*"}},
+		},
+		{
+			name: "valid rgp",
+			spec: PolicySpec{Type: "rgp", Policy: "main = rule { true }", EnforcementLevel: "hard-mandatory"},
+		},
+		{
+			name:    "egp without enforcement level",
+			spec:    PolicySpec{Type: "egp", Policy: "main = rule { true }", Paths: []string{"*"}},
+			wantErr: "enforcementLevel is required for egp policies",
+		},
+		{
+			name:    "egp without paths",
+			spec:    PolicySpec{Type: "egp", Policy: "main = rule { true }", EnforcementLevel: "advisory"},
+			wantErr: "paths is required for egp policies",
+		},
+		{
+			name:    "rgp with paths",
+			spec:    PolicySpec{Type: "rgp", Policy: "main = rule { true }", EnforcementLevel: "advisory", Paths: []string{"*"}},
+			wantErr: "paths is only supported for egp policies",
+		},
+		{
+			name:    "acl with enforcement level",
+			spec:    PolicySpec{Type: "acl", Policy: `path "secret/*" { capabilities = ["read"] }`, EnforcementLevel: "advisory"},
+			wantErr: "enforcementLevel is only supported for egp and rgp policies",
+		},
+		{
+			name:    "rgp with tests",
+			spec:    PolicySpec{Type: "rgp", Policy: "main = rule { true }", EnforcementLevel: "advisory", Tests: []PolicyTest{{Path: "secret/app", Capabilities: []string{"read"}}}},
+			wantErr: "tests are only supported for acl policies",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &Policy{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: tt.spec}
+			_, err := policy.validatePolicy()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -18,13 +18,23 @@ package v1alpha1
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	vault "github.com/hashicorp/vault/api"
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -34,6 +44,7 @@ import (
 
 var _ vaultutils.VaultObject = &Policy{}
 var _ vaultutils.ConditionsAware = &Policy{}
+var _ vaultutils.VaultPostWriteReconciler = &Policy{}
 
 func (d *Policy) GetVaultConnection() *vaultutils.VaultConnection {
 	return d.Spec.Connection
@@ -52,13 +63,25 @@ func (d *Policy) GetPath() string {
 	return vaultutils.CleansePath("sys/policy/" + d.Name)
 }
 func (d *Policy) GetPayload() map[string]any {
-	return map[string]any{
+	payload := map[string]any{
 		"policy": d.Spec.Policy,
 	}
+	if d.isSentinel() {
+		payload["enforcement_level"] = d.Spec.EnforcementLevel
+	}
+	if d.Spec.Type == "egp" {
+		payload["paths"] = toInterfaceArray(d.Spec.Paths)
+	}
+	return payload
+}
+
+// isSentinel returns whether the policy is a Sentinel endpoint governing or role governing policy, as opposed to an ACL policy.
+func (d *Policy) isSentinel() bool {
+	return d.Spec.Type == "egp" || d.Spec.Type == "rgp"
 }
 func (d *Policy) IsEquivalentToDesiredState(payload map[string]any) bool {
 	desiredState := d.GetPayload()
-	desiredState["name"] = map[bool]string{true: d.Spec.Name, false: d.Name}[d.Spec.Name != ""]
+	desiredState["name"] = d.getVaultName()
 	if d.Spec.Type == "" {
 		desiredState["rules"] = desiredState["policy"]
 		delete(desiredState, "policy")
@@ -81,6 +104,16 @@ func (d *Policy) PrepareInternalValues(context context.Context, object client.Ob
 		return nil
 	}
 
+	err = d.resolvePlaceholders(context)
+	if err != nil {
+		return err
+	}
+
+	// Skip the auth engines lookup if no auth engine accessor placeholder is left
+	if !strings.Contains(d.Spec.Policy, "${auth/") {
+		return nil
+	}
+
 	log := log.FromContext(context)
 
 	// Retrieves the list of auth engines to get their accessors
@@ -107,6 +140,143 @@ func (d *Policy) PrepareInternalValues(context context.Context, object client.Ob
 	return nil
 }
 
+var (
+	policyParameterPlaceholderRegexp = regexp.MustCompile(`\$\{parameter:([^}]+)\}`)
+	policyReferencePlaceholderRegexp = regexp.MustCompile(`\$\{(secretEngineMount|group):([^}]+)\.(\w+)\}`)
+)
+
+const policyKubernetesNamespacePlaceholder = "${kubernetesNamespace}"
+
+// resolvePlaceholders replaces the parameters, the namespace of the policy, and the references to other CRs in the policy.
+// Parameters are replaced first, so that their values can contain the other placeholders.
+func (d *Policy) resolvePlaceholders(context context.Context) error {
+	var errs []error
+	policy := policyParameterPlaceholderRegexp.ReplaceAllStringFunc(d.Spec.Policy, func(placeholder string) string {
+		name := policyParameterPlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+		value, ok := d.Spec.Parameters[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("parameter %s used in placeholder %s is not defined in spec.parameters", name, placeholder))
+			return placeholder
+		}
+		return value
+	})
+	policy = strings.ReplaceAll(policy, policyKubernetesNamespacePlaceholder, d.Namespace)
+	policy = policyReferencePlaceholderRegexp.ReplaceAllStringFunc(policy, func(placeholder string) string {
+		match := policyReferencePlaceholderRegexp.FindStringSubmatch(placeholder)
+		value, err := d.resolveReference(context, match[1], match[2], match[3])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to resolve placeholder %s: %w", placeholder, err))
+			return placeholder
+		}
+		return value
+	})
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	d.Spec.Policy = policy
+	return nil
+}
+
+// resolveReference returns the field of the referenced CR, read from its status or from its effective path.
+func (d *Policy) resolveReference(context context.Context, kind string, reference string, field string) (string, error) {
+	kubeClient := vaultutils.KubeClientFromContext(context)
+	namespacedName, err := d.referenceKey(reference)
+	if err != nil {
+		return "", err
+	}
+	switch kind {
+	case "secretEngineMount":
+		mount := &SecretEngineMount{}
+		err = kubeClient.Get(context, namespacedName, mount)
+		if err != nil {
+			return "", err
+		}
+		switch field {
+		case "path":
+			return strings.TrimPrefix(mount.GetPath(), mount.GetEngineListPath()+"/"), nil
+		case "accessor":
+			if mount.Status.Accessor == "" {
+				return "", fmt.Errorf("the accessor of SecretEngineMount %s is not known yet", namespacedName)
+			}
+			return mount.Status.Accessor, nil
+		}
+	case "group":
+		group := &Group{}
+		err = kubeClient.Get(context, namespacedName, group)
+		if err != nil {
+			return "", err
+		}
+		switch field {
+		case "id":
+			if group.Status.ID == "" {
+				return "", fmt.Errorf("the id of Group %s is not known yet", namespacedName)
+			}
+			return group.Status.ID, nil
+		case "name":
+			if group.Spec.Name != "" {
+				return group.Spec.Name, nil
+			}
+			return group.Name, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported field %s for %s references", field, kind)
+}
+
+// referenceKey returns the key of the referenced CR, given as <name> or <namespace>/<name>. The referenced CR must be in the namespace of the policy.
+func (d *Policy) referenceKey(reference string) (types.NamespacedName, error) {
+	namespace, name, found := strings.Cut(reference, "/")
+	if !found {
+		return types.NamespacedName{Namespace: d.Namespace, Name: reference}, nil
+	}
+	if namespace == "" || name == "" || strings.Contains(name, "/") {
+		return types.NamespacedName{}, fmt.Errorf("malformed reference %s, expected <name> or <namespace>/<name>", reference)
+	}
+	if namespace != d.Namespace {
+		return types.NamespacedName{}, fmt.Errorf("reference %s is not in the namespace of the policy %s", reference, d.Namespace)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// isSupportedReferenceField returns whether resolveReference supports the field for the kind of reference.
+func isSupportedReferenceField(kind string, field string) bool {
+	switch kind {
+	case "secretEngineMount":
+		return field == "path" || field == "accessor"
+	case "group":
+		return field == "id" || field == "name"
+	}
+	return false
+}
+
+// References returns whether the policy has a placeholder referencing the given SecretEngineMount or Group.
+func (d *Policy) References(obj client.Object) bool {
+	var kind string
+	switch obj.(type) {
+	case *SecretEngineMount:
+		kind = "secretEngineMount"
+	case *Group:
+		kind = "group"
+	default:
+		return false
+	}
+	// references can also be made from the parameters
+	texts := []string{d.Spec.Policy}
+	for _, value := range d.Spec.Parameters {
+		texts = append(texts, value)
+	}
+	for _, text := range texts {
+		for _, match := range policyReferencePlaceholderRegexp.FindAllStringSubmatch(text, -1) {
+			if match[1] != kind {
+				continue
+			}
+			if key, err := d.referenceKey(match[2]); err == nil && key == client.ObjectKeyFromObject(obj) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (d *Policy) PrepareTLSConfig(context context.Context, object client.Object) error {
 	return nil
 }
@@ -115,6 +285,326 @@ func (r *Policy) IsValid() (bool, error) {
 	return true, nil
 }
 
+var (
+	policyPlaceholderRegexp             = regexp.MustCompile(`\$\{[^}]*\}`)
+	policyAuthAccessorPlaceholderRegexp = regexp.MustCompile(`^\$\{auth/([\w.-]+/)*[\w.-]+/@accessor\}$`)
+)
+
+// policyCapabilities are the capabilities accepted by Vault in the capabilities list of a path.
+var policyCapabilities = map[string]bool{
+	"create":    true,
+	"read":      true,
+	"update":    true,
+	"patch":     true,
+	"delete":    true,
+	"list":      true,
+	"sudo":      true,
+	"deny":      true,
+	"subscribe": true,
+	"recover":   true,
+}
+
+// policyLegacyPolicies are the values accepted by Vault in the deprecated policy attribute of a path.
+var policyLegacyPolicies = map[string]bool{
+	"deny":  true,
+	"read":  true,
+	"write": true,
+	"sudo":  true,
+}
+
+const (
+	// PolicyTestsFailed is true when at least one of spec.tests did not get the expected capabilities.
+	PolicyTestsFailed = "PolicyTestsFailed"
+
+	PolicyTestsFailedReason = "TestsFailed"
+	PolicyTestsPassedReason = "TestsPassed"
+	PolicyTestsErrorReason  = "TestsNotRun"
+
+	// policyTestTokenTTL bounds the life of the test token in case it cannot be revoked.
+	policyTestTokenTTL = "5m"
+)
+
+// ReconcilePostWrite runs the tests of the policy against Vault, with a token carrying only the policy, and records their results in status.
+// The tests are run again only when the policy written to Vault or the tests change, or when they could not run, otherwise the last results are kept.
+func (d *Policy) ReconcilePostWrite(ctx context.Context) error {
+	if len(d.Spec.Tests) == 0 {
+		d.Status.TestResults = nil
+		d.Status.TestedPolicyHash = ""
+		apimeta.RemoveStatusCondition(&d.Status.Conditions, PolicyTestsFailed)
+		return nil
+	}
+	hash, err := d.computeTestedPolicyHash()
+	if err != nil {
+		return err
+	}
+	condition := apimeta.FindStatusCondition(d.Status.Conditions, PolicyTestsFailed)
+	if hash == d.Status.TestedPolicyHash && condition != nil && condition.Status != metav1.ConditionUnknown {
+		return nil
+	}
+	results, err := d.runTests(ctx)
+	if err != nil {
+		apimeta.SetStatusCondition(&d.Status.Conditions, metav1.Condition{
+			Type:               PolicyTestsFailed,
+			Status:             metav1.ConditionUnknown,
+			ObservedGeneration: d.GetGeneration(),
+			Reason:             PolicyTestsErrorReason,
+			Message:            err.Error(),
+		})
+		return err
+	}
+	d.Status.TestResults = results
+	d.Status.TestedPolicyHash = hash
+	failed := []string{}
+	for _, result := range results {
+		if !result.Passed {
+			failed = append(failed, fmt.Sprintf("%s: expected %v, got %v", result.Path, result.ExpectedCapabilities, result.Capabilities))
+		}
+	}
+	condition = &metav1.Condition{
+		Type:               PolicyTestsFailed,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: d.GetGeneration(),
+		Reason:             PolicyTestsPassedReason,
+		Message:            fmt.Sprintf("%d test(s) passed", len(results)),
+	}
+	if len(failed) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = PolicyTestsFailedReason
+		condition.Message = strings.Join(failed, "; ")
+	}
+	apimeta.SetStatusCondition(&d.Status.Conditions, *condition)
+	return nil
+}
+
+// computeTestedPolicyHash returns the hash of the policy, as rendered for Vault, and of its tests.
+func (d *Policy) computeTestedPolicyHash() (string, error) {
+	tests, err := json.Marshal(d.Spec.Tests)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(d.Spec.Policy + "\n" + string(tests)))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// runTests creates a token carrying only this policy, checks its capabilities on the path of each test, and revokes it.
+func (d *Policy) runTests(ctx context.Context) (results []PolicyTestResult, err error) {
+	vaultClient := vaultutils.VaultClientFromContext(ctx)
+	secret, err := vaultClient.Auth().Token().CreateWithContext(ctx, &vault.TokenCreateRequest{
+		Policies:        []string{d.getVaultName()},
+		NoDefaultPolicy: true,
+		TTL:             policyTestTokenTTL,
+		DisplayName:     "policy-test-" + d.getVaultName(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a token to test the policy: %w", err)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return nil, errors.New("unable to create a token to test the policy: no token returned")
+	}
+	token := secret.Auth.ClientToken
+	defer func() {
+		if revokeErr := vaultClient.Auth().Token().RevokeTreeWithContext(ctx, token); revokeErr != nil {
+			err = errors.Join(err, fmt.Errorf("unable to revoke the token used to test the policy: %w", revokeErr))
+		}
+	}()
+
+	for _, test := range d.Spec.Tests {
+		capabilities, err := vaultClient.Sys().CapabilitiesWithContext(ctx, token, test.Path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the capabilities on %s: %w", test.Path, err)
+		}
+		slices.Sort(capabilities)
+		expected := slices.Clone(test.Capabilities)
+		slices.Sort(expected)
+		results = append(results, PolicyTestResult{
+			Path:                 test.Path,
+			ExpectedCapabilities: test.Capabilities,
+			Capabilities:         capabilities,
+			Passed:               slices.Equal(capabilities, slices.Compact(expected)),
+		})
+	}
+	return results, nil
+}
+
+// getVaultName returns the name of the policy in Vault.
+func (d *Policy) getVaultName() string {
+	if d.Spec.Name != "" {
+		return d.Spec.Name
+	}
+	return d.Name
+}
+
+// validatePolicy parses the policy the way Vault would and returns an error if Vault would reject it.
+// The returned warnings flag grants that are valid but usually a mistake, such as sudo or wildcards covering sys/.
+func (d *Policy) validatePolicy() ([]string, error) {
+	err := d.validateSentinelFields()
+	if err != nil {
+		return nil, err
+	}
+	warnings, err := d.validatePlaceholders()
+	if err != nil {
+		return warnings, err
+	}
+	// Sentinel policies are not HCL, they are checked by Vault when they are written
+	if d.isSentinel() {
+		return warnings, nil
+	}
+	for _, test := range d.Spec.Tests {
+		for _, capability := range test.Capabilities {
+			if !policyCapabilities[capability] {
+				return warnings, fmt.Errorf("unknown capability %q in the test of path %q", capability, test.Path)
+			}
+		}
+	}
+
+	// Vault parses policies with HCL1, which also reads JSON policies
+	file, err := hcl.Parse(d.Spec.Policy)
+	if err != nil {
+		return warnings, fmt.Errorf("unable to parse spec.policy: %w", err)
+	}
+	list, ok := file.Node.(*ast.ObjectList)
+	if !ok {
+		return warnings, errors.New("unable to parse spec.policy: it does not contain a root object")
+	}
+	var errs []error
+	for _, item := range list.Items {
+		key := policyItemKey(item)
+		if key != "name" && key != "path" {
+			errs = append(errs, fmt.Errorf("invalid spec.policy: unexpected key %q on line %d", key, item.Pos().Line))
+		}
+	}
+	for _, item := range list.Filter("path").Items {
+		path := policyItemKey(item)
+		if path == "" {
+			errs = append(errs, fmt.Errorf("invalid spec.policy: path without a name on line %d", item.Pos().Line))
+			continue
+		}
+		// only the grants are checked, the other keys of a path, such as the parameter constraints or the control groups, are left to Vault
+		var pathRules struct {
+			Capabilities []string `hcl:"capabilities"`
+			Policy       string   `hcl:"policy"`
+		}
+		if err := hcl.DecodeObject(&pathRules, item.Val); err != nil {
+			errs = append(errs, fmt.Errorf("invalid path %q: %w", path, err))
+			continue
+		}
+		for _, capability := range pathRules.Capabilities {
+			if !policyCapabilities[capability] {
+				errs = append(errs, fmt.Errorf("unknown capability %q for path %q", capability, path))
+			}
+		}
+		capabilities := pathRules.Capabilities
+		if pathRules.Policy != "" {
+			if !policyLegacyPolicies[pathRules.Policy] {
+				errs = append(errs, fmt.Errorf("unknown policy %q for path %q", pathRules.Policy, path))
+				continue
+			}
+			capabilities = append(capabilities, pathRules.Policy)
+		}
+		warnings = append(warnings, policyPathWarnings(path, capabilities)...)
+	}
+	return warnings, errors.Join(errs...)
+}
+
+// policyItemKey returns the first key of an item of a policy, unquoted.
+func policyItemKey(item *ast.ObjectItem) string {
+	if len(item.Keys) == 0 {
+		return ""
+	}
+	key, _ := item.Keys[0].Token.Value().(string)
+	return key
+}
+
+// validateSentinelFields checks that the enforcement level and the paths are only set for the types of policy that support them.
+func (d *Policy) validateSentinelFields() error {
+	if d.isSentinel() {
+		if d.Spec.EnforcementLevel == "" {
+			return fmt.Errorf("enforcementLevel is required for %s policies", d.Spec.Type)
+		}
+		if len(d.Spec.Tests) > 0 {
+			return errors.New("tests are only supported for acl policies")
+		}
+	} else if d.Spec.EnforcementLevel != "" {
+		return errors.New("enforcementLevel is only supported for egp and rgp policies")
+	}
+	if d.Spec.Type == "egp" && len(d.Spec.Paths) == 0 {
+		return errors.New("paths is required for egp policies")
+	}
+	if d.Spec.Type != "egp" && len(d.Spec.Paths) > 0 {
+		return errors.New("paths is only supported for egp policies")
+	}
+	return nil
+}
+
+// validatePlaceholders checks that the placeholders of the policy are well-formed and that the parameters they use are defined.
+// Unknown placeholders are left as is in the policy written to Vault, so they only produce a warning.
+func (d *Policy) validatePlaceholders() ([]string, error) {
+	var warnings []string
+	var errs []error
+	policy := policyParameterPlaceholderRegexp.ReplaceAllStringFunc(d.Spec.Policy, func(placeholder string) string {
+		name := policyParameterPlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+		value, ok := d.Spec.Parameters[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("parameter %s used in placeholder %s is not defined in spec.parameters", name, placeholder))
+			return ""
+		}
+		return value
+	})
+	for _, placeholder := range policyPlaceholderRegexp.FindAllString(policy, -1) {
+		switch {
+		case placeholder == policyKubernetesNamespacePlaceholder:
+		case strings.HasPrefix(placeholder, "${auth"):
+			if !policyAuthAccessorPlaceholderRegexp.MatchString(placeholder) {
+				errs = append(errs, fmt.Errorf("malformed placeholder %s, expected ${auth/<path>/@accessor}", placeholder))
+			}
+		case strings.HasPrefix(placeholder, "${parameter:"):
+			errs = append(errs, fmt.Errorf("malformed placeholder %s, expected ${parameter:<name>}", placeholder))
+		case strings.HasPrefix(placeholder, "${secretEngineMount:"), strings.HasPrefix(placeholder, "${group:"):
+			match := policyReferencePlaceholderRegexp.FindStringSubmatch(placeholder)
+			if match == nil || match[0] != placeholder {
+				errs = append(errs, fmt.Errorf("malformed placeholder %s, expected ${<kind>:<name>.<field>}", placeholder))
+				continue
+			}
+			if _, err := d.referenceKey(match[2]); err != nil {
+				errs = append(errs, fmt.Errorf("invalid placeholder %s: %w", placeholder, err))
+				continue
+			}
+			if !isSupportedReferenceField(match[1], match[3]) {
+				errs = append(errs, fmt.Errorf("unsupported field %s in placeholder %s", match[3], placeholder))
+			}
+		default:
+			warnings = append(warnings, fmt.Sprintf("placeholder %s is not recognized and will be written to Vault as is", placeholder))
+		}
+	}
+	return warnings, errors.Join(errs...)
+}
+
+// policyPathWarnings returns the warnings for the grants of a path that are root-equivalent.
+func policyPathWarnings(path string, capabilities []string) []string {
+	// deny takes precedence over any other capability
+	if len(capabilities) == 0 || slices.Contains(capabilities, "deny") {
+		return nil
+	}
+	var warnings []string
+	if slices.Contains(capabilities, "sudo") {
+		warnings = append(warnings, fmt.Sprintf("path %q grants sudo, which gives access to root-protected endpoints", path))
+	}
+	if isSysWildcard(path) {
+		warnings = append(warnings, fmt.Sprintf("path %q grants %v on every sys/ endpoint, which is root-equivalent", path, capabilities))
+	}
+	return warnings
+}
+
+// isSysWildcard returns whether a policy path ends with a wildcard that covers all of sys/.
+func isSysWildcard(path string) bool {
+	path = strings.TrimPrefix(path, "/")
+	if !strings.HasSuffix(path, "*") {
+		return false
+	}
+	prefix := strings.TrimSuffix(path, "*")
+	return prefix == "sys/" || prefix == "+/" || strings.HasPrefix("sys", prefix)
+}
+
 // PolicySpec defines the desired state of Policy
 type PolicySpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -124,15 +614,25 @@ type PolicySpec struct {
 	// +kubebuilder:validation:Optional
 	Connection *vaultutils.VaultConnection `json:"connection,omitempty"`
 
-	// Policy is a Vault policy expressed in HCL language.
+	// Policy is a Vault policy expressed in HCL language, or the Sentinel code of egp and rgp policies.
 	// +kubebuilder:validation:Required
 	Policy string `json:"policy,omitempty"`
 
-	// Type represents the policy type, currently the only supported policy type is "acl", but in the future rgp and egp  might be supported. If not specified a policy will be created at /sys/policies/<name>, if specified (the recommended approach) a policy will be created at /sys/policies/acl/<name>
+	// Type represents the policy type, one of "acl", or "egp" and "rgp" for Vault Enterprise Sentinel endpoint governing and role governing policies. If not specified an ACL policy will be created at /sys/policy/<name>, if specified (the recommended approach) a policy will be created at /sys/policies/<type>/<name>
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum={"acl"}
+	// +kubebuilder:validation:Enum={"acl","egp","rgp"}
 	Type string `json:"type,omitempty"`
 
+	// EnforcementLevel is the enforcement level of a Sentinel policy, required when type is egp or rgp.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum={"advisory","soft-mandatory","hard-mandatory"}
+	EnforcementLevel string `json:"enforcementLevel,omitempty"`
+
+	// Paths are the request paths, with optional glob patterns, the endpoint governing policy applies to. Required when type is egp and not allowed for the other types.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Paths []string `json:"paths,omitempty"`
+
 	// Authentication is the kube auth configuration to be used to execute this request
 	// +kubebuilder:validation:Required
 	Authentication vaultutils.KubeAuthConfiguration `json:"authentication,omitempty"`
@@ -141,6 +641,50 @@ type PolicySpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Pattern:=`[a-z0-9]([-a-z0-9]*[a-z0-9])?`
 	Name string `json:"name,omitempty"`
+
+	// Parameters are values substituted in the policy with the ${parameter:<name>} placeholder, so that near-identical policies can share the same text.
+	// Besides parameters and ${auth/<path>/@accessor}, the policy supports the following placeholders:
+	// ${kubernetesNamespace} the namespace of this Policy,
+	// ${secretEngineMount:<ref>.path} and ${secretEngineMount:<ref>.accessor} the mount path and the accessor of a SecretEngineMount,
+	// ${group:<ref>.id} and ${group:<ref>.name} the id and the name of a Group.
+	// <ref> is <name> or <namespace>/<name>, the referenced SecretEngineMounts and Groups must be in the namespace of this Policy.
+	// +kubebuilder:validation:Optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Tests are checks run against Vault after an acl policy is written. For each test, the capabilities granted by the policy on the path are compared to the expected ones.
+	// The policy is tested with a short-lived token carrying only this policy, so the Vault identity of the operator must be able to create tokens with any policy (sudo on auth/token/create) and to call sys/capabilities.
+	// The results are recorded in status.testResults and a mismatch sets the PolicyTestsFailed condition.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	Tests []PolicyTest `json:"tests,omitempty"`
+}
+
+type PolicyTest struct {
+	// Path is the Vault path whose capabilities are checked.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// Capabilities are the capabilities the policy is expected to grant on the path, for example ["read", "list"], or ["deny"] if the path must not be accessible. The order does not matter.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Capabilities []string `json:"capabilities"`
+}
+
+type PolicyTestResult struct {
+	// Path is the Vault path whose capabilities were checked.
+	Path string `json:"path"`
+
+	// ExpectedCapabilities are the capabilities the policy is expected to grant on the path.
+	ExpectedCapabilities []string `json:"expectedCapabilities"`
+
+	// Capabilities are the capabilities the policy actually grants on the path, as returned by sys/capabilities.
+	// +kubebuilder:validation:Optional
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// Passed is true if the actual capabilities match the expected ones.
+	Passed bool `json:"passed"`
 }
 
 // PolicyStatus defines the observed state of Policy
@@ -151,6 +695,15 @@ type PolicyStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// TestResults are the results of spec.tests for the last version of the policy written to Vault.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	TestResults []PolicyTestResult `json:"testResults,omitempty"`
+
+	// TestedPolicyHash is the hash of the policy written to Vault and of spec.tests when the tests were last run. The tests are not run again while it does not change.
+	// +kubebuilder:validation:Optional
+	TestedPolicyHash string `json:"testedPolicyHash,omitempty"`
 }
 
 func (m *Policy) GetConditions() []metav1.Condition {
@@ -54,17 +54,13 @@ var _ admission.Validator[*Policy] = &Policy{}
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
 func (r *Policy) ValidateCreate(ctx context.Context, obj *Policy) (admission.Warnings, error) {
 	policylog.Info("validate create", "name", obj.Name)
-
-	// TODO(user): fill in your validation logic upon object creation.
-	return nil, nil
+	return obj.validatePolicy()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
 func (r *Policy) ValidateUpdate(ctx context.Context, oldObj, newObj *Policy) (admission.Warnings, error) {
 	policylog.Info("validate update", "name", newObj.Name)
-
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	return newObj.validatePolicy()
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
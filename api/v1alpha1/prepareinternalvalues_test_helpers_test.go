@@ -59,7 +59,7 @@ func (h *fakeVaultHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		resp := map[string]any{"data": data}
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(resp) // test handler; encode error is not actionable
-	case http.MethodPut, http.MethodPost:
+	case http.MethodPut, http.MethodPost, http.MethodPatch:
 		data, ok := h.routes[path]
 		if !ok {
 			w.WriteHeader(http.StatusNotFound)
@@ -190,6 +190,33 @@ func (kc *KubeAuthConfiguration) GetServiceAccountName() string {
 	return "default"
 }
 
+// VaultNamespaceAnnotation is the annotation, or label, of a Kubernetes namespace that sets the Vault namespace of the CRs it contains.
+// It is only used by the CRs that do not set authentication.namespace. Label values cannot contain "/", so nested Vault namespaces require the annotation.
+const VaultNamespaceAnnotation = "redhatcop.redhat.io/vault-namespace"
+
+// WithInheritedNamespace returns the configuration to use for the CRs of the given Kubernetes namespace.
+// If the Vault namespace is not set, it is inherited from the VaultNamespaceAnnotation annotation or label of the Kubernetes namespace. The receiver is never modified.
+func (kc *KubeAuthConfiguration) WithInheritedNamespace(context context.Context, kubeNamespace string) (*KubeAuthConfiguration, error) {
+	if kc.Namespace != "" {
+		return kc, nil
+	}
+	namespace := &corev1.Namespace{}
+	err := KubeClientFromContext(context).Get(context, client.ObjectKey{Name: kubeNamespace}, namespace)
+	if err != nil {
+		return nil, err
+	}
+	vaultNamespace, ok := namespace.Annotations[VaultNamespaceAnnotation]
+	if !ok {
+		vaultNamespace = namespace.Labels[VaultNamespaceAnnotation]
+	}
+	if vaultNamespace == "" {
+		return kc, nil
+	}
+	inherited := kc.DeepCopy()
+	inherited.Namespace = vaultNamespace
+	return inherited, nil
+}
+
 func (kc *KubeAuthConfiguration) getCacheKey(kubeNamespace string) string {
 	return fmt.Sprintf("%s:%s:%s:%s:%s", kubeNamespace, kc.ServiceAccount.Name, kc.Path, kc.Role, kc.Namespace)
 }
@@ -1,7 +1,12 @@
 package utils
 
 import (
+	"context"
 	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestToString(t *testing.T) {
@@ -26,3 +31,44 @@ func TestToString(t *testing.T) {
 		})
 	}
 }
+
+func TestKubeAuthConfigurationWithInheritedNamespace(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "annotated", Annotations: map[string]string{VaultNamespaceAnnotation: "tenants/team-a"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "labeled", Labels: map[string]string{VaultNamespaceAnnotation: "team-b"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "plain"}},
+	).Build()
+	ctx := ContextWithKubeClient(context.Background(), kubeClient)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		kubeNamespace string
+		expected      string
+	}{
+		{name: "explicit namespace wins", namespace: "admin", kubeNamespace: "annotated", expected: "admin"},
+		{name: "inherited from the annotation", kubeNamespace: "annotated", expected: "tenants/team-a"},
+		{name: "inherited from the label", kubeNamespace: "labeled", expected: "team-b"},
+		{name: "nothing to inherit", kubeNamespace: "plain", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc := &KubeAuthConfiguration{Path: "kubernetes", Role: "admin", Namespace: tt.namespace}
+			result, err := kc.WithInheritedNamespace(ctx, tt.kubeNamespace)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Namespace != tt.expected {
+				t.Errorf("Namespace = %q, want %q", result.Namespace, tt.expected)
+			}
+			if kc.Namespace != tt.namespace {
+				t.Errorf("the receiver must not be modified, got %q", kc.Namespace)
+			}
+		})
+	}
+
+	kc := &KubeAuthConfiguration{Path: "kubernetes", Role: "admin"}
+	if _, err := kc.WithInheritedNamespace(ctx, "missing"); err == nil {
+		t.Error("expected an error for a missing Kubernetes namespace")
+	}
+}
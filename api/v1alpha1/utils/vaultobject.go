@@ -18,6 +18,7 @@ package utils
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	vault "github.com/hashicorp/vault/api"
@@ -46,6 +47,35 @@ type VaultStatusEnricher interface {
 	EnrichStatus(ctx context.Context) error
 }
 
+// VaultPostWriteReconciler is an optional interface that VaultObjects can implement
+// to perform the writes, in Vault or in Kubernetes, that follow a successful create
+// or update operation. Unlike EnrichStatus, which must only read, an error fails the
+// reconciliation so that it is retried with backoff.
+type VaultPostWriteReconciler interface {
+	ReconcilePostWrite(ctx context.Context) error
+}
+
+// VaultAssignedIDObject is an optional interface that VaultObjects can implement
+// when Vault assigns their identifier, which is part of their path, at their creation.
+// Until the identifier is known, CreateOrUpdate creates the object instead of reading it.
+type VaultAssignedIDObject interface {
+	// GetVaultAssignedID returns the identifier assigned by Vault, empty until the object is created.
+	GetVaultAssignedID() string
+	// GetCreationPath returns the path the object is created at.
+	GetCreationPath() string
+	// GetCreationPayload returns the payload the object is created with.
+	GetCreationPayload() map[string]any
+	// SetVaultAssignedID records the identifier found in the data of the response to the creation.
+	SetVaultAssignedID(data map[string]any) error
+}
+
+// VaultDependentsDeleter is an optional interface that VaultObjects can implement
+// to delete the Vault objects they created besides the one at their path, when
+// they are deleted.
+type VaultDependentsDeleter interface {
+	DeleteDependents(ctx context.Context) error
+}
+
 type VaultEndpoint struct {
 	vaultObject VaultObject
 }
@@ -80,6 +110,10 @@ func (ve *VaultEndpoint) DeleteKVv2IfExists(context context.Context) error {
 
 func (ve *VaultEndpoint) DeleteIfExists(context context.Context) error {
 	log := log.FromContext(context)
+	if assigned, ok := ve.vaultObject.(VaultAssignedIDObject); ok && assigned.GetVaultAssignedID() == "" {
+		// the object was never created
+		return nil
+	}
 	vaultClient := VaultClientFromContext(context)
 	_, err := vaultClient.Logical().Delete(ve.vaultObject.GetPath())
 	if err != nil {
@@ -165,6 +199,9 @@ func (ve *VaultEndpoint) CreateOrMergeKV(context context.Context, isKVv2 bool, p
 
 func (ve *VaultEndpoint) CreateOrUpdate(context context.Context) error {
 	log := log.FromContext(context)
+	if assigned, ok := ve.vaultObject.(VaultAssignedIDObject); ok && assigned.GetVaultAssignedID() == "" {
+		return createWithAssignedID(context, assigned)
+	}
 	currentPayload, found, err := read(context, ve.vaultObject.GetPath())
 	if err != nil {
 		log.Error(err, "unable to read object at", "path", ve.vaultObject.GetPath())
@@ -180,6 +217,18 @@ func (ve *VaultEndpoint) CreateOrUpdate(context context.Context) error {
 	return nil
 }
 
+// createWithAssignedID creates the object and records the identifier Vault assigned to it.
+func createWithAssignedID(context context.Context, assigned VaultAssignedIDObject) error {
+	secret, err := writeWithResponse(context, assigned.GetCreationPath(), assigned.GetCreationPayload())
+	if err != nil {
+		return err
+	}
+	if secret == nil {
+		return fmt.Errorf("no data returned by Vault at the creation of the object at %s", assigned.GetCreationPath())
+	}
+	return assigned.SetVaultAssignedID(secret.Data)
+}
+
 type RabbitMQEngineConfigVaultObject interface {
 	VaultObject
 	GetLeasePath() string
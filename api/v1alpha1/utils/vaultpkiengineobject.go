@@ -26,13 +26,16 @@ import (
 
 type VaultPKIEngineObject interface {
 	GetGeneratePath() string
+	GetGeneratePayload() map[string]any
 	GetDeletePath() string
 	GetGeneratedStatus() bool
 	SetGeneratedStatus(status bool)
 	GetConfigUrlsPath() string
 	GetConfigCrlPath() string
+	GetConfigClusterPath() string
 	GetConfigUrlsPayload() map[string]any
 	GetConfigCrlPayload() map[string]any
+	GetConfigClusterPayload() map[string]any
 	CreateExported(context context.Context, secret *vault.Secret) (bool, error)
 	SetExportedStatus(status bool)
 	SetIntermediate(context context.Context) error
@@ -40,6 +43,32 @@ type VaultPKIEngineObject interface {
 	SetSignedStatus(status bool)
 }
 
+// VaultPKIIssuerObject is implemented by the PKI engine objects managing the issuers of their mount.
+type VaultPKIIssuerObject interface {
+	ReconcileIssuers(context context.Context) error
+}
+
+// VaultPKITidyObject is implemented by the PKI engine objects managing the tidy operations of their mount.
+type VaultPKITidyObject interface {
+	ReconcileTidy(context context.Context) error
+}
+
+// VaultPKIACMEObject is implemented by the PKI engine objects managing the ACME server of their mount.
+type VaultPKIACMEObject interface {
+	ReconcileACME(context context.Context) error
+}
+
+// VaultPKIExportedObject is implemented by the PKI engine objects restoring the secret their private key was exported to.
+type VaultPKIExportedObject interface {
+	ReconcileExported(context context.Context) error
+}
+
+// VaultPKIImportObject is implemented by the PKI engine objects which can import their CA instead of generating it.
+type VaultPKIImportObject interface {
+	IsImport() bool
+	ImportCA(context context.Context) error
+}
+
 type VaultPKIEngineEndpoint struct {
 	*VaultEndpoint
 	vaultPKIEngineObject VaultPKIEngineObject
@@ -57,7 +86,7 @@ func (ve *VaultPKIEngineEndpoint) Exists(context context.Context) (bool, error)
 }
 
 func (ve *VaultPKIEngineEndpoint) Generate(context context.Context) (*vault.Secret, error) {
-	return writeWithResponse(context, ve.vaultPKIEngineObject.GetGeneratePath(), ve.vaultObject.GetPayload())
+	return writeWithResponse(context, ve.vaultPKIEngineObject.GetGeneratePath(), ve.vaultPKIEngineObject.GetGeneratePayload())
 }
 
 func (ve *VaultPKIEngineEndpoint) DeleteIfExists(context context.Context) error {
@@ -92,6 +121,15 @@ func (ve *VaultPKIEngineEndpoint) CreateOrUpdateConfigCrl(context context.Contex
 // 	return ve.readConfig(context, ve.vaultPKIEngineObject.GetConfigCrlPath())
 // }
 
+// CreateOrUpdateConfigCluster writes the cluster configuration of the mount, when it is managed.
+func (ve *VaultPKIEngineEndpoint) CreateOrUpdateConfigCluster(context context.Context) error {
+	payload := ve.vaultPKIEngineObject.GetConfigClusterPayload()
+	if payload == nil {
+		return nil
+	}
+	return ve.CreateOrUpdateConfig(context, ve.vaultPKIEngineObject.GetConfigClusterPath(), payload)
+}
+
 func (ve *VaultPKIEngineEndpoint) readConfig(context context.Context, configPath string) (map[string]any, error) {
 	log := log.FromContext(context)
 	config, _, err := read(context, configPath)
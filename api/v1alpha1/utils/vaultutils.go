@@ -104,3 +104,33 @@ func ReadSecretWithPayload(context context.Context, path string, payload map[str
 	}
 	return secret, true, nil
 }
+
+// RenewLease renews the lease identified by leaseID, requesting the given increment in seconds.
+// The returned secret carries the lease duration actually granted by Vault, which can be shorter than the requested increment when the lease max TTL is reached.
+func RenewLease(context context.Context, leaseID string, increment int) (*vault.Secret, error) {
+	log := log.FromContext(context)
+	vaultClient := VaultClientFromContext(context)
+	secret, err := vaultClient.Sys().RenewWithContext(context, leaseID, increment)
+	if err != nil {
+		log.Error(err, "unable to renew lease", "leaseID", leaseID)
+		return nil, err
+	}
+	return secret, nil
+}
+
+// RevokeLease revokes the lease identified by leaseID. It doesn't fail if the lease is already expired or revoked.
+func RevokeLease(context context.Context, leaseID string) error {
+	log := log.FromContext(context)
+	vaultClient := VaultClientFromContext(context)
+	err := vaultClient.Sys().RevokeWithContext(context, leaseID)
+	if err != nil {
+		if respErr, ok := err.(*vault.ResponseError); ok {
+			if respErr.StatusCode == 400 || respErr.StatusCode == 404 {
+				return nil
+			}
+		}
+		log.Error(err, "unable to revoke lease", "leaseID", leaseID)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultResourceTemplateSpec defines the desired state of VaultResourceTemplate
+type VaultResourceTemplateSpec struct {
+	// Parameters are the parameters of the template, set by the VaultTenants rendering it.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Parameters []VaultResourceTemplateParameter `json:"parameters,omitempty"`
+
+	// Template is a Go template rendering a multi-document YAML stream of redhatcop.redhat.io/v1alpha1 resources, which are created in the namespace of the VaultTenant.
+	// The template receives .tenant.name and .tenant.namespace, the name and the namespace of the VaultTenant, and .parameters, the parameters of the VaultTenant with their defaults.
+	// The same functions as in the templates of VaultSecret are available, except lookup.
+	// The resources are created in the order of the template, which should list dependencies first, and they are deleted in reverse order.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Template string `json:"template"`
+}
+
+type VaultResourceTemplateParameter struct {
+	// Name is the name of the parameter, available in the template as .parameters.<name>.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Name string `json:"name"`
+
+	// Description describes the parameter to the authors of VaultTenants.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// Default is the value of the parameter when a VaultTenant does not set it.
+	// +kubebuilder:validation:Optional
+	Default string `json:"default,omitempty"`
+
+	// Required parameters must be set by the VaultTenants.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	Required bool `json:"required,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VaultResourceTemplate is the Schema for the vaultresourcetemplates API.
+// A VaultResourceTemplate describes a parameterized set of resources, such as the mount, the roles and the policies of a team, which VaultTenants stamp out.
+type VaultResourceTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VaultResourceTemplateSpec `json:"spec,omitempty"`
+}
+
+// ResolveParameters returns the parameters of the template with the given values, or their default.
+// It fails if a required parameter is missing, or if a value is given for a parameter the template does not declare.
+func (t *VaultResourceTemplate) ResolveParameters(values map[string]string) (map[string]string, error) {
+	var errs []error
+	parameters := map[string]string{}
+	for _, parameter := range t.Spec.Parameters {
+		value, ok := values[parameter.Name]
+		switch {
+		case ok:
+			parameters[parameter.Name] = value
+		case parameter.Required:
+			errs = append(errs, fmt.Errorf("required parameter %s is not set", parameter.Name))
+		default:
+			parameters[parameter.Name] = parameter.Default
+		}
+	}
+	for name := range values {
+		if !slices.ContainsFunc(t.Spec.Parameters, func(p VaultResourceTemplateParameter) bool { return p.Name == name }) {
+			errs = append(errs, fmt.Errorf("parameter %s is not declared by VaultResourceTemplate %s", name, t.Name))
+		}
+	}
+	return parameters, errors.Join(errs...)
+}
+
+//+kubebuilder:object:root=true
+
+// VaultResourceTemplateList contains a list of VaultResourceTemplate
+type VaultResourceTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultResourceTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultResourceTemplate{}, &VaultResourceTemplateList{})
+}
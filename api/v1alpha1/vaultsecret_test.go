@@ -0,0 +1,338 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVaultSecretHasRenewableLeases(t *testing.T) {
+	definitions := []VaultSecretDefinition{{Name: "db"}, {Name: "other"}}
+
+	tests := []struct {
+		name     string
+		spec     []VaultSecretDefinition
+		status   []VaultSecretDefinitionStatus
+		expected bool
+	}{
+		{
+			name:     "never synced",
+			spec:     definitions,
+			expected: false,
+		},
+		{
+			name: "all leases renewable",
+			spec: definitions,
+			status: []VaultSecretDefinitionStatus{
+				{Name: "db", LeaseID: "database/creds/read-only/abc", LeaseDuration: 3600, Renewable: true},
+				{Name: "other", LeaseID: "database/creds/read-only/def", LeaseDuration: 3600, Renewable: true},
+			},
+			expected: true,
+		},
+		{
+			name: "one lease capped by max ttl",
+			spec: definitions,
+			status: []VaultSecretDefinitionStatus{
+				{Name: "db", LeaseID: "database/creds/read-only/abc", LeaseDuration: 3600, Renewable: true},
+				{Name: "other", LeaseID: "database/creds/read-only/def", LeaseDuration: 1200, Renewable: false},
+			},
+			expected: false,
+		},
+		{
+			name: "static secret without lease",
+			spec: definitions,
+			status: []VaultSecretDefinitionStatus{
+				{Name: "db", LeaseID: "database/creds/read-only/abc", LeaseDuration: 3600, Renewable: true},
+				{Name: "other", LeaseDuration: 2764800},
+			},
+			expected: false,
+		},
+		{
+			name: "definition added since last sync",
+			spec: append(definitions, VaultSecretDefinition{Name: "new"}),
+			status: []VaultSecretDefinitionStatus{
+				{Name: "db", LeaseID: "database/creds/read-only/abc", LeaseDuration: 3600, Renewable: true},
+				{Name: "other", LeaseID: "database/creds/read-only/def", LeaseDuration: 3600, Renewable: true},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := &VaultSecret{
+				Spec:   VaultSecretSpec{VaultSecretDefinitions: tt.spec},
+				Status: VaultSecretStatus{VaultSecretDefinitionsStatus: tt.status},
+			}
+			if result := vs.HasRenewableLeases(); result != tt.expected {
+				t.Errorf("HasRenewableLeases() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVaultSecretValidateRolloutTargets(t *testing.T) {
+	tests := []struct {
+		name        string
+		vsName      string
+		targets     []RolloutTarget
+		expectError bool
+	}{
+		{
+			name:   "no rollout targets",
+			vsName: "db-credentials",
+		},
+		{
+			name:    "target by name",
+			vsName:  "db-credentials",
+			targets: []RolloutTarget{{Kind: "Deployment", Name: "app"}},
+		},
+		{
+			name:    "target by selector",
+			vsName:  "db-credentials",
+			targets: []RolloutTarget{{Kind: "StatefulSet", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db-client"}}}},
+		},
+		{
+			name:        "both name and selector",
+			vsName:      "db-credentials",
+			targets:     []RolloutTarget{{Kind: "Deployment", Name: "app", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db-client"}}}},
+			expectError: true,
+		},
+		{
+			name:        "neither name nor selector",
+			vsName:      "db-credentials",
+			targets:     []RolloutTarget{{Kind: "DaemonSet"}},
+			expectError: true,
+		},
+		{
+			name:        "invalid selector",
+			vsName:      "db-credentials",
+			targets:     []RolloutTarget{{Kind: "Deployment", Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Bogus"}}}}},
+			expectError: true,
+		},
+		{
+			name:        "name too long for the rollout annotation",
+			vsName:      strings.Repeat("a", 64),
+			targets:     []RolloutTarget{{Kind: "Deployment", Name: "app"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := &VaultSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.vsName},
+				Spec: VaultSecretSpec{
+					TemplatizedK8sSecret: TemplatizedK8sSecret{Name: "db", Type: "Opaque", StringData: map[string]string{"password": "{{ .db.password }}"}},
+					RolloutTargets:       tt.targets,
+				},
+			}
+			_, err := vs.IsValid()
+			if (err != nil) != tt.expectError {
+				t.Errorf("IsValid() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+func TestVaultSecretGetRolloutAnnotationName(t *testing.T) {
+	vs := &VaultSecret{ObjectMeta: metav1.ObjectMeta{Name: "db-credentials"}}
+	if result := vs.GetRolloutAnnotationName(); result != "vaultsecret.redhatcop.redhat.io/db-credentials" {
+		t.Errorf("GetRolloutAnnotationName() = %v", result)
+	}
+}
+
+func TestVaultSecretValidateOutputs(t *testing.T) {
+	stringData := map[string]string{"password": "{{ .db.password }}"}
+	tests := []struct {
+		name        string
+		output      TemplatizedK8sSecret
+		outputs     []VaultSecretOutput
+		expectError bool
+	}{
+		{
+			name:   "single output",
+			output: TemplatizedK8sSecret{Name: "db", Type: "Opaque", StringData: stringData},
+		},
+		{
+			name: "list of outputs",
+			outputs: []VaultSecretOutput{
+				{Kind: "Secret", Name: "db", Type: "Opaque", StringData: stringData},
+				{Kind: "ConfigMap", Name: "db", StringData: map[string]string{"url": "{{ .db.url }}"}},
+			},
+		},
+		{
+			name:        "neither output nor outputs",
+			expectError: true,
+		},
+		{
+			name:        "both output and outputs",
+			output:      TemplatizedK8sSecret{Name: "db", Type: "Opaque", StringData: stringData},
+			outputs:     []VaultSecretOutput{{Kind: "ConfigMap", Name: "db", StringData: stringData}},
+			expectError: true,
+		},
+		{
+			name: "duplicate outputs",
+			outputs: []VaultSecretOutput{
+				{Kind: "Secret", Name: "db", Type: "Opaque", StringData: stringData},
+				{Kind: "Secret", Name: "db", Type: "kubernetes.io/basic-auth", StringData: stringData},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := &VaultSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "db-credentials"},
+				Spec:       VaultSecretSpec{TemplatizedK8sSecret: tt.output, Outputs: tt.outputs},
+			}
+			_, err := vs.IsValid()
+			if (err != nil) != tt.expectError {
+				t.Errorf("IsValid() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+func TestVaultSecretGetOutputs(t *testing.T) {
+	vs := &VaultSecret{
+		Spec: VaultSecretSpec{
+			TemplatizedK8sSecret: TemplatizedK8sSecret{
+				Name:        "db",
+				Type:        "kubernetes.io/basic-auth",
+				StringData:  map[string]string{"password": "{{ .db.password }}"},
+				Labels:      map[string]string{"app": "db"},
+				Annotations: map[string]string{"team": "payments"},
+			},
+		},
+	}
+	expected := []VaultSecretOutput{{
+		Kind:        "Secret",
+		Name:        "db",
+		Type:        "kubernetes.io/basic-auth",
+		StringData:  map[string]string{"password": "{{ .db.password }}"},
+		Labels:      map[string]string{"app": "db"},
+		Annotations: map[string]string{"team": "payments"},
+	}}
+	if result := vs.GetOutputs(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("GetOutputs() = %v, expected %v", result, expected)
+	}
+
+	vs.Spec.Outputs = []VaultSecretOutput{{Kind: "ConfigMap", Name: "db-endpoint", StringData: map[string]string{"url": "{{ .db.url }}"}}}
+	if result := vs.GetOutputs(); !reflect.DeepEqual(result, vs.Spec.Outputs) {
+		t.Errorf("GetOutputs() = %v, expected %v", result, vs.Spec.Outputs)
+	}
+}
+
+func TestVaultSecretValidateTargetNamespaces(t *testing.T) {
+	tests := []struct {
+		name             string
+		targetNamespaces *vaultutils.TargetNamespaceConfig
+		expectError      bool
+	}{
+		{
+			name: "no target namespaces",
+		},
+		{
+			name:             "list of namespaces",
+			targetNamespaces: &vaultutils.TargetNamespaceConfig{TargetNamespaces: []string{"team-a", "team-b"}},
+		},
+		{
+			name: "namespace selector",
+			targetNamespaces: &vaultutils.TargetNamespaceConfig{TargetNamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"registry-access": "true"},
+			}},
+		},
+		{
+			name:             "neither list nor selector",
+			targetNamespaces: &vaultutils.TargetNamespaceConfig{},
+			expectError:      true,
+		},
+		{
+			name: "both list and selector",
+			targetNamespaces: &vaultutils.TargetNamespaceConfig{
+				TargetNamespaces:        []string{"team-a"},
+				TargetNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"registry-access": "true"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid selector",
+			targetNamespaces: &vaultutils.TargetNamespaceConfig{TargetNamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "registry-access", Operator: "Unknown"}},
+			}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := &VaultSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: "vault-admin"},
+				Spec: VaultSecretSpec{
+					TemplatizedK8sSecret: TemplatizedK8sSecret{Name: "pull-secret", Type: "kubernetes.io/dockerconfigjson"},
+					TargetNamespaces:     tt.targetNamespaces,
+				},
+			}
+			err := vs.isValid()
+			if tt.expectError && err == nil {
+				t.Errorf("expected error but got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVaultSecretGrantAllows(t *testing.T) {
+	tests := []struct {
+		name         string
+		grant        VaultSecretGrantSpec
+		namespace    string
+		vaultSecret  string
+		expectAllows bool
+	}{
+		{
+			name:         "all VaultSecrets of the source namespace",
+			grant:        VaultSecretGrantSpec{SourceNamespace: "vault-admin"},
+			namespace:    "vault-admin",
+			vaultSecret:  "pull-secret",
+			expectAllows: true,
+		},
+		{
+			name:         "listed VaultSecret",
+			grant:        VaultSecretGrantSpec{SourceNamespace: "vault-admin", VaultSecrets: []string{"pull-secret"}},
+			namespace:    "vault-admin",
+			vaultSecret:  "pull-secret",
+			expectAllows: true,
+		},
+		{
+			name:         "VaultSecret not listed",
+			grant:        VaultSecretGrantSpec{SourceNamespace: "vault-admin", VaultSecrets: []string{"pull-secret"}},
+			namespace:    "vault-admin",
+			vaultSecret:  "database-credentials",
+			expectAllows: false,
+		},
+		{
+			name:         "other source namespace",
+			grant:        VaultSecretGrantSpec{SourceNamespace: "vault-admin"},
+			namespace:    "team-a",
+			vaultSecret:  "pull-secret",
+			expectAllows: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grant := &VaultSecretGrant{Spec: tt.grant}
+			if allows := grant.Allows(tt.namespace, tt.vaultSecret); allows != tt.expectAllows {
+				t.Errorf("Allows() = %v, want %v", allows, tt.expectAllows)
+			}
+		})
+	}
+}
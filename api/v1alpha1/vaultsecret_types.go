@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"errors"
+	"fmt"
+
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -48,8 +51,45 @@ type VaultSecretSpec struct {
 	// +kubebuilder:validation:Required
 	VaultSecretDefinitions []VaultSecretDefinition `json:"vaultSecretDefinitions,omitempty"`
 	// TemplatizedK8sSecret is the formatted K8s Secret created by templating from the Vault KV secrets.
-	// +kubebuilder:validation:Required
+	// Either TemplatizedK8sSecret or Outputs can be specified.
+	// +kubebuilder:validation:Optional
 	TemplatizedK8sSecret TemplatizedK8sSecret `json:"output,omitempty"`
+	// Outputs are the K8s Secrets and ConfigMaps created by templating from the Vault secrets, allowing the same Vault secrets to be rendered into several resources.
+	// Either TemplatizedK8sSecret or Outputs can be specified.
+	// +kubebuilder:validation:Optional
+	Outputs []VaultSecretOutput `json:"outputs,omitempty"`
+	// RolloutTargets are the workloads in the same namespace consuming the K8s Secret that should be restarted when the content of the K8s Secret changes.
+	// The operator patches the pod template of the selected workloads with an annotation carrying the hash of the K8s Secret, triggering a rolling restart.
+	// +kubebuilder:validation:Optional
+	RolloutTargets []RolloutTarget `json:"rolloutTargets,omitempty"`
+	// TargetNamespaces selects other namespaces the outputs are copied to, in addition to the namespace of the VaultSecret.
+	// A target namespace must opt in with a VaultSecretGrant allowing this VaultSecret, otherwise it is skipped.
+	// The copies are not owned by the VaultSecret: they are tracked in the status and deleted by the finalizer.
+	// +kubebuilder:validation:Optional
+	TargetNamespaces *vaultutils.TargetNamespaceConfig `json:"targetNamespaces,omitempty"`
+}
+
+type RolloutTarget struct {
+	// Kind is the kind of the workload to restart.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum={"Deployment","StatefulSet","DaemonSet"}
+	Kind string `json:"kind"`
+	// Name is the name of the workload to restart. Either Name or Selector can be specified.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+	// Selector is a label selector of the workloads to restart. Either Name or Selector can be specified.
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// OutputReference identifies an output, or a copy of an output in a target namespace.
+type OutputReference struct {
+	// Kind is the kind of the output, either Secret or ConfigMap.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the output.
+	Namespace string `json:"namespace"`
+	// Name is the name of the output.
+	Name string `json:"name"`
 }
 
 // VaultSecretStatus defines the observed state of VaultSecret
@@ -66,11 +106,30 @@ type VaultSecretStatus struct {
 	//NextVaultSecretUpdate the next time when this secret will be synced with Vault. If nil, it will not be refreshed.
 	NextVaultSecretUpdate *metav1.Time `json:"nextVaultSecretUpdate,omitempty"`
 
+	//LastLeaseRenewal the last time when the leases of the dynamic secrets were renewed instead of being read again from Vault.
+	//It is reset every time the secret is read again from Vault.
+	// +kubebuilder:validation:Optional
+	LastLeaseRenewal *metav1.Time `json:"lastLeaseRenewal,omitempty"`
+
 	//SyncedResourceVersion is a combination of the VaultSecret's generation and metadata hash.
 	//Is enabled by SyncOnResourceChange: true
 	// +kubebuilder:validation:Optional
 	SyncedResourceVersion string `json:"syncedResourceVersion,omitempty"`
 
+	//RolloutSecretHash the hash of the K8s Secret that was last propagated to the rollout targets
+	// +kubebuilder:validation:Optional
+	RolloutSecretHash string `json:"rolloutSecretHash,omitempty"`
+
+	//Outputs the outputs written in the namespace of the VaultSecret.
+	//An output is deleted when it is removed from the spec.
+	// +kubebuilder:validation:Optional
+	Outputs []OutputReference `json:"outputs,omitempty"`
+
+	//CopiedOutputs the copies of the outputs written in the target namespaces.
+	//A copy is deleted when its namespace is no longer selected or granted, and when the VaultSecret is deleted.
+	// +kubebuilder:validation:Optional
+	CopiedOutputs []OutputReference `json:"copiedOutputs,omitempty"`
+
 	//VaultSecretDefinitionsStatus information used to determine if the secret should be rereconciled
 	VaultSecretDefinitionsStatus []VaultSecretDefinitionStatus `json:"vaultSecretDefinitionsStatus,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
@@ -148,11 +207,26 @@ type VaultSecretDefinitionStatus struct {
 	// LeaseDuration is the time until the secret should be read in again, thus recreating the k8s Secret
 	// +kubebuilder:validation:Optional
 	LeaseDuration int `json:"lease_duration,omitempty"`
-	// Renewable informs if the lease is renewable for the dynamic secret
+	// Renewable informs if the lease is renewable for the dynamic secret.
+	// It is set to false once a renewal is capped by the lease max TTL, so that the secret is read again at the next refresh.
 	// +kubebuilder:validation:Optional
 	Renewable bool `json:"renewable,omitempty"`
 }
 
+// HasRenewableLeases returns true when every Vault secret definition was read with a renewable lease,
+// meaning that the secret can be refreshed by renewing the leases rather than reading the secrets again.
+func (vs *VaultSecret) HasRenewableLeases() bool {
+	if len(vs.Status.VaultSecretDefinitionsStatus) == 0 || len(vs.Status.VaultSecretDefinitionsStatus) != len(vs.Spec.VaultSecretDefinitions) {
+		return false
+	}
+	for _, defstat := range vs.Status.VaultSecretDefinitionsStatus {
+		if defstat.LeaseID == "" || !defstat.Renewable {
+			return false
+		}
+	}
+	return true
+}
+
 type TemplatizedK8sSecret struct {
 	// Name is the K8s Secret name to output to.
 	// +kubebuilder:validation:Required
@@ -173,15 +247,126 @@ type TemplatizedK8sSecret struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
+type VaultSecretOutput struct {
+	// Kind is the kind of the K8s resource to output to.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum={"Secret","ConfigMap"}
+	// +kubebuilder:default=Secret
+	Kind string `json:"kind"`
+	// Name is the name of the K8s resource to output to.
+	// +kubebuilder:validation:Required
+	Name string `json:"name,omitempty"`
+	// Type is the K8s Secret type to output to. Not used for ConfigMaps.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Opaque
+	Type string `json:"type"`
+	// StringData is the data of the K8s resource in string form with go templating support
+	// to transform the Vault secrets into a formatted K8s Secret or ConfigMap.
+	// The Sprig template library and Helm functions (like toYaml) are supported.
+	// +kubebuilder:validation:Required
+	StringData map[string]string `json:"stringData,omitempty"`
+	// Labels are labels to add to the final K8s resource.
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are annotations to add to the final K8s resource.
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// GetOutputs returns the K8s resources to output to. When the single output is used, it is returned as a Secret output.
+func (vs *VaultSecret) GetOutputs() []VaultSecretOutput {
+	if len(vs.Spec.Outputs) > 0 {
+		return vs.Spec.Outputs
+	}
+	return []VaultSecretOutput{{
+		Kind:        "Secret",
+		Name:        vs.Spec.TemplatizedK8sSecret.Name,
+		Type:        vs.Spec.TemplatizedK8sSecret.Type,
+		StringData:  vs.Spec.TemplatizedK8sSecret.StringData,
+		Labels:      vs.Spec.TemplatizedK8sSecret.Labels,
+		Annotations: vs.Spec.TemplatizedK8sSecret.Annotations,
+	}}
+}
+
 func (vs *VaultSecret) IsValid() (bool, error) {
 	err := vs.isValid()
 	return err == nil, err
 }
 
 func (vs *VaultSecret) isValid() error {
+	err := vs.validateOutputs()
+	if err != nil {
+		return err
+	}
+	err = vs.validateRolloutTargets()
+	if err != nil {
+		return err
+	}
+	return vs.validateTargetNamespaces()
+}
+
+func (vs *VaultSecret) validateOutputs() error {
+	if (vs.Spec.TemplatizedK8sSecret.Name == "") == (len(vs.Spec.Outputs) == 0) {
+		return errors.New("only one of output or outputs can be specified")
+	}
+	outputs := map[string]bool{}
+	for i, output := range vs.Spec.Outputs {
+		key := output.Kind + "/" + output.Name
+		if outputs[key] {
+			return fmt.Errorf("spec.outputs[%d]: duplicate output %s", i, key)
+		}
+		outputs[key] = true
+	}
+	return nil
+}
+
+func (vs *VaultSecret) validateRolloutTargets() error {
+	if len(vs.Spec.RolloutTargets) == 0 {
+		return nil
+	}
+	// the name becomes the name part of the rollout annotation, which is limited to 63 characters
+	if len(vs.Name) > 63 {
+		return errors.New("the name of a VaultSecret with rolloutTargets cannot be longer than 63 characters")
+	}
+	for i, target := range vs.Spec.RolloutTargets {
+		if (target.Name == "") == (target.Selector == nil) {
+			return fmt.Errorf("spec.rolloutTargets[%d]: only one of name or selector can be specified", i)
+		}
+		if target.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(target.Selector); err != nil {
+				return fmt.Errorf("spec.rolloutTargets[%d]: invalid selector: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (vs *VaultSecret) validateTargetNamespaces() error {
+	if vs.Spec.TargetNamespaces == nil {
+		return nil
+	}
+	if (vs.Spec.TargetNamespaces.TargetNamespaceSelector == nil) == (vs.Spec.TargetNamespaces.TargetNamespaces == nil) {
+		return errors.New("spec.targetNamespaces: only one of TargetNamespaceSelector or TargetNamespaces can be specified")
+	}
+	if vs.Spec.TargetNamespaces.TargetNamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(vs.Spec.TargetNamespaces.TargetNamespaceSelector); err != nil {
+			return fmt.Errorf("spec.targetNamespaces: invalid selector: %w", err)
+		}
+	}
 	return nil
 }
 
+// GetCopyOwnerAnnotationValue returns the value of the annotation identifying the copies of the outputs of this VaultSecret in the target namespaces.
+func (vs *VaultSecret) GetCopyOwnerAnnotationValue() string {
+	return vs.Namespace + "/" + vs.Name
+}
+
+// GetRolloutAnnotationName returns the pod template annotation used to propagate the hash of the K8s Secret to the rollout targets.
+// The annotation is specific to this VaultSecret, so that a workload can consume the secrets of several VaultSecrets.
+func (vs *VaultSecret) GetRolloutAnnotationName() string {
+	return "vaultsecret.redhatcop.redhat.io/" + vs.Name
+}
+
 var _ vaultutils.VaultSecretObject = &VaultSecretDefinition{}
 
 func (d *VaultSecretDefinition) GetVaultConnection() *vaultutils.VaultConnection {
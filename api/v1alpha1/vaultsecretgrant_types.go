@@ -0,0 +1,69 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultSecretGrantSpec defines the desired state of VaultSecretGrant
+type VaultSecretGrantSpec struct {
+	// SourceNamespace is the namespace of the VaultSecrets allowed to copy their outputs to the namespace of this grant.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	SourceNamespace string `json:"sourceNamespace"`
+
+	// VaultSecrets are the names of the VaultSecrets of the source namespace allowed to copy their outputs to the namespace of this grant.
+	// If empty, all the VaultSecrets of the source namespace are allowed.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	VaultSecrets []string `json:"vaultSecrets,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VaultSecretGrant is the Schema for the vaultsecretgrants API.
+// A VaultSecretGrant is created in a namespace to opt in to the copies of the outputs of VaultSecrets of another namespace.
+type VaultSecretGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VaultSecretGrantSpec `json:"spec,omitempty"`
+}
+
+// Allows returns whether the grant allows the VaultSecret with the given namespace and name to copy its outputs.
+func (g *VaultSecretGrant) Allows(namespace string, name string) bool {
+	if g.Spec.SourceNamespace != namespace {
+		return false
+	}
+	return len(g.Spec.VaultSecrets) == 0 || slices.Contains(g.Spec.VaultSecrets, name)
+}
+
+//+kubebuilder:object:root=true
+
+// VaultSecretGrantList contains a list of VaultSecretGrant
+type VaultSecretGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultSecretGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultSecretGrant{}, &VaultSecretGrantList{})
+}
@@ -0,0 +1,221 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVaultResourceTemplateResolveParameters(t *testing.T) {
+	template := &VaultResourceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "team"},
+		Spec: VaultResourceTemplateSpec{
+			Parameters: []VaultResourceTemplateParameter{
+				{Name: "team", Required: true},
+				{Name: "ttl", Default: "1h"},
+				{Name: "empty"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		values   map[string]string
+		expected map[string]string
+		errors   []string
+	}{
+		{
+			name:     "defaults",
+			values:   map[string]string{"team": "a"},
+			expected: map[string]string{"team": "a", "ttl": "1h", "empty": ""},
+		},
+		{
+			name:     "overridden default",
+			values:   map[string]string{"team": "a", "ttl": "2h"},
+			expected: map[string]string{"team": "a", "ttl": "2h", "empty": ""},
+		},
+		{
+			name:   "missing required parameter",
+			values: map[string]string{},
+			errors: []string{"required parameter team is not set"},
+		},
+		{
+			name:   "undeclared parameter",
+			values: map[string]string{"team": "a", "other": "b"},
+			errors: []string{"parameter other is not declared by VaultResourceTemplate team"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := template.ResolveParameters(tt.values)
+			if len(tt.errors) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expected) {
+					t.Errorf("ResolveParameters() = %v, expected %v", result, tt.expected)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, expected := range tt.errors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("error %q does not contain %q", err, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestVaultTenantGetTemplateReference(t *testing.T) {
+	tenant := &VaultTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "team-a"},
+		Spec:       VaultTenantSpec{TemplateRef: VaultResourceTemplateReference{Name: "team"}},
+	}
+	if ref := tenant.GetTemplateReference(); ref.Namespace != "team-a" || ref.Name != "team" {
+		t.Errorf("GetTemplateReference() = %v, expected team-a/team", ref)
+	}
+}
+
+func TestVaultTenantIsValid(t *testing.T) {
+	tenant := &VaultTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "team-a"},
+		Spec: VaultTenantSpec{
+			TemplateRef: VaultResourceTemplateReference{Name: "team"},
+			Parameters:  map[string]string{"team": "team-a", "max_lease_ttl": "8760h"},
+		},
+	}
+	if err := tenant.isValid(); err != nil {
+		t.Errorf("isValid() unexpected error: %v", err)
+	}
+
+	tenant.Spec.Parameters["max-lease-ttl"] = "8760h"
+	if err := tenant.isValid(); err == nil || !strings.Contains(err.Error(), `parameter "max-lease-ttl" is not a valid parameter name`) {
+		t.Errorf("isValid() = %v, expected an invalid parameter name error", err)
+	}
+}
+
+func TestVaultTenantParseResources(t *testing.T) {
+	tenant := &VaultTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "team-a"},
+	}
+
+	tests := []struct {
+		name     string
+		rendered string
+		expected []string
+		err      string
+	}{
+		{
+			name: "multiple documents",
+			rendered: `
+apiVersion: redhatcop.redhat.io/v1alpha1
+kind: SecretEngineMount
+metadata:
+  name: kv
+---
+---
+apiVersion: redhatcop.redhat.io/v1alpha1
+kind: Policy
+metadata:
+  name: reader
+  namespace: team-a
+`,
+			expected: []string{"SecretEngineMount/kv", "Policy/reader"},
+		},
+		{
+			name:     "empty",
+			rendered: "---\n",
+			expected: []string{},
+		},
+		{
+			name: "foreign api version",
+			rendered: `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+`,
+			err: `resource Secret/secret has apiVersion "v1"`,
+		},
+		{
+			name: "missing name",
+			rendered: `
+apiVersion: redhatcop.redhat.io/v1alpha1
+kind: Policy
+`,
+			err: "must have a kind and a name",
+		},
+		{
+			name: "nested tenant",
+			rendered: `
+apiVersion: redhatcop.redhat.io/v1alpha1
+kind: VaultTenant
+metadata:
+  name: nested
+`,
+			err: "cannot be created by a VaultTenant",
+		},
+		{
+			name: "other namespace",
+			rendered: `
+apiVersion: redhatcop.redhat.io/v1alpha1
+kind: Policy
+metadata:
+  name: reader
+  namespace: team-b
+`,
+			err: "must be in the namespace of the VaultTenant",
+		},
+		{
+			name: "duplicate",
+			rendered: `
+apiVersion: redhatcop.redhat.io/v1alpha1
+kind: Policy
+metadata:
+  name: reader
+---
+apiVersion: redhatcop.redhat.io/v1alpha1
+kind: Policy
+metadata:
+  name: reader
+`,
+			err: "is rendered more than once",
+		},
+		{
+			name:     "invalid yaml",
+			rendered: "kind: [",
+			err:      "unable to decode the rendered template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, err := tenant.ParseResources(tt.rendered)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ids := []string{}
+			for _, resource := range resources {
+				if resource.GetNamespace() != "team-a" {
+					t.Errorf("resource %s/%s is in namespace %q", resource.GetKind(), resource.GetName(), resource.GetNamespace())
+				}
+				ids = append(ids, resource.GetKind()+"/"+resource.GetName())
+			}
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("ParseResources() = %v, expected %v", ids, tt.expected)
+			}
+		})
+	}
+}
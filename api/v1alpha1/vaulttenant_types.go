@@ -0,0 +1,185 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// VaultTenantSpec defines the desired state of VaultTenant
+type VaultTenantSpec struct {
+	// TemplateRef is the VaultResourceTemplate rendered for this tenant, in the namespace of the VaultTenant.
+	// +kubebuilder:validation:Required
+	TemplateRef VaultResourceTemplateReference `json:"templateRef"`
+
+	// Parameters are the values of the parameters of the template.
+	// +kubebuilder:validation:Optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+type VaultResourceTemplateReference struct {
+	// Name is the name of the VaultResourceTemplate.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// VaultTenantStatus defines the observed state of VaultTenant
+type VaultTenantStatus struct {
+
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Children are the resources created for this tenant, in the order of the template, with the status of their ReconcileSuccessful condition.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	Children []VaultTenantChild `json:"children,omitempty"`
+}
+
+type VaultTenantChild struct {
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Name is the name of the resource, in the namespace of the VaultTenant.
+	Name string `json:"name"`
+
+	// ReconcileSuccessful is the status of the ReconcileSuccessful condition of the resource, Unknown until the resource is reconciled.
+	ReconcileSuccessful metav1.ConditionStatus `json:"reconcileSuccessful"`
+
+	// Message is the message of the last failed reconcile of the resource.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	// ChildrenReconciled is true when all the children of a VaultTenant have been successfully reconciled.
+	ChildrenReconciled = "ChildrenReconciled"
+
+	ChildrenReconciledReason    = "ChildrenReconciled"
+	ChildrenNotReconciledReason = "ChildrenNotReconciled"
+)
+
+func (m *VaultTenant) GetConditions() []metav1.Condition {
+	return m.Status.Conditions
+}
+
+func (m *VaultTenant) SetConditions(conditions []metav1.Condition) {
+	m.Status.Conditions = conditions
+}
+
+// GetTemplateReference returns the namespaced name of the VaultResourceTemplate of the tenant.
+// Templates are only read from the namespace of the tenant, so that a tenant cannot render the templates of other namespaces.
+func (m *VaultTenant) GetTemplateReference() types.NamespacedName {
+	return types.NamespacedName{Namespace: m.Namespace, Name: m.Spec.TemplateRef.Name}
+}
+
+// templateParameterNameRegexp matches the names of the parameters of a VaultResourceTemplate.
+var templateParameterNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// isValid checks that the parameters of the tenant can be declared by a VaultResourceTemplate.
+func (m *VaultTenant) isValid() error {
+	var errs []error
+	names := make([]string, 0, len(m.Spec.Parameters))
+	for name := range m.Spec.Parameters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if !templateParameterNameRegexp.MatchString(name) {
+			errs = append(errs, fmt.Errorf("parameter %q is not a valid parameter name, it must match %s", name, templateParameterNameRegexp))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ParseResources decodes the rendered template into the resources of the tenant, in the namespace of the tenant.
+// Only redhatcop.redhat.io/v1alpha1 resources are accepted, except VaultTenants and VaultResourceTemplates.
+func (m *VaultTenant) ParseResources(rendered string) ([]*unstructured.Unstructured, error) {
+	resources := []*unstructured.Unstructured{}
+	seen := map[string]bool{}
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(rendered), 4096)
+	for {
+		content := map[string]any{}
+		err := decoder.Decode(&content)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode the rendered template: %w", err)
+		}
+		if len(content) == 0 {
+			continue
+		}
+		resource := &unstructured.Unstructured{Object: content}
+		id := resource.GetKind() + "/" + resource.GetName()
+		switch {
+		case resource.GetAPIVersion() != GroupVersion.String():
+			return nil, fmt.Errorf("resource %s has apiVersion %q, only %s resources are supported", id, resource.GetAPIVersion(), GroupVersion)
+		case resource.GetKind() == "" || resource.GetName() == "":
+			return nil, fmt.Errorf("resource %s must have a kind and a name", id)
+		case resource.GetKind() == "VaultTenant" || resource.GetKind() == "VaultResourceTemplate":
+			return nil, fmt.Errorf("resource %s cannot be created by a VaultTenant", id)
+		case resource.GetNamespace() != "" && resource.GetNamespace() != m.Namespace:
+			return nil, fmt.Errorf("resource %s must be in the namespace of the VaultTenant, not %s", id, resource.GetNamespace())
+		case seen[id]:
+			return nil, fmt.Errorf("resource %s is rendered more than once", id)
+		}
+		seen[id] = true
+		resource.SetNamespace(m.Namespace)
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// VaultTenant is the Schema for the vaulttenants API.
+// A VaultTenant renders a VaultResourceTemplate with its parameters and manages the resulting resources.
+type VaultTenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultTenantSpec   `json:"spec,omitempty"`
+	Status VaultTenantStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VaultTenantList contains a list of VaultTenant
+type VaultTenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultTenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultTenant{}, &VaultTenantList{})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var vaulttenantlog = logf.Log.WithName("vaulttenant-resource")
+
+func (r *VaultTenant) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, r).
+		WithDefaulter(r).
+		WithValidator(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-redhatcop-redhat-io-v1alpha1-vaulttenant,mutating=true,failurePolicy=fail,sideEffects=None,groups=redhatcop.redhat.io,resources=vaulttenants,verbs=create,versions=v1alpha1,name=mvaulttenant.kb.io,admissionReviewVersions=v1
+
+var _ admission.Defaulter[*VaultTenant] = &VaultTenant{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (r *VaultTenant) Default(ctx context.Context, obj *VaultTenant) error {
+	vaulttenantlog.Info("default", "name", obj.Name)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-redhatcop-redhat-io-v1alpha1-vaulttenant,mutating=false,failurePolicy=fail,sideEffects=None,groups=redhatcop.redhat.io,resources=vaulttenants,verbs=create;update,versions=v1alpha1,name=vvaulttenant.kb.io,admissionReviewVersions=v1
+
+var _ admission.Validator[*VaultTenant] = &VaultTenant{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *VaultTenant) ValidateCreate(ctx context.Context, obj *VaultTenant) (admission.Warnings, error) {
+	vaulttenantlog.Info("validate create", "name", obj.Name)
+	return nil, obj.isValid()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *VaultTenant) ValidateUpdate(ctx context.Context, oldObj, newObj *VaultTenant) (admission.Warnings, error) {
+	vaulttenantlog.Info("validate update", "name", newObj.Name)
+	return nil, newObj.isValid()
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *VaultTenant) ValidateDelete(ctx context.Context, obj *VaultTenant) (admission.Warnings, error) {
+	vaulttenantlog.Info("validate delete", "name", obj.Name)
+	return nil, nil
+}
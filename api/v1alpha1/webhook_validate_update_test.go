@@ -455,11 +455,11 @@ func TestValidateUpdateRejectsPathChange(t *testing.T) {
 				return r.ValidateUpdate(context.Background(),
 					&DatabaseSecretEngineStaticRole{ObjectMeta: metav1.ObjectMeta{Name: "old"}, Spec: DatabaseSecretEngineStaticRoleSpec{
 						Path:           "same/path",
-						DBSEStaticRole: DBSEStaticRole{PasswordCredentialConfig: &PasswordCredentialConfig{}},
+						DBSEStaticRole: DBSEStaticRole{RotationPeriod: 86400, PasswordCredentialConfig: &PasswordCredentialConfig{}},
 					}},
 					&DatabaseSecretEngineStaticRole{ObjectMeta: metav1.ObjectMeta{Name: "new"}, Spec: DatabaseSecretEngineStaticRoleSpec{
 						Path:           "same/path",
-						DBSEStaticRole: DBSEStaticRole{PasswordCredentialConfig: &PasswordCredentialConfig{}},
+						DBSEStaticRole: DBSEStaticRole{RotationPeriod: 86400, PasswordCredentialConfig: &PasswordCredentialConfig{}},
 					}},
 				)
 			},
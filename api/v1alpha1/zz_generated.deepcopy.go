@@ -1247,6 +1247,36 @@ func (in *DBSEConfig) DeepCopy() *DBSEConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSEConnectionVerification) DeepCopyInto(out *DBSEConnectionVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSEConnectionVerification.
+func (in *DBSEConnectionVerification) DeepCopy() *DBSEConnectionVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSEConnectionVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSECredentialsSecret) DeepCopyInto(out *DBSECredentialsSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSECredentialsSecret.
+func (in *DBSECredentialsSecret) DeepCopy() *DBSECredentialsSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSECredentialsSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBSERole) DeepCopyInto(out *DBSERole) {
 	*out = *in
@@ -1384,6 +1414,11 @@ func (in *DatabaseSecretEngineConfigSpec) DeepCopyInto(out *DatabaseSecretEngine
 	in.Authentication.DeepCopyInto(&out.Authentication)
 	in.DBSEConfig.DeepCopyInto(&out.DBSEConfig)
 	in.RootCredentials.DeepCopyInto(&out.RootCredentials)
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(DBSEConnectionVerification)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSecretEngineConfigSpec.
@@ -1591,6 +1626,11 @@ func (in *DatabaseSecretEngineStaticRoleSpec) DeepCopyInto(out *DatabaseSecretEn
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
 	in.DBSEStaticRole.DeepCopyInto(&out.DBSEStaticRole)
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(DBSECredentialsSecret)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSecretEngineStaticRoleSpec.
@@ -1613,6 +1653,14 @@ func (in *DatabaseSecretEngineStaticRoleStatus) DeepCopyInto(out *DatabaseSecret
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastVaultRotation != nil {
+		in, out := &in.LastVaultRotation, &out.LastVaultRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.NextVaultRotation != nil {
+		in, out := &in.NextVaultRotation, &out.NextVaultRotation
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSecretEngineStaticRoleStatus.
@@ -1625,6 +1673,22 @@ func (in *DatabaseSecretEngineStaticRoleStatus) DeepCopy() *DatabaseSecretEngine
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DuoMFAConfig) DeepCopyInto(out *DuoMFAConfig) {
+	*out = *in
+	out.CredentialsSecret = in.CredentialsSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DuoMFAConfig.
+func (in *DuoMFAConfig) DeepCopy() *DuoMFAConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DuoMFAConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Entity) DeepCopyInto(out *Entity) {
 	*out = *in
@@ -2603,6 +2667,36 @@ func (in *GroupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupMemberSelectors) DeepCopyInto(out *GroupMemberSelectors) {
+	*out = *in
+	if in.Entities != nil {
+		in, out := &in.Entities, &out.Entities
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubernetesAuthEngineRoles != nil {
+		in, out := &in.KubernetesAuthEngineRoles, &out.KubernetesAuthEngineRoles
+		*out = new(KubernetesAuthEngineRoleMemberSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupMemberSelectors.
+func (in *GroupMemberSelectors) DeepCopy() *GroupMemberSelectors {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupMemberSelectors)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupSpec) DeepCopyInto(out *GroupSpec) {
 	*out = *in
@@ -2613,6 +2707,11 @@ func (in *GroupSpec) DeepCopyInto(out *GroupSpec) {
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
 	in.GroupConfig.DeepCopyInto(&out.GroupConfig)
+	if in.MemberSelectors != nil {
+		in, out := &in.MemberSelectors, &out.MemberSelectors
+		*out = new(GroupMemberSelectors)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSpec.
@@ -2869,6 +2968,11 @@ func (in *IdentityOIDCClientSpec) DeepCopyInto(out *IdentityOIDCClientSpec) {
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
 	in.IdentityOIDCClientConfig.DeepCopyInto(&out.IdentityOIDCClientConfig)
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(OIDCClientCredentialsSecret)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityOIDCClientSpec.
@@ -4044,6 +4148,27 @@ func (in *KubernetesAuthEngineRoleList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesAuthEngineRoleMemberSelector) DeepCopyInto(out *KubernetesAuthEngineRoleMemberSelector) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesAuthEngineRoleMemberSelector.
+func (in *KubernetesAuthEngineRoleMemberSelector) DeepCopy() *KubernetesAuthEngineRoleMemberSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesAuthEngineRoleMemberSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesAuthEngineRoleSpec) DeepCopyInto(out *KubernetesAuthEngineRoleSpec) {
 	*out = *in
@@ -4055,6 +4180,11 @@ func (in *KubernetesAuthEngineRoleSpec) DeepCopyInto(out *KubernetesAuthEngineRo
 	in.Authentication.DeepCopyInto(&out.Authentication)
 	in.VRole.DeepCopyInto(&out.VRole)
 	in.TargetNamespaces.DeepCopyInto(&out.TargetNamespaces)
+	if in.ServiceAccountEntities != nil {
+		in, out := &in.ServiceAccountEntities, &out.ServiceAccountEntities
+		*out = new(ServiceAccountEntitiesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesAuthEngineRoleSpec.
@@ -4077,6 +4207,11 @@ func (in *KubernetesAuthEngineRoleStatus) DeepCopyInto(out *KubernetesAuthEngine
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ServiceAccountEntities != nil {
+		in, out := &in.ServiceAccountEntities, &out.ServiceAccountEntities
+		*out = make([]ServiceAccountEntity, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesAuthEngineRoleStatus.
@@ -4520,65 +4655,154 @@ func (in *LDAPConfig) DeepCopy() *LDAPConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Mount) DeepCopyInto(out *Mount) {
+func (in *MFALoginEnforcement) DeepCopyInto(out *MFALoginEnforcement) {
 	*out = *in
-	in.Config.DeepCopyInto(&out.Config)
-	if in.Options != nil {
-		in, out := &in.Options, &out.Options
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MFALoginEnforcement.
+func (in *MFALoginEnforcement) DeepCopy() *MFALoginEnforcement {
+	if in == nil {
+		return nil
+	}
+	out := new(MFALoginEnforcement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MFALoginEnforcement) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MFALoginEnforcementList) DeepCopyInto(out *MFALoginEnforcementList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MFALoginEnforcement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mount.
-func (in *Mount) DeepCopy() *Mount {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MFALoginEnforcementList.
+func (in *MFALoginEnforcementList) DeepCopy() *MFALoginEnforcementList {
 	if in == nil {
 		return nil
 	}
-	out := new(Mount)
+	out := new(MFALoginEnforcementList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MFALoginEnforcementList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MountConfig) DeepCopyInto(out *MountConfig) {
+func (in *MFALoginEnforcementSpec) DeepCopyInto(out *MFALoginEnforcementSpec) {
 	*out = *in
-	if in.AuditNonHMACRequestKeys != nil {
-		in, out := &in.AuditNonHMACRequestKeys, &out.AuditNonHMACRequestKeys
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(utils.VaultConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Authentication.DeepCopyInto(&out.Authentication)
+	if in.MFAMethods != nil {
+		in, out := &in.MFAMethods, &out.MFAMethods
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.AuditNonHMACResponseKeys != nil {
-		in, out := &in.AuditNonHMACResponseKeys, &out.AuditNonHMACResponseKeys
+	if in.AuthEngineMounts != nil {
+		in, out := &in.AuthEngineMounts, &out.AuthEngineMounts
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.PassthroughRequestHeaders != nil {
-		in, out := &in.PassthroughRequestHeaders, &out.PassthroughRequestHeaders
+	if in.AuthMethodTypes != nil {
+		in, out := &in.AuthMethodTypes, &out.AuthMethodTypes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.AllowedResponseHeaders != nil {
-		in, out := &in.AllowedResponseHeaders, &out.AllowedResponseHeaders
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Entities != nil {
+		in, out := &in.Entities, &out.Entities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.retrievedMFAMethodIDs != nil {
+		in, out := &in.retrievedMFAMethodIDs, &out.retrievedMFAMethodIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.retrievedAuthMethodAccessors != nil {
+		in, out := &in.retrievedAuthMethodAccessors, &out.retrievedAuthMethodAccessors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.retrievedGroupIDs != nil {
+		in, out := &in.retrievedGroupIDs, &out.retrievedGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.retrievedEntityIDs != nil {
+		in, out := &in.retrievedEntityIDs, &out.retrievedEntityIDs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MountConfig.
-func (in *MountConfig) DeepCopy() *MountConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MFALoginEnforcementSpec.
+func (in *MFALoginEnforcementSpec) DeepCopy() *MFALoginEnforcementSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MountConfig)
+	out := new(MFALoginEnforcementSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Namespace) DeepCopyInto(out *Namespace) {
+func (in *MFALoginEnforcementStatus) DeepCopyInto(out *MFALoginEnforcementStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MFALoginEnforcementStatus.
+func (in *MFALoginEnforcementStatus) DeepCopy() *MFALoginEnforcementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MFALoginEnforcementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MFAMethod) DeepCopyInto(out *MFAMethod) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -4586,18 +4810,18 @@ func (in *Namespace) DeepCopyInto(out *Namespace) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Namespace.
-func (in *Namespace) DeepCopy() *Namespace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MFAMethod.
+func (in *MFAMethod) DeepCopy() *MFAMethod {
 	if in == nil {
 		return nil
 	}
-	out := new(Namespace)
+	out := new(MFAMethod)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Namespace) DeepCopyObject() runtime.Object {
+func (in *MFAMethod) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4605,31 +4829,31 @@ func (in *Namespace) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamespaceList) DeepCopyInto(out *NamespaceList) {
+func (in *MFAMethodList) DeepCopyInto(out *MFAMethodList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Namespace, len(*in))
+		*out = make([]MFAMethod, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceList.
-func (in *NamespaceList) DeepCopy() *NamespaceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MFAMethodList.
+func (in *MFAMethodList) DeepCopy() *MFAMethodList {
 	if in == nil {
 		return nil
 	}
-	out := new(NamespaceList)
+	out := new(MFAMethodList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NamespaceList) DeepCopyObject() runtime.Object {
+func (in *MFAMethodList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4637,7 +4861,7 @@ func (in *NamespaceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamespaceSpec) DeepCopyInto(out *NamespaceSpec) {
+func (in *MFAMethodSpec) DeepCopyInto(out *MFAMethodSpec) {
 	*out = *in
 	if in.Connection != nil {
 		in, out := &in.Connection, &out.Connection
@@ -4645,20 +4869,47 @@ func (in *NamespaceSpec) DeepCopyInto(out *NamespaceSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
+	if in.TOTP != nil {
+		in, out := &in.TOTP, &out.TOTP
+		*out = new(TOTPMFAConfig)
+		**out = **in
+	}
+	if in.Duo != nil {
+		in, out := &in.Duo, &out.Duo
+		*out = new(DuoMFAConfig)
+		**out = **in
+	}
+	if in.Okta != nil {
+		in, out := &in.Okta, &out.Okta
+		*out = new(OktaMFAConfig)
+		**out = **in
+	}
+	if in.PingID != nil {
+		in, out := &in.PingID, &out.PingID
+		*out = new(PingIDMFAConfig)
+		**out = **in
+	}
+	if in.retrievedCredentials != nil {
+		in, out := &in.retrievedCredentials, &out.retrievedCredentials
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSpec.
-func (in *NamespaceSpec) DeepCopy() *NamespaceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MFAMethodSpec.
+func (in *MFAMethodSpec) DeepCopy() *MFAMethodSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NamespaceSpec)
+	out := new(MFAMethodSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamespaceStatus) DeepCopyInto(out *NamespaceStatus) {
+func (in *MFAMethodStatus) DeepCopyInto(out *MFAMethodStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -4669,50 +4920,356 @@ func (in *NamespaceStatus) DeepCopyInto(out *NamespaceStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceStatus.
-func (in *NamespaceStatus) DeepCopy() *NamespaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MFAMethodStatus.
+func (in *MFAMethodStatus) DeepCopy() *MFAMethodStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NamespaceStatus)
+	out := new(MFAMethodStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKICommon) DeepCopyInto(out *PKICommon) {
+func (in *Mount) DeepCopyInto(out *Mount) {
 	*out = *in
-	out.TTL = in.TTL
-	if in.PermittedDnsDomains != nil {
-		in, out := &in.PermittedDnsDomains, &out.PermittedDnsDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.Config.DeepCopyInto(&out.Config)
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKICommon.
-func (in *PKICommon) DeepCopy() *PKICommon {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mount.
+func (in *Mount) DeepCopy() *Mount {
 	if in == nil {
 		return nil
 	}
-	out := new(PKICommon)
+	out := new(Mount)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKIConfig) DeepCopyInto(out *PKIConfig) {
+func (in *MountConfig) DeepCopyInto(out *MountConfig) {
 	*out = *in
-	in.PKIConfigUrls.DeepCopyInto(&out.PKIConfigUrls)
-	out.PKIConfigCRL = in.PKIConfigCRL
+	if in.AuditNonHMACRequestKeys != nil {
+		in, out := &in.AuditNonHMACRequestKeys, &out.AuditNonHMACRequestKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuditNonHMACResponseKeys != nil {
+		in, out := &in.AuditNonHMACResponseKeys, &out.AuditNonHMACResponseKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PassthroughRequestHeaders != nil {
+		in, out := &in.PassthroughRequestHeaders, &out.PassthroughRequestHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedResponseHeaders != nil {
+		in, out := &in.AllowedResponseHeaders, &out.AllowedResponseHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIConfig.
-func (in *PKIConfig) DeepCopy() *PKIConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MountConfig.
+func (in *MountConfig) DeepCopy() *MountConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PKIConfig)
+	out := new(MountConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Namespace) DeepCopyInto(out *Namespace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Namespace.
+func (in *Namespace) DeepCopy() *Namespace {
+	if in == nil {
+		return nil
+	}
+	out := new(Namespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Namespace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceList) DeepCopyInto(out *NamespaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Namespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceList.
+func (in *NamespaceList) DeepCopy() *NamespaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceReference) DeepCopyInto(out *NamespaceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceReference.
+func (in *NamespaceReference) DeepCopy() *NamespaceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSpec) DeepCopyInto(out *NamespaceSpec) {
+	*out = *in
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(utils.VaultConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Authentication.DeepCopyInto(&out.Authentication)
+	if in.Parent != nil {
+		in, out := &in.Parent, &out.Parent
+		*out = new(NamespaceReference)
+		**out = **in
+	}
+	if in.CustomMetadata != nil {
+		in, out := &in.CustomMetadata, &out.CustomMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSpec.
+func (in *NamespaceSpec) DeepCopy() *NamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceStatus) DeepCopyInto(out *NamespaceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceStatus.
+func (in *NamespaceStatus) DeepCopy() *NamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCClientCredentialsSecret) DeepCopyInto(out *OIDCClientCredentialsSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCClientCredentialsSecret.
+func (in *OIDCClientCredentialsSecret) DeepCopy() *OIDCClientCredentialsSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCClientCredentialsSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OktaMFAConfig) DeepCopyInto(out *OktaMFAConfig) {
+	*out = *in
+	out.CredentialsSecret = in.CredentialsSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OktaMFAConfig.
+func (in *OktaMFAConfig) DeepCopy() *OktaMFAConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OktaMFAConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputReference) DeepCopyInto(out *OutputReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputReference.
+func (in *OutputReference) DeepCopy() *OutputReference {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIACME) DeepCopyInto(out *PKIACME) {
+	*out = *in
+	if in.AllowedIssuers != nil {
+		in, out := &in.AllowedIssuers, &out.AllowedIssuers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedRoles != nil {
+		in, out := &in.AllowedRoles, &out.AllowedRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EABSecret != nil {
+		in, out := &in.EABSecret, &out.EABSecret
+		*out = new(PKIEABSecret)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIACME.
+func (in *PKIACME) DeepCopy() *PKIACME {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIACME)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIACMEStatus) DeepCopyInto(out *PKIACMEStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIACMEStatus.
+func (in *PKIACMEStatus) DeepCopy() *PKIACMEStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIACMEStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKICAStatus) DeepCopyInto(out *PKICAStatus) {
+	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.IssuerChain != nil {
+		in, out := &in.IssuerChain, &out.IssuerChain
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKICAStatus.
+func (in *PKICAStatus) DeepCopy() *PKICAStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PKICAStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKICommon) DeepCopyInto(out *PKICommon) {
+	*out = *in
+	out.TTL = in.TTL
+	if in.PermittedDnsDomains != nil {
+		in, out := &in.PermittedDnsDomains, &out.PermittedDnsDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKICommon.
+func (in *PKICommon) DeepCopy() *PKICommon {
+	if in == nil {
+		return nil
+	}
+	out := new(PKICommon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIConfig) DeepCopyInto(out *PKIConfig) {
+	*out = *in
+	in.PKIConfigUrls.DeepCopyInto(&out.PKIConfigUrls)
+	out.PKIConfigCRL = in.PKIConfigCRL
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIConfig.
+func (in *PKIConfig) DeepCopy() *PKIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4733,6 +5290,21 @@ func (in *PKIConfigCRL) DeepCopy() *PKIConfigCRL {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIConfigCluster) DeepCopyInto(out *PKIConfigCluster) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIConfigCluster.
+func (in *PKIConfigCluster) DeepCopy() *PKIConfigCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIConfigCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PKIConfigUrls) DeepCopyInto(out *PKIConfigUrls) {
 	*out = *in
@@ -4764,80 +5336,739 @@ func (in *PKIConfigUrls) DeepCopy() *PKIConfigUrls {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKIIntermediate) DeepCopyInto(out *PKIIntermediate) {
+func (in *PKIEABSecret) DeepCopyInto(out *PKIEABSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIEABSecret.
+func (in *PKIEABSecret) DeepCopy() *PKIEABSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIEABSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIExportedSecret) DeepCopyInto(out *PKIExportedSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIExportedSecret.
+func (in *PKIExportedSecret) DeepCopy() *PKIExportedSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIExportedSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIExportedSecretStatus) DeepCopyInto(out *PKIExportedSecretStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIExportedSecretStatus.
+func (in *PKIExportedSecretStatus) DeepCopy() *PKIExportedSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIExportedSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIImport) DeepCopyInto(out *PKIImport) {
+	*out = *in
+	out.Secret = in.Secret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIImport.
+func (in *PKIImport) DeepCopy() *PKIImport {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIIntermediate) DeepCopyInto(out *PKIIntermediate) {
+	*out = *in
+	if in.ExternalSignSecret != nil {
+		in, out := &in.ExternalSignSecret, &out.ExternalSignSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.InternalSign != nil {
+		in, out := &in.InternalSign, &out.InternalSign
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIIntermediate.
+func (in *PKIIntermediate) DeepCopy() *PKIIntermediate {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIIntermediate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIIssuerRotation) DeepCopyInto(out *PKIIssuerRotation) {
+	*out = *in
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIIssuerRotation.
+func (in *PKIIssuerRotation) DeepCopy() *PKIIssuerRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIIssuerRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIIssuerStatus) DeepCopyInto(out *PKIIssuerStatus) {
+	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIIssuerStatus.
+func (in *PKIIssuerStatus) DeepCopy() *PKIIssuerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIIssuerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIIssuers) DeepCopyInto(out *PKIIssuers) {
+	*out = *in
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(PKIIssuerRotation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIIssuers.
+func (in *PKIIssuers) DeepCopy() *PKIIssuers {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIIssuers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIRole) DeepCopyInto(out *PKIRole) {
+	*out = *in
+	out.TTL = in.TTL
+	out.MaxTTL = in.MaxTTL
+	if in.AllowedDomains != nil {
+		in, out := &in.AllowedDomains, &out.AllowedDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedURISans != nil {
+		in, out := &in.AllowedURISans, &out.AllowedURISans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeyUsage != nil {
+		in, out := &in.KeyUsage, &out.KeyUsage
+		*out = make([]KeyUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtKeyUsage != nil {
+		in, out := &in.ExtKeyUsage, &out.ExtKeyUsage
+		*out = make([]ExtKeyUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtKeyUsageOids != nil {
+		in, out := &in.ExtKeyUsageOids, &out.ExtKeyUsageOids
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyIdentifiers != nil {
+		in, out := &in.PolicyIdentifiers, &out.PolicyIdentifiers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.NotBeforeDuration = in.NotBeforeDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIRole.
+func (in *PKIRole) DeepCopy() *PKIRole {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKISecretEngineConfig) DeepCopyInto(out *PKISecretEngineConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineConfig.
+func (in *PKISecretEngineConfig) DeepCopy() *PKISecretEngineConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PKISecretEngineConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PKISecretEngineConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKISecretEngineConfigList) DeepCopyInto(out *PKISecretEngineConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PKISecretEngineConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineConfigList.
+func (in *PKISecretEngineConfigList) DeepCopy() *PKISecretEngineConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(PKISecretEngineConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PKISecretEngineConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKISecretEngineConfigSpec) DeepCopyInto(out *PKISecretEngineConfigSpec) {
+	*out = *in
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(utils.VaultConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Authentication.DeepCopyInto(&out.Authentication)
+	out.PKIType = in.PKIType
+	in.PKICommon.DeepCopyInto(&out.PKICommon)
+	in.PKIConfig.DeepCopyInto(&out.PKIConfig)
+	in.PKIIntermediate.DeepCopyInto(&out.PKIIntermediate)
+	if in.Issuers != nil {
+		in, out := &in.Issuers, &out.Issuers
+		*out = new(PKIIssuers)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpiringSoonThreshold != nil {
+		in, out := &in.ExpiringSoonThreshold, &out.ExpiringSoonThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Tidy != nil {
+		in, out := &in.Tidy, &out.Tidy
+		*out = new(PKITidy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(PKIConfigCluster)
+		**out = **in
+	}
+	if in.ACME != nil {
+		in, out := &in.ACME, &out.ACME
+		*out = new(PKIACME)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExportedSecret != nil {
+		in, out := &in.ExportedSecret, &out.ExportedSecret
+		*out = new(PKIExportedSecret)
+		**out = **in
+	}
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(PKIImport)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineConfigSpec.
+func (in *PKISecretEngineConfigSpec) DeepCopy() *PKISecretEngineConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PKISecretEngineConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKISecretEngineConfigStatus) DeepCopyInto(out *PKISecretEngineConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Issuers != nil {
+		in, out := &in.Issuers, &out.Issuers
+		*out = make([]PKIIssuerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(PKICAStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tidy != nil {
+		in, out := &in.Tidy, &out.Tidy
+		*out = new(PKITidyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ACME != nil {
+		in, out := &in.ACME, &out.ACME
+		*out = new(PKIACMEStatus)
+		**out = **in
+	}
+	if in.ExportedSecret != nil {
+		in, out := &in.ExportedSecret, &out.ExportedSecret
+		*out = new(PKIExportedSecretStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineConfigStatus.
+func (in *PKISecretEngineConfigStatus) DeepCopy() *PKISecretEngineConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PKISecretEngineConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKISecretEngineRole) DeepCopyInto(out *PKISecretEngineRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineRole.
+func (in *PKISecretEngineRole) DeepCopy() *PKISecretEngineRole {
+	if in == nil {
+		return nil
+	}
+	out := new(PKISecretEngineRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PKISecretEngineRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKISecretEngineRoleList) DeepCopyInto(out *PKISecretEngineRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PKISecretEngineRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineRoleList.
+func (in *PKISecretEngineRoleList) DeepCopy() *PKISecretEngineRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(PKISecretEngineRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PKISecretEngineRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKISecretEngineRoleSpec) DeepCopyInto(out *PKISecretEngineRoleSpec) {
+	*out = *in
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(utils.VaultConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Authentication.DeepCopyInto(&out.Authentication)
+	in.PKIRole.DeepCopyInto(&out.PKIRole)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineRoleSpec.
+func (in *PKISecretEngineRoleSpec) DeepCopy() *PKISecretEngineRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PKISecretEngineRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKISecretEngineRoleStatus) DeepCopyInto(out *PKISecretEngineRoleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineRoleStatus.
+func (in *PKISecretEngineRoleStatus) DeepCopy() *PKISecretEngineRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PKISecretEngineRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKITidy) DeepCopyInto(out *PKITidy) {
+	*out = *in
+	if in.SafetyBuffer != nil {
+		in, out := &in.SafetyBuffer, &out.SafetyBuffer
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.IssuerSafetyBuffer != nil {
+		in, out := &in.IssuerSafetyBuffer, &out.IssuerSafetyBuffer
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKITidy.
+func (in *PKITidy) DeepCopy() *PKITidy {
+	if in == nil {
+		return nil
+	}
+	out := new(PKITidy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKITidyStatus) DeepCopyInto(out *PKITidyStatus) {
+	*out = *in
+	if in.TimeStarted != nil {
+		in, out := &in.TimeStarted, &out.TimeStarted
+		*out = (*in).DeepCopy()
+	}
+	if in.TimeFinished != nil {
+		in, out := &in.TimeFinished, &out.TimeFinished
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKITidyStatus.
+func (in *PKITidyStatus) DeepCopy() *PKITidyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PKITidyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PKIType) DeepCopyInto(out *PKIType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIType.
+func (in *PKIType) DeepCopy() *PKIType {
+	if in == nil {
+		return nil
+	}
+	out := new(PKIType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordCredentialConfig) DeepCopyInto(out *PasswordCredentialConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordCredentialConfig.
+func (in *PasswordCredentialConfig) DeepCopy() *PasswordCredentialConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordCredentialConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicy.
+func (in *PasswordPolicy) DeepCopy() *PasswordPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PasswordPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicyFormat) DeepCopyInto(out *PasswordPolicyFormat) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PasswordPolicyRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicyFormat.
+func (in *PasswordPolicyFormat) DeepCopy() *PasswordPolicyFormat {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicyFormat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicyList) DeepCopyInto(out *PasswordPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PasswordPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicyList.
+func (in *PasswordPolicyList) DeepCopy() *PasswordPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PasswordPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicyRule) DeepCopyInto(out *PasswordPolicyRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicyRule.
+func (in *PasswordPolicyRule) DeepCopy() *PasswordPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicySpec) DeepCopyInto(out *PasswordPolicySpec) {
+	*out = *in
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(utils.VaultConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Authentication.DeepCopyInto(&out.Authentication)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicySpec.
+func (in *PasswordPolicySpec) DeepCopy() *PasswordPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicyStatus) DeepCopyInto(out *PasswordPolicyStatus) {
 	*out = *in
-	if in.ExternalSignSecret != nil {
-		in, out := &in.ExternalSignSecret, &out.ExternalSignSecret
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.InternalSign != nil {
-		in, out := &in.InternalSign, &out.InternalSign
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIIntermediate.
-func (in *PKIIntermediate) DeepCopy() *PKIIntermediate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicyStatus.
+func (in *PasswordPolicyStatus) DeepCopy() *PasswordPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PKIIntermediate)
+	out := new(PasswordPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKIRole) DeepCopyInto(out *PKIRole) {
+func (in *PermissionSet) DeepCopyInto(out *PermissionSet) {
 	*out = *in
-	out.TTL = in.TTL
-	out.MaxTTL = in.MaxTTL
-	if in.AllowedDomains != nil {
-		in, out := &in.AllowedDomains, &out.AllowedDomains
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.AllowedURISans != nil {
-		in, out := &in.AllowedURISans, &out.AllowedURISans
+	if in.RepositoriesIDs != nil {
+		in, out := &in.RepositoriesIDs, &out.RepositoriesIDs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.KeyUsage != nil {
-		in, out := &in.KeyUsage, &out.KeyUsage
-		*out = make([]KeyUsage, len(*in))
-		copy(*out, *in)
-	}
-	if in.ExtKeyUsage != nil {
-		in, out := &in.ExtKeyUsage, &out.ExtKeyUsage
-		*out = make([]ExtKeyUsage, len(*in))
-		copy(*out, *in)
-	}
-	if in.ExtKeyUsageOids != nil {
-		in, out := &in.ExtKeyUsageOids, &out.ExtKeyUsageOids
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.PolicyIdentifiers != nil {
-		in, out := &in.PolicyIdentifiers, &out.PolicyIdentifiers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionSet.
+func (in *PermissionSet) DeepCopy() *PermissionSet {
+	if in == nil {
+		return nil
 	}
-	out.NotBeforeDuration = in.NotBeforeDuration
+	out := new(PermissionSet)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIRole.
-func (in *PKIRole) DeepCopy() *PKIRole {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingIDMFAConfig) DeepCopyInto(out *PingIDMFAConfig) {
+	*out = *in
+	out.SettingsFileSecret = in.SettingsFileSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingIDMFAConfig.
+func (in *PingIDMFAConfig) DeepCopy() *PingIDMFAConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PKIRole)
+	out := new(PingIDMFAConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKISecretEngineConfig) DeepCopyInto(out *PKISecretEngineConfig) {
+func (in *Policy) DeepCopyInto(out *Policy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -4845,18 +6076,18 @@ func (in *PKISecretEngineConfig) DeepCopyInto(out *PKISecretEngineConfig) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineConfig.
-func (in *PKISecretEngineConfig) DeepCopy() *PKISecretEngineConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Policy.
+func (in *Policy) DeepCopy() *Policy {
 	if in == nil {
 		return nil
 	}
-	out := new(PKISecretEngineConfig)
+	out := new(Policy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PKISecretEngineConfig) DeepCopyObject() runtime.Object {
+func (in *Policy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4864,31 +6095,31 @@ func (in *PKISecretEngineConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKISecretEngineConfigList) DeepCopyInto(out *PKISecretEngineConfigList) {
+func (in *PolicyList) DeepCopyInto(out *PolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PKISecretEngineConfig, len(*in))
+		*out = make([]Policy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineConfigList.
-func (in *PKISecretEngineConfigList) DeepCopy() *PKISecretEngineConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyList.
+func (in *PolicyList) DeepCopy() *PolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(PKISecretEngineConfigList)
+	out := new(PolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PKISecretEngineConfigList) DeepCopyObject() runtime.Object {
+func (in *PolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4896,7 +6127,7 @@ func (in *PKISecretEngineConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKISecretEngineConfigSpec) DeepCopyInto(out *PKISecretEngineConfigSpec) {
+func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
 	*out = *in
 	if in.Connection != nil {
 		in, out := &in.Connection, &out.Connection
@@ -4904,24 +6135,39 @@ func (in *PKISecretEngineConfigSpec) DeepCopyInto(out *PKISecretEngineConfigSpec
 		(*in).DeepCopyInto(*out)
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
-	out.PKIType = in.PKIType
-	in.PKICommon.DeepCopyInto(&out.PKICommon)
-	in.PKIConfig.DeepCopyInto(&out.PKIConfig)
-	in.PKIIntermediate.DeepCopyInto(&out.PKIIntermediate)
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]PolicyTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineConfigSpec.
-func (in *PKISecretEngineConfigSpec) DeepCopy() *PKISecretEngineConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySpec.
+func (in *PolicySpec) DeepCopy() *PolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PKISecretEngineConfigSpec)
+	out := new(PolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKISecretEngineConfigStatus) DeepCopyInto(out *PKISecretEngineConfigStatus) {
+func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -4930,20 +6176,160 @@ func (in *PKISecretEngineConfigStatus) DeepCopyInto(out *PKISecretEngineConfigSt
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TestResults != nil {
+		in, out := &in.TestResults, &out.TestResults
+		*out = make([]PolicyTestResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineConfigStatus.
-func (in *PKISecretEngineConfigStatus) DeepCopy() *PKISecretEngineConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyStatus.
+func (in *PolicyStatus) DeepCopy() *PolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PKISecretEngineConfigStatus)
+	out := new(PolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKISecretEngineRole) DeepCopyInto(out *PKISecretEngineRole) {
+func (in *PolicyTest) DeepCopyInto(out *PolicyTest) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTest.
+func (in *PolicyTest) DeepCopy() *PolicyTest {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTestResult) DeepCopyInto(out *PolicyTestResult) {
+	*out = *in
+	if in.ExpectedCapabilities != nil {
+		in, out := &in.ExpectedCapabilities, &out.ExpectedCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTestResult.
+func (in *PolicyTestResult) DeepCopy() *PolicyTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuayBaseRole) DeepCopyInto(out *QuayBaseRole) {
+	*out = *in
+	if in.CreateRepositories != nil {
+		in, out := &in.CreateRepositories, &out.CreateRepositories
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(map[string]TeamRole)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]TeamRole, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = new(map[string]Permission)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]Permission, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
+	}
+	if in.DefaultPermission != nil {
+		in, out := &in.DefaultPermission, &out.DefaultPermission
+		*out = new(Permission)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuayBaseRole.
+func (in *QuayBaseRole) DeepCopy() *QuayBaseRole {
+	if in == nil {
+		return nil
+	}
+	out := new(QuayBaseRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuayConfig) DeepCopyInto(out *QuayConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuayConfig.
+func (in *QuayConfig) DeepCopy() *QuayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(QuayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuayRole) DeepCopyInto(out *QuayRole) {
+	*out = *in
+	in.QuayBaseRole.DeepCopyInto(&out.QuayBaseRole)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxTTL != nil {
+		in, out := &in.MaxTTL, &out.MaxTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuayRole.
+func (in *QuayRole) DeepCopy() *QuayRole {
+	if in == nil {
+		return nil
+	}
+	out := new(QuayRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuaySecretEngineConfig) DeepCopyInto(out *QuaySecretEngineConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -4951,18 +6337,18 @@ func (in *PKISecretEngineRole) DeepCopyInto(out *PKISecretEngineRole) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineRole.
-func (in *PKISecretEngineRole) DeepCopy() *PKISecretEngineRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineConfig.
+func (in *QuaySecretEngineConfig) DeepCopy() *QuaySecretEngineConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PKISecretEngineRole)
+	out := new(QuaySecretEngineConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PKISecretEngineRole) DeepCopyObject() runtime.Object {
+func (in *QuaySecretEngineConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4970,31 +6356,31 @@ func (in *PKISecretEngineRole) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKISecretEngineRoleList) DeepCopyInto(out *PKISecretEngineRoleList) {
+func (in *QuaySecretEngineConfigList) DeepCopyInto(out *QuaySecretEngineConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PKISecretEngineRole, len(*in))
+		*out = make([]QuaySecretEngineConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineRoleList.
-func (in *PKISecretEngineRoleList) DeepCopy() *PKISecretEngineRoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineConfigList.
+func (in *QuaySecretEngineConfigList) DeepCopy() *QuaySecretEngineConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(PKISecretEngineRoleList)
+	out := new(QuaySecretEngineConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PKISecretEngineRoleList) DeepCopyObject() runtime.Object {
+func (in *QuaySecretEngineConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5002,7 +6388,7 @@ func (in *PKISecretEngineRoleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKISecretEngineRoleSpec) DeepCopyInto(out *PKISecretEngineRoleSpec) {
+func (in *QuaySecretEngineConfigSpec) DeepCopyInto(out *QuaySecretEngineConfigSpec) {
 	*out = *in
 	if in.Connection != nil {
 		in, out := &in.Connection, &out.Connection
@@ -5010,21 +6396,22 @@ func (in *PKISecretEngineRoleSpec) DeepCopyInto(out *PKISecretEngineRoleSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
-	in.PKIRole.DeepCopyInto(&out.PKIRole)
+	out.QuayConfig = in.QuayConfig
+	in.RootCredentials.DeepCopyInto(&out.RootCredentials)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineRoleSpec.
-func (in *PKISecretEngineRoleSpec) DeepCopy() *PKISecretEngineRoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineConfigSpec.
+func (in *QuaySecretEngineConfigSpec) DeepCopy() *QuaySecretEngineConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PKISecretEngineRoleSpec)
+	out := new(QuaySecretEngineConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKISecretEngineRoleStatus) DeepCopyInto(out *PKISecretEngineRoleStatus) {
+func (in *QuaySecretEngineConfigStatus) DeepCopyInto(out *QuaySecretEngineConfigStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -5035,48 +6422,18 @@ func (in *PKISecretEngineRoleStatus) DeepCopyInto(out *PKISecretEngineRoleStatus
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISecretEngineRoleStatus.
-func (in *PKISecretEngineRoleStatus) DeepCopy() *PKISecretEngineRoleStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(PKISecretEngineRoleStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PKIType) DeepCopyInto(out *PKIType) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKIType.
-func (in *PKIType) DeepCopy() *PKIType {
-	if in == nil {
-		return nil
-	}
-	out := new(PKIType)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordCredentialConfig) DeepCopyInto(out *PasswordCredentialConfig) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordCredentialConfig.
-func (in *PasswordCredentialConfig) DeepCopy() *PasswordCredentialConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineConfigStatus.
+func (in *QuaySecretEngineConfigStatus) DeepCopy() *QuaySecretEngineConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordCredentialConfig)
+	out := new(QuaySecretEngineConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
+func (in *QuaySecretEngineRole) DeepCopyInto(out *QuaySecretEngineRole) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -5084,18 +6441,18 @@ func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicy.
-func (in *PasswordPolicy) DeepCopy() *PasswordPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineRole.
+func (in *QuaySecretEngineRole) DeepCopy() *QuaySecretEngineRole {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordPolicy)
+	out := new(QuaySecretEngineRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PasswordPolicy) DeepCopyObject() runtime.Object {
+func (in *QuaySecretEngineRole) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5103,51 +6460,31 @@ func (in *PasswordPolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordPolicyFormat) DeepCopyInto(out *PasswordPolicyFormat) {
-	*out = *in
-	if in.Rules != nil {
-		in, out := &in.Rules, &out.Rules
-		*out = make([]PasswordPolicyRule, len(*in))
-		copy(*out, *in)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicyFormat.
-func (in *PasswordPolicyFormat) DeepCopy() *PasswordPolicyFormat {
-	if in == nil {
-		return nil
-	}
-	out := new(PasswordPolicyFormat)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordPolicyList) DeepCopyInto(out *PasswordPolicyList) {
+func (in *QuaySecretEngineRoleList) DeepCopyInto(out *QuaySecretEngineRoleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PasswordPolicy, len(*in))
+		*out = make([]QuaySecretEngineRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicyList.
-func (in *PasswordPolicyList) DeepCopy() *PasswordPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineRoleList.
+func (in *QuaySecretEngineRoleList) DeepCopy() *QuaySecretEngineRoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordPolicyList)
+	out := new(QuaySecretEngineRoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PasswordPolicyList) DeepCopyObject() runtime.Object {
+func (in *QuaySecretEngineRoleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5155,22 +6492,7 @@ func (in *PasswordPolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordPolicyRule) DeepCopyInto(out *PasswordPolicyRule) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicyRule.
-func (in *PasswordPolicyRule) DeepCopy() *PasswordPolicyRule {
-	if in == nil {
-		return nil
-	}
-	out := new(PasswordPolicyRule)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordPolicySpec) DeepCopyInto(out *PasswordPolicySpec) {
+func (in *QuaySecretEngineRoleSpec) DeepCopyInto(out *QuaySecretEngineRoleSpec) {
 	*out = *in
 	if in.Connection != nil {
 		in, out := &in.Connection, &out.Connection
@@ -5178,20 +6500,21 @@ func (in *PasswordPolicySpec) DeepCopyInto(out *PasswordPolicySpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
+	in.QuayRole.DeepCopyInto(&out.QuayRole)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicySpec.
-func (in *PasswordPolicySpec) DeepCopy() *PasswordPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineRoleSpec.
+func (in *QuaySecretEngineRoleSpec) DeepCopy() *QuaySecretEngineRoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordPolicySpec)
+	out := new(QuaySecretEngineRoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordPolicyStatus) DeepCopyInto(out *PasswordPolicyStatus) {
+func (in *QuaySecretEngineRoleStatus) DeepCopyInto(out *QuaySecretEngineRoleStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -5202,50 +6525,18 @@ func (in *PasswordPolicyStatus) DeepCopyInto(out *PasswordPolicyStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicyStatus.
-func (in *PasswordPolicyStatus) DeepCopy() *PasswordPolicyStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(PasswordPolicyStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PermissionSet) DeepCopyInto(out *PermissionSet) {
-	*out = *in
-	if in.Repositories != nil {
-		in, out := &in.Repositories, &out.Repositories
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.RepositoriesIDs != nil {
-		in, out := &in.RepositoriesIDs, &out.RepositoriesIDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Permissions != nil {
-		in, out := &in.Permissions, &out.Permissions
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionSet.
-func (in *PermissionSet) DeepCopy() *PermissionSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineRoleStatus.
+func (in *QuaySecretEngineRoleStatus) DeepCopy() *QuaySecretEngineRoleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PermissionSet)
+	out := new(QuaySecretEngineRoleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Policy) DeepCopyInto(out *Policy) {
+func (in *QuaySecretEngineStaticRole) DeepCopyInto(out *QuaySecretEngineStaticRole) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -5253,18 +6544,18 @@ func (in *Policy) DeepCopyInto(out *Policy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Policy.
-func (in *Policy) DeepCopy() *Policy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineStaticRole.
+func (in *QuaySecretEngineStaticRole) DeepCopy() *QuaySecretEngineStaticRole {
 	if in == nil {
 		return nil
 	}
-	out := new(Policy)
+	out := new(QuaySecretEngineStaticRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Policy) DeepCopyObject() runtime.Object {
+func (in *QuaySecretEngineStaticRole) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5272,31 +6563,31 @@ func (in *Policy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyList) DeepCopyInto(out *PolicyList) {
+func (in *QuaySecretEngineStaticRoleList) DeepCopyInto(out *QuaySecretEngineStaticRoleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Policy, len(*in))
+		*out = make([]QuaySecretEngineStaticRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyList.
-func (in *PolicyList) DeepCopy() *PolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineStaticRoleList.
+func (in *QuaySecretEngineStaticRoleList) DeepCopy() *QuaySecretEngineStaticRoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyList)
+	out := new(QuaySecretEngineStaticRoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PolicyList) DeepCopyObject() runtime.Object {
+func (in *QuaySecretEngineStaticRoleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5304,7 +6595,7 @@ func (in *PolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
+func (in *QuaySecretEngineStaticRoleSpec) DeepCopyInto(out *QuaySecretEngineStaticRoleSpec) {
 	*out = *in
 	if in.Connection != nil {
 		in, out := &in.Connection, &out.Connection
@@ -5312,130 +6603,100 @@ func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
+	in.QuayBaseRole.DeepCopyInto(&out.QuayBaseRole)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySpec.
-func (in *PolicySpec) DeepCopy() *PolicySpec {
-	if in == nil {
-		return nil
-	}
-	out := new(PolicySpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
-	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyStatus.
-func (in *PolicyStatus) DeepCopy() *PolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineStaticRoleSpec.
+func (in *QuaySecretEngineStaticRoleSpec) DeepCopy() *QuaySecretEngineStaticRoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyStatus)
+	out := new(QuaySecretEngineStaticRoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuayBaseRole) DeepCopyInto(out *QuayBaseRole) {
-	*out = *in
-	if in.CreateRepositories != nil {
-		in, out := &in.CreateRepositories, &out.CreateRepositories
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Teams != nil {
-		in, out := &in.Teams, &out.Teams
-		*out = new(map[string]TeamRole)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make(map[string]TeamRole, len(*in))
-			for key, val := range *in {
-				(*out)[key] = val
-			}
-		}
-	}
-	if in.Repositories != nil {
-		in, out := &in.Repositories, &out.Repositories
-		*out = new(map[string]Permission)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make(map[string]Permission, len(*in))
-			for key, val := range *in {
-				(*out)[key] = val
-			}
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuaySecretEngineStaticRoleStatus) DeepCopyInto(out *QuaySecretEngineStaticRoleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.DefaultPermission != nil {
-		in, out := &in.DefaultPermission, &out.DefaultPermission
-		*out = new(Permission)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuayBaseRole.
-func (in *QuayBaseRole) DeepCopy() *QuayBaseRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineStaticRoleStatus.
+func (in *QuaySecretEngineStaticRoleStatus) DeepCopy() *QuaySecretEngineStaticRoleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(QuayBaseRole)
+	out := new(QuaySecretEngineStaticRoleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuayConfig) DeepCopyInto(out *QuayConfig) {
+func (in *RMQSEConfig) DeepCopyInto(out *RMQSEConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuayConfig.
-func (in *QuayConfig) DeepCopy() *QuayConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RMQSEConfig.
+func (in *RMQSEConfig) DeepCopy() *RMQSEConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(QuayConfig)
+	out := new(RMQSEConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuayRole) DeepCopyInto(out *QuayRole) {
+func (in *RMQSERole) DeepCopyInto(out *RMQSERole) {
 	*out = *in
-	in.QuayBaseRole.DeepCopyInto(&out.QuayBaseRole)
-	if in.TTL != nil {
-		in, out := &in.TTL, &out.TTL
-		*out = new(v1.Duration)
-		**out = **in
+	if in.Vhosts != nil {
+		in, out := &in.Vhosts, &out.Vhosts
+		*out = make([]Vhost, len(*in))
+		copy(*out, *in)
 	}
-	if in.MaxTTL != nil {
-		in, out := &in.MaxTTL, &out.MaxTTL
-		*out = new(v1.Duration)
-		**out = **in
+	if in.VhostTopics != nil {
+		in, out := &in.VhostTopics, &out.VhostTopics
+		*out = make([]VhostTopic, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuayRole.
-func (in *QuayRole) DeepCopy() *QuayRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RMQSERole.
+func (in *RMQSERole) DeepCopy() *RMQSERole {
 	if in == nil {
 		return nil
 	}
-	out := new(QuayRole)
+	out := new(RMQSERole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineConfig) DeepCopyInto(out *QuaySecretEngineConfig) {
+func (in *RSAPrivateKeyCredentialConfig) DeepCopyInto(out *RSAPrivateKeyCredentialConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RSAPrivateKeyCredentialConfig.
+func (in *RSAPrivateKeyCredentialConfig) DeepCopy() *RSAPrivateKeyCredentialConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RSAPrivateKeyCredentialConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQSecretEngineConfig) DeepCopyInto(out *RabbitMQSecretEngineConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -5443,18 +6704,18 @@ func (in *QuaySecretEngineConfig) DeepCopyInto(out *QuaySecretEngineConfig) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineConfig.
-func (in *QuaySecretEngineConfig) DeepCopy() *QuaySecretEngineConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineConfig.
+func (in *RabbitMQSecretEngineConfig) DeepCopy() *RabbitMQSecretEngineConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineConfig)
+	out := new(RabbitMQSecretEngineConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *QuaySecretEngineConfig) DeepCopyObject() runtime.Object {
+func (in *RabbitMQSecretEngineConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5462,31 +6723,31 @@ func (in *QuaySecretEngineConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineConfigList) DeepCopyInto(out *QuaySecretEngineConfigList) {
+func (in *RabbitMQSecretEngineConfigList) DeepCopyInto(out *RabbitMQSecretEngineConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]QuaySecretEngineConfig, len(*in))
+		*out = make([]RabbitMQSecretEngineConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineConfigList.
-func (in *QuaySecretEngineConfigList) DeepCopy() *QuaySecretEngineConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineConfigList.
+func (in *RabbitMQSecretEngineConfigList) DeepCopy() *RabbitMQSecretEngineConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineConfigList)
+	out := new(RabbitMQSecretEngineConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *QuaySecretEngineConfigList) DeepCopyObject() runtime.Object {
+func (in *RabbitMQSecretEngineConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5494,7 +6755,7 @@ func (in *QuaySecretEngineConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineConfigSpec) DeepCopyInto(out *QuaySecretEngineConfigSpec) {
+func (in *RabbitMQSecretEngineConfigSpec) DeepCopyInto(out *RabbitMQSecretEngineConfigSpec) {
 	*out = *in
 	if in.Connection != nil {
 		in, out := &in.Connection, &out.Connection
@@ -5502,22 +6763,22 @@ func (in *QuaySecretEngineConfigSpec) DeepCopyInto(out *QuaySecretEngineConfigSp
 		(*in).DeepCopyInto(*out)
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
-	out.QuayConfig = in.QuayConfig
+	out.RMQSEConfig = in.RMQSEConfig
 	in.RootCredentials.DeepCopyInto(&out.RootCredentials)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineConfigSpec.
-func (in *QuaySecretEngineConfigSpec) DeepCopy() *QuaySecretEngineConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineConfigSpec.
+func (in *RabbitMQSecretEngineConfigSpec) DeepCopy() *RabbitMQSecretEngineConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineConfigSpec)
+	out := new(RabbitMQSecretEngineConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineConfigStatus) DeepCopyInto(out *QuaySecretEngineConfigStatus) {
+func (in *RabbitMQSecretEngineConfigStatus) DeepCopyInto(out *RabbitMQSecretEngineConfigStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -5528,18 +6789,18 @@ func (in *QuaySecretEngineConfigStatus) DeepCopyInto(out *QuaySecretEngineConfig
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineConfigStatus.
-func (in *QuaySecretEngineConfigStatus) DeepCopy() *QuaySecretEngineConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineConfigStatus.
+func (in *RabbitMQSecretEngineConfigStatus) DeepCopy() *RabbitMQSecretEngineConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineConfigStatus)
+	out := new(RabbitMQSecretEngineConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineRole) DeepCopyInto(out *QuaySecretEngineRole) {
+func (in *RabbitMQSecretEngineRole) DeepCopyInto(out *RabbitMQSecretEngineRole) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -5547,18 +6808,18 @@ func (in *QuaySecretEngineRole) DeepCopyInto(out *QuaySecretEngineRole) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineRole.
-func (in *QuaySecretEngineRole) DeepCopy() *QuaySecretEngineRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineRole.
+func (in *RabbitMQSecretEngineRole) DeepCopy() *RabbitMQSecretEngineRole {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineRole)
+	out := new(RabbitMQSecretEngineRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *QuaySecretEngineRole) DeepCopyObject() runtime.Object {
+func (in *RabbitMQSecretEngineRole) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5566,31 +6827,31 @@ func (in *QuaySecretEngineRole) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineRoleList) DeepCopyInto(out *QuaySecretEngineRoleList) {
+func (in *RabbitMQSecretEngineRoleList) DeepCopyInto(out *RabbitMQSecretEngineRoleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]QuaySecretEngineRole, len(*in))
+		*out = make([]RabbitMQSecretEngineRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineRoleList.
-func (in *QuaySecretEngineRoleList) DeepCopy() *QuaySecretEngineRoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineRoleList.
+func (in *RabbitMQSecretEngineRoleList) DeepCopy() *RabbitMQSecretEngineRoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineRoleList)
+	out := new(RabbitMQSecretEngineRoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *QuaySecretEngineRoleList) DeepCopyObject() runtime.Object {
+func (in *RabbitMQSecretEngineRoleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5598,7 +6859,7 @@ func (in *QuaySecretEngineRoleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineRoleSpec) DeepCopyInto(out *QuaySecretEngineRoleSpec) {
+func (in *RabbitMQSecretEngineRoleSpec) DeepCopyInto(out *RabbitMQSecretEngineRoleSpec) {
 	*out = *in
 	if in.Connection != nil {
 		in, out := &in.Connection, &out.Connection
@@ -5606,21 +6867,21 @@ func (in *QuaySecretEngineRoleSpec) DeepCopyInto(out *QuaySecretEngineRoleSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
-	in.QuayRole.DeepCopyInto(&out.QuayRole)
+	in.RMQSERole.DeepCopyInto(&out.RMQSERole)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineRoleSpec.
-func (in *QuaySecretEngineRoleSpec) DeepCopy() *QuaySecretEngineRoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineRoleSpec.
+func (in *RabbitMQSecretEngineRoleSpec) DeepCopy() *RabbitMQSecretEngineRoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineRoleSpec)
+	out := new(RabbitMQSecretEngineRoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineRoleStatus) DeepCopyInto(out *QuaySecretEngineRoleStatus) {
+func (in *RabbitMQSecretEngineRoleStatus) DeepCopyInto(out *RabbitMQSecretEngineRoleStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -5631,18 +6892,18 @@ func (in *QuaySecretEngineRoleStatus) DeepCopyInto(out *QuaySecretEngineRoleStat
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineRoleStatus.
-func (in *QuaySecretEngineRoleStatus) DeepCopy() *QuaySecretEngineRoleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineRoleStatus.
+func (in *RabbitMQSecretEngineRoleStatus) DeepCopy() *RabbitMQSecretEngineRoleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineRoleStatus)
+	out := new(RabbitMQSecretEngineRoleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineStaticRole) DeepCopyInto(out *QuaySecretEngineStaticRole) {
+func (in *RandomSecret) DeepCopyInto(out *RandomSecret) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -5650,18 +6911,18 @@ func (in *QuaySecretEngineStaticRole) DeepCopyInto(out *QuaySecretEngineStaticRo
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineStaticRole.
-func (in *QuaySecretEngineStaticRole) DeepCopy() *QuaySecretEngineStaticRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RandomSecret.
+func (in *RandomSecret) DeepCopy() *RandomSecret {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineStaticRole)
+	out := new(RandomSecret)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *QuaySecretEngineStaticRole) DeepCopyObject() runtime.Object {
+func (in *RandomSecret) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5669,31 +6930,31 @@ func (in *QuaySecretEngineStaticRole) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineStaticRoleList) DeepCopyInto(out *QuaySecretEngineStaticRoleList) {
+func (in *RandomSecretList) DeepCopyInto(out *RandomSecretList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]QuaySecretEngineStaticRole, len(*in))
+		*out = make([]RandomSecret, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineStaticRoleList.
-func (in *QuaySecretEngineStaticRoleList) DeepCopy() *QuaySecretEngineStaticRoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RandomSecretList.
+func (in *RandomSecretList) DeepCopy() *RandomSecretList {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineStaticRoleList)
+	out := new(RandomSecretList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *QuaySecretEngineStaticRoleList) DeepCopyObject() runtime.Object {
+func (in *RandomSecretList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5701,7 +6962,7 @@ func (in *QuaySecretEngineStaticRoleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineStaticRoleSpec) DeepCopyInto(out *QuaySecretEngineStaticRoleSpec) {
+func (in *RandomSecretSpec) DeepCopyInto(out *RandomSecretSpec) {
 	*out = *in
 	if in.Connection != nil {
 		in, out := &in.Connection, &out.Connection
@@ -5709,21 +6970,26 @@ func (in *QuaySecretEngineStaticRoleSpec) DeepCopyInto(out *QuaySecretEngineStat
 		(*in).DeepCopyInto(*out)
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
-	in.QuayBaseRole.DeepCopyInto(&out.QuayBaseRole)
+	out.SecretFormat = in.SecretFormat
+	if in.RefreshPeriod != nil {
+		in, out := &in.RefreshPeriod, &out.RefreshPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineStaticRoleSpec.
-func (in *QuaySecretEngineStaticRoleSpec) DeepCopy() *QuaySecretEngineStaticRoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RandomSecretSpec.
+func (in *RandomSecretSpec) DeepCopy() *RandomSecretSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineStaticRoleSpec)
+	out := new(RandomSecretSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuaySecretEngineStaticRoleStatus) DeepCopyInto(out *QuaySecretEngineStaticRoleStatus) {
+func (in *RandomSecretStatus) DeepCopyInto(out *RandomSecretStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -5732,77 +6998,85 @@ func (in *QuaySecretEngineStaticRoleStatus) DeepCopyInto(out *QuaySecretEngineSt
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastVaultSecretUpdate != nil {
+		in, out := &in.LastVaultSecretUpdate, &out.LastVaultSecretUpdate
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuaySecretEngineStaticRoleStatus.
-func (in *QuaySecretEngineStaticRoleStatus) DeepCopy() *QuaySecretEngineStaticRoleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RandomSecretStatus.
+func (in *RandomSecretStatus) DeepCopy() *RandomSecretStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(QuaySecretEngineStaticRoleStatus)
+	out := new(RandomSecretStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RMQSEConfig) DeepCopyInto(out *RMQSEConfig) {
+func (in *RolloutTarget) DeepCopyInto(out *RolloutTarget) {
 	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RMQSEConfig.
-func (in *RMQSEConfig) DeepCopy() *RMQSEConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutTarget.
+func (in *RolloutTarget) DeepCopy() *RolloutTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(RMQSEConfig)
+	out := new(RolloutTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RMQSERole) DeepCopyInto(out *RMQSERole) {
+func (in *RootPasswordRotation) DeepCopyInto(out *RootPasswordRotation) {
 	*out = *in
-	if in.Vhosts != nil {
-		in, out := &in.Vhosts, &out.Vhosts
-		*out = make([]Vhost, len(*in))
-		copy(*out, *in)
-	}
-	if in.VhostTopics != nil {
-		in, out := &in.VhostTopics, &out.VhostTopics
-		*out = make([]VhostTopic, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.RotationPeriod = in.RotationPeriod
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RMQSERole.
-func (in *RMQSERole) DeepCopy() *RMQSERole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootPasswordRotation.
+func (in *RootPasswordRotation) DeepCopy() *RootPasswordRotation {
 	if in == nil {
 		return nil
 	}
-	out := new(RMQSERole)
+	out := new(RootPasswordRotation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RSAPrivateKeyCredentialConfig) DeepCopyInto(out *RSAPrivateKeyCredentialConfig) {
+func (in *SSHKeyConfig) DeepCopyInto(out *SSHKeyConfig) {
 	*out = *in
+	if in.VaultSecret != nil {
+		in, out := &in.VaultSecret, &out.VaultSecret
+		*out = new(utils.VaultSecretReference)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RSAPrivateKeyCredentialConfig.
-func (in *RSAPrivateKeyCredentialConfig) DeepCopy() *RSAPrivateKeyCredentialConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeyConfig.
+func (in *SSHKeyConfig) DeepCopy() *SSHKeyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(RSAPrivateKeyCredentialConfig)
+	out := new(SSHKeyConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQSecretEngineConfig) DeepCopyInto(out *RabbitMQSecretEngineConfig) {
+func (in *SecretEngineMount) DeepCopyInto(out *SecretEngineMount) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -5810,18 +7084,18 @@ func (in *RabbitMQSecretEngineConfig) DeepCopyInto(out *RabbitMQSecretEngineConf
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineConfig.
-func (in *RabbitMQSecretEngineConfig) DeepCopy() *RabbitMQSecretEngineConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEngineMount.
+func (in *SecretEngineMount) DeepCopy() *SecretEngineMount {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQSecretEngineConfig)
+	out := new(SecretEngineMount)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMQSecretEngineConfig) DeepCopyObject() runtime.Object {
+func (in *SecretEngineMount) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5829,31 +7103,31 @@ func (in *RabbitMQSecretEngineConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQSecretEngineConfigList) DeepCopyInto(out *RabbitMQSecretEngineConfigList) {
+func (in *SecretEngineMountList) DeepCopyInto(out *SecretEngineMountList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RabbitMQSecretEngineConfig, len(*in))
+		*out = make([]SecretEngineMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineConfigList.
-func (in *RabbitMQSecretEngineConfigList) DeepCopy() *RabbitMQSecretEngineConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEngineMountList.
+func (in *SecretEngineMountList) DeepCopy() *SecretEngineMountList {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQSecretEngineConfigList)
+	out := new(SecretEngineMountList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMQSecretEngineConfigList) DeepCopyObject() runtime.Object {
+func (in *SecretEngineMountList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5861,7 +7135,7 @@ func (in *RabbitMQSecretEngineConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQSecretEngineConfigSpec) DeepCopyInto(out *RabbitMQSecretEngineConfigSpec) {
+func (in *SecretEngineMountSpec) DeepCopyInto(out *SecretEngineMountSpec) {
 	*out = *in
 	if in.Connection != nil {
 		in, out := &in.Connection, &out.Connection
@@ -5869,22 +7143,21 @@ func (in *RabbitMQSecretEngineConfigSpec) DeepCopyInto(out *RabbitMQSecretEngine
 		(*in).DeepCopyInto(*out)
 	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
-	out.RMQSEConfig = in.RMQSEConfig
-	in.RootCredentials.DeepCopyInto(&out.RootCredentials)
+	in.Mount.DeepCopyInto(&out.Mount)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineConfigSpec.
-func (in *RabbitMQSecretEngineConfigSpec) DeepCopy() *RabbitMQSecretEngineConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEngineMountSpec.
+func (in *SecretEngineMountSpec) DeepCopy() *SecretEngineMountSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQSecretEngineConfigSpec)
+	out := new(SecretEngineMountSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQSecretEngineConfigStatus) DeepCopyInto(out *RabbitMQSecretEngineConfigStatus) {
+func (in *SecretEngineMountStatus) DeepCopyInto(out *SecretEngineMountStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -5895,140 +7168,200 @@ func (in *RabbitMQSecretEngineConfigStatus) DeepCopyInto(out *RabbitMQSecretEngi
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineConfigStatus.
-func (in *RabbitMQSecretEngineConfigStatus) DeepCopy() *RabbitMQSecretEngineConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEngineMountStatus.
+func (in *SecretEngineMountStatus) DeepCopy() *SecretEngineMountStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQSecretEngineConfigStatus)
+	out := new(SecretEngineMountStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQSecretEngineRole) DeepCopyInto(out *RabbitMQSecretEngineRole) {
+func (in *ServiceAccountEntitiesConfig) DeepCopyInto(out *ServiceAccountEntitiesConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineRole.
-func (in *RabbitMQSecretEngineRole) DeepCopy() *RabbitMQSecretEngineRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountEntitiesConfig.
+func (in *ServiceAccountEntitiesConfig) DeepCopy() *ServiceAccountEntitiesConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQSecretEngineRole)
+	out := new(ServiceAccountEntitiesConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMQSecretEngineRole) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountEntity) DeepCopyInto(out *ServiceAccountEntity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountEntity.
+func (in *ServiceAccountEntity) DeepCopy() *ServiceAccountEntity {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ServiceAccountEntity)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQSecretEngineRoleList) DeepCopyInto(out *RabbitMQSecretEngineRoleList) {
+func (in *TOTPMFAConfig) DeepCopyInto(out *TOTPMFAConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]RabbitMQSecretEngineRole, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TOTPMFAConfig.
+func (in *TOTPMFAConfig) DeepCopy() *TOTPMFAConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TOTPMFAConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatizedK8sSecret) DeepCopyInto(out *TemplatizedK8sSecret) {
+	*out = *in
+	if in.StringData != nil {
+		in, out := &in.StringData, &out.StringData
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineRoleList.
-func (in *RabbitMQSecretEngineRoleList) DeepCopy() *RabbitMQSecretEngineRoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatizedK8sSecret.
+func (in *TemplatizedK8sSecret) DeepCopy() *TemplatizedK8sSecret {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQSecretEngineRoleList)
+	out := new(TemplatizedK8sSecret)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMQSecretEngineRoleList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Topic) DeepCopyInto(out *Topic) {
+	*out = *in
+	out.Permissions = in.Permissions
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Topic.
+func (in *Topic) DeepCopy() *Topic {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(Topic)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQSecretEngineRoleSpec) DeepCopyInto(out *RabbitMQSecretEngineRoleSpec) {
+func (in *VRole) DeepCopyInto(out *VRole) {
 	*out = *in
-	if in.Connection != nil {
-		in, out := &in.Connection, &out.Connection
-		*out = new(utils.VaultConnection)
-		(*in).DeepCopyInto(*out)
+	if in.TargetServiceAccounts != nil {
+		in, out := &in.TargetServiceAccounts, &out.TargetServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = new(string)
+		**out = **in
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenBoundCIDRs != nil {
+		in, out := &in.TokenBoundCIDRs, &out.TokenBoundCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.namespaces != nil {
+		in, out := &in.namespaces, &out.namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.Authentication.DeepCopyInto(&out.Authentication)
-	in.RMQSERole.DeepCopyInto(&out.RMQSERole)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineRoleSpec.
-func (in *RabbitMQSecretEngineRoleSpec) DeepCopy() *RabbitMQSecretEngineRoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRole.
+func (in *VRole) DeepCopy() *VRole {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQSecretEngineRoleSpec)
+	out := new(VRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQSecretEngineRoleStatus) DeepCopyInto(out *RabbitMQSecretEngineRoleStatus) {
+func (in *VaultPasswordPolicy) DeepCopyInto(out *VaultPasswordPolicy) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQSecretEngineRoleStatus.
-func (in *RabbitMQSecretEngineRoleStatus) DeepCopy() *RabbitMQSecretEngineRoleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPasswordPolicy.
+func (in *VaultPasswordPolicy) DeepCopy() *VaultPasswordPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQSecretEngineRoleStatus)
+	out := new(VaultPasswordPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RandomSecret) DeepCopyInto(out *RandomSecret) {
+func (in *VaultResourceTemplate) DeepCopyInto(out *VaultResourceTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RandomSecret.
-func (in *RandomSecret) DeepCopy() *RandomSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultResourceTemplate.
+func (in *VaultResourceTemplate) DeepCopy() *VaultResourceTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(RandomSecret)
+	out := new(VaultResourceTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RandomSecret) DeepCopyObject() runtime.Object {
+func (in *VaultResourceTemplate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6036,31 +7369,31 @@ func (in *RandomSecret) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RandomSecretList) DeepCopyInto(out *RandomSecretList) {
+func (in *VaultResourceTemplateList) DeepCopyInto(out *VaultResourceTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RandomSecret, len(*in))
+		*out = make([]VaultResourceTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RandomSecretList.
-func (in *RandomSecretList) DeepCopy() *RandomSecretList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultResourceTemplateList.
+func (in *VaultResourceTemplateList) DeepCopy() *VaultResourceTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(RandomSecretList)
+	out := new(VaultResourceTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RandomSecretList) DeepCopyObject() runtime.Object {
+func (in *VaultResourceTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6068,120 +7401,145 @@ func (in *RandomSecretList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RandomSecretSpec) DeepCopyInto(out *RandomSecretSpec) {
+func (in *VaultResourceTemplateParameter) DeepCopyInto(out *VaultResourceTemplateParameter) {
 	*out = *in
-	if in.Connection != nil {
-		in, out := &in.Connection, &out.Connection
-		*out = new(utils.VaultConnection)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Authentication.DeepCopyInto(&out.Authentication)
-	out.SecretFormat = in.SecretFormat
-	if in.RefreshPeriod != nil {
-		in, out := &in.RefreshPeriod, &out.RefreshPeriod
-		*out = new(v1.Duration)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultResourceTemplateParameter.
+func (in *VaultResourceTemplateParameter) DeepCopy() *VaultResourceTemplateParameter {
+	if in == nil {
+		return nil
 	}
+	out := new(VaultResourceTemplateParameter)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RandomSecretSpec.
-func (in *RandomSecretSpec) DeepCopy() *RandomSecretSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultResourceTemplateReference) DeepCopyInto(out *VaultResourceTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultResourceTemplateReference.
+func (in *VaultResourceTemplateReference) DeepCopy() *VaultResourceTemplateReference {
 	if in == nil {
 		return nil
 	}
-	out := new(RandomSecretSpec)
+	out := new(VaultResourceTemplateReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RandomSecretStatus) DeepCopyInto(out *RandomSecretStatus) {
+func (in *VaultResourceTemplateSpec) DeepCopyInto(out *VaultResourceTemplateSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]VaultResourceTemplateParameter, len(*in))
+		copy(*out, *in)
 	}
-	if in.LastVaultSecretUpdate != nil {
-		in, out := &in.LastVaultSecretUpdate, &out.LastVaultSecretUpdate
-		*out = (*in).DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultResourceTemplateSpec.
+func (in *VaultResourceTemplateSpec) DeepCopy() *VaultResourceTemplateSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(VaultResourceTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RandomSecretStatus.
-func (in *RandomSecretStatus) DeepCopy() *RandomSecretStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecret) DeepCopyInto(out *VaultSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecret.
+func (in *VaultSecret) DeepCopy() *VaultSecret {
 	if in == nil {
 		return nil
 	}
-	out := new(RandomSecretStatus)
+	out := new(VaultSecret)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RootPasswordRotation) DeepCopyInto(out *RootPasswordRotation) {
+func (in *VaultSecretDefinition) DeepCopyInto(out *VaultSecretDefinition) {
 	*out = *in
-	out.RotationPeriod = in.RotationPeriod
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(utils.VaultConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Authentication.DeepCopyInto(&out.Authentication)
+	if in.RequestPayload != nil {
+		in, out := &in.RequestPayload, &out.RequestPayload
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootPasswordRotation.
-func (in *RootPasswordRotation) DeepCopy() *RootPasswordRotation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretDefinition.
+func (in *VaultSecretDefinition) DeepCopy() *VaultSecretDefinition {
 	if in == nil {
 		return nil
 	}
-	out := new(RootPasswordRotation)
+	out := new(VaultSecretDefinition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SSHKeyConfig) DeepCopyInto(out *SSHKeyConfig) {
+func (in *VaultSecretDefinitionStatus) DeepCopyInto(out *VaultSecretDefinitionStatus) {
 	*out = *in
-	if in.VaultSecret != nil {
-		in, out := &in.VaultSecret, &out.VaultSecret
-		*out = new(utils.VaultSecretReference)
-		**out = **in
-	}
-	if in.Secret != nil {
-		in, out := &in.Secret, &out.Secret
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeyConfig.
-func (in *SSHKeyConfig) DeepCopy() *SSHKeyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretDefinitionStatus.
+func (in *VaultSecretDefinitionStatus) DeepCopy() *VaultSecretDefinitionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SSHKeyConfig)
+	out := new(VaultSecretDefinitionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretEngineMount) DeepCopyInto(out *SecretEngineMount) {
+func (in *VaultSecretGrant) DeepCopyInto(out *VaultSecretGrant) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEngineMount.
-func (in *SecretEngineMount) DeepCopy() *SecretEngineMount {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretGrant.
+func (in *VaultSecretGrant) DeepCopy() *VaultSecretGrant {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretEngineMount)
+	out := new(VaultSecretGrant)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecretEngineMount) DeepCopyObject() runtime.Object {
+func (in *VaultSecretGrant) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6189,31 +7547,31 @@ func (in *SecretEngineMount) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretEngineMountList) DeepCopyInto(out *SecretEngineMountList) {
+func (in *VaultSecretGrantList) DeepCopyInto(out *VaultSecretGrantList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SecretEngineMount, len(*in))
+		*out = make([]VaultSecretGrant, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEngineMountList.
-func (in *SecretEngineMountList) DeepCopy() *SecretEngineMountList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretGrantList.
+func (in *VaultSecretGrantList) DeepCopy() *VaultSecretGrantList {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretEngineMountList)
+	out := new(VaultSecretGrantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecretEngineMountList) DeepCopyObject() runtime.Object {
+func (in *VaultSecretGrantList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6221,51 +7579,59 @@ func (in *SecretEngineMountList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretEngineMountSpec) DeepCopyInto(out *SecretEngineMountSpec) {
+func (in *VaultSecretGrantSpec) DeepCopyInto(out *VaultSecretGrantSpec) {
 	*out = *in
-	if in.Connection != nil {
-		in, out := &in.Connection, &out.Connection
-		*out = new(utils.VaultConnection)
-		(*in).DeepCopyInto(*out)
+	if in.VaultSecrets != nil {
+		in, out := &in.VaultSecrets, &out.VaultSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.Authentication.DeepCopyInto(&out.Authentication)
-	in.Mount.DeepCopyInto(&out.Mount)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEngineMountSpec.
-func (in *SecretEngineMountSpec) DeepCopy() *SecretEngineMountSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretGrantSpec.
+func (in *VaultSecretGrantSpec) DeepCopy() *VaultSecretGrantSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretEngineMountSpec)
+	out := new(VaultSecretGrantSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretEngineMountStatus) DeepCopyInto(out *SecretEngineMountStatus) {
+func (in *VaultSecretList) DeepCopyInto(out *VaultSecretList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultSecret, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEngineMountStatus.
-func (in *SecretEngineMountStatus) DeepCopy() *SecretEngineMountStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretList.
+func (in *VaultSecretList) DeepCopy() *VaultSecretList {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretEngineMountStatus)
+	out := new(VaultSecretList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemplatizedK8sSecret) DeepCopyInto(out *TemplatizedK8sSecret) {
+func (in *VaultSecretOutput) DeepCopyInto(out *VaultSecretOutput) {
 	*out = *in
 	if in.StringData != nil {
 		in, out := &in.StringData, &out.StringData
@@ -6290,89 +7656,114 @@ func (in *TemplatizedK8sSecret) DeepCopyInto(out *TemplatizedK8sSecret) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatizedK8sSecret.
-func (in *TemplatizedK8sSecret) DeepCopy() *TemplatizedK8sSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretOutput.
+func (in *VaultSecretOutput) DeepCopy() *VaultSecretOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(TemplatizedK8sSecret)
+	out := new(VaultSecretOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Topic) DeepCopyInto(out *Topic) {
+func (in *VaultSecretSpec) DeepCopyInto(out *VaultSecretSpec) {
 	*out = *in
-	out.Permissions = in.Permissions
+	if in.RefreshPeriod != nil {
+		in, out := &in.RefreshPeriod, &out.RefreshPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.VaultSecretDefinitions != nil {
+		in, out := &in.VaultSecretDefinitions, &out.VaultSecretDefinitions
+		*out = make([]VaultSecretDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.TemplatizedK8sSecret.DeepCopyInto(&out.TemplatizedK8sSecret)
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]VaultSecretOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RolloutTargets != nil {
+		in, out := &in.RolloutTargets, &out.RolloutTargets
+		*out = make([]RolloutTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TargetNamespaces != nil {
+		in, out := &in.TargetNamespaces, &out.TargetNamespaces
+		*out = new(utils.TargetNamespaceConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Topic.
-func (in *Topic) DeepCopy() *Topic {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretSpec.
+func (in *VaultSecretSpec) DeepCopy() *VaultSecretSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Topic)
+	out := new(VaultSecretSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VRole) DeepCopyInto(out *VRole) {
+func (in *VaultSecretStatus) DeepCopyInto(out *VaultSecretStatus) {
 	*out = *in
-	if in.TargetServiceAccounts != nil {
-		in, out := &in.TargetServiceAccounts, &out.TargetServiceAccounts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Audience != nil {
-		in, out := &in.Audience, &out.Audience
-		*out = new(string)
-		**out = **in
+	if in.LastVaultSecretUpdate != nil {
+		in, out := &in.LastVaultSecretUpdate, &out.LastVaultSecretUpdate
+		*out = (*in).DeepCopy()
 	}
-	if in.Policies != nil {
-		in, out := &in.Policies, &out.Policies
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.NextVaultSecretUpdate != nil {
+		in, out := &in.NextVaultSecretUpdate, &out.NextVaultSecretUpdate
+		*out = (*in).DeepCopy()
 	}
-	if in.TokenBoundCIDRs != nil {
-		in, out := &in.TokenBoundCIDRs, &out.TokenBoundCIDRs
-		*out = make([]string, len(*in))
+	if in.LastLeaseRenewal != nil {
+		in, out := &in.LastLeaseRenewal, &out.LastLeaseRenewal
+		*out = (*in).DeepCopy()
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputReference, len(*in))
 		copy(*out, *in)
 	}
-	if in.namespaces != nil {
-		in, out := &in.namespaces, &out.namespaces
-		*out = make([]string, len(*in))
+	if in.CopiedOutputs != nil {
+		in, out := &in.CopiedOutputs, &out.CopiedOutputs
+		*out = make([]OutputReference, len(*in))
 		copy(*out, *in)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRole.
-func (in *VRole) DeepCopy() *VRole {
-	if in == nil {
-		return nil
+	if in.VaultSecretDefinitionsStatus != nil {
+		in, out := &in.VaultSecretDefinitionsStatus, &out.VaultSecretDefinitionsStatus
+		*out = make([]VaultSecretDefinitionStatus, len(*in))
+		copy(*out, *in)
 	}
-	out := new(VRole)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultPasswordPolicy) DeepCopyInto(out *VaultPasswordPolicy) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPasswordPolicy.
-func (in *VaultPasswordPolicy) DeepCopy() *VaultPasswordPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretStatus.
+func (in *VaultSecretStatus) DeepCopy() *VaultSecretStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultPasswordPolicy)
+	out := new(VaultSecretStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultSecret) DeepCopyInto(out *VaultSecret) {
+func (in *VaultTenant) DeepCopyInto(out *VaultTenant) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -6380,18 +7771,18 @@ func (in *VaultSecret) DeepCopyInto(out *VaultSecret) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecret.
-func (in *VaultSecret) DeepCopy() *VaultSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTenant.
+func (in *VaultTenant) DeepCopy() *VaultTenant {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultSecret)
+	out := new(VaultTenant)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VaultSecret) DeepCopyObject() runtime.Object {
+func (in *VaultTenant) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6399,74 +7790,46 @@ func (in *VaultSecret) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultSecretDefinition) DeepCopyInto(out *VaultSecretDefinition) {
-	*out = *in
-	if in.Connection != nil {
-		in, out := &in.Connection, &out.Connection
-		*out = new(utils.VaultConnection)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Authentication.DeepCopyInto(&out.Authentication)
-	if in.RequestPayload != nil {
-		in, out := &in.RequestPayload, &out.RequestPayload
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretDefinition.
-func (in *VaultSecretDefinition) DeepCopy() *VaultSecretDefinition {
-	if in == nil {
-		return nil
-	}
-	out := new(VaultSecretDefinition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultSecretDefinitionStatus) DeepCopyInto(out *VaultSecretDefinitionStatus) {
+func (in *VaultTenantChild) DeepCopyInto(out *VaultTenantChild) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretDefinitionStatus.
-func (in *VaultSecretDefinitionStatus) DeepCopy() *VaultSecretDefinitionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTenantChild.
+func (in *VaultTenantChild) DeepCopy() *VaultTenantChild {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultSecretDefinitionStatus)
+	out := new(VaultTenantChild)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultSecretList) DeepCopyInto(out *VaultSecretList) {
+func (in *VaultTenantList) DeepCopyInto(out *VaultTenantList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VaultSecret, len(*in))
+		*out = make([]VaultTenant, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretList.
-func (in *VaultSecretList) DeepCopy() *VaultSecretList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTenantList.
+func (in *VaultTenantList) DeepCopy() *VaultTenantList {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultSecretList)
+	out := new(VaultTenantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VaultSecretList) DeepCopyObject() runtime.Object {
+func (in *VaultTenantList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6474,35 +7837,30 @@ func (in *VaultSecretList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultSecretSpec) DeepCopyInto(out *VaultSecretSpec) {
+func (in *VaultTenantSpec) DeepCopyInto(out *VaultTenantSpec) {
 	*out = *in
-	if in.RefreshPeriod != nil {
-		in, out := &in.RefreshPeriod, &out.RefreshPeriod
-		*out = new(v1.Duration)
-		**out = **in
-	}
-	if in.VaultSecretDefinitions != nil {
-		in, out := &in.VaultSecretDefinitions, &out.VaultSecretDefinitions
-		*out = make([]VaultSecretDefinition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	out.TemplateRef = in.TemplateRef
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
-	in.TemplatizedK8sSecret.DeepCopyInto(&out.TemplatizedK8sSecret)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretSpec.
-func (in *VaultSecretSpec) DeepCopy() *VaultSecretSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTenantSpec.
+func (in *VaultTenantSpec) DeepCopy() *VaultTenantSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultSecretSpec)
+	out := new(VaultTenantSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultSecretStatus) DeepCopyInto(out *VaultSecretStatus) {
+func (in *VaultTenantStatus) DeepCopyInto(out *VaultTenantStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -6511,27 +7869,19 @@ func (in *VaultSecretStatus) DeepCopyInto(out *VaultSecretStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.LastVaultSecretUpdate != nil {
-		in, out := &in.LastVaultSecretUpdate, &out.LastVaultSecretUpdate
-		*out = (*in).DeepCopy()
-	}
-	if in.NextVaultSecretUpdate != nil {
-		in, out := &in.NextVaultSecretUpdate, &out.NextVaultSecretUpdate
-		*out = (*in).DeepCopy()
-	}
-	if in.VaultSecretDefinitionsStatus != nil {
-		in, out := &in.VaultSecretDefinitionsStatus, &out.VaultSecretDefinitionsStatus
-		*out = make([]VaultSecretDefinitionStatus, len(*in))
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]VaultTenantChild, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretStatus.
-func (in *VaultSecretStatus) DeepCopy() *VaultSecretStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTenantStatus.
+func (in *VaultTenantStatus) DeepCopy() *VaultTenantStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultSecretStatus)
+	out := new(VaultTenantStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -228,6 +228,10 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "VaultSecret")
 		os.Exit(1)
 	}
+	if err = (&controller.VaultTenantReconciler{ReconcilerBase: vaultresourcecontroller.NewFromManager(mgr, "VaultTenant")}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VaultTenant")
+		os.Exit(1)
+	}
 	if err = (&controller.PasswordPolicyReconciler{ReconcilerBase: vaultresourcecontroller.NewFromManager(mgr, "PasswordPolicy")}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PasswordPolicy")
 		os.Exit(1)
@@ -318,6 +322,14 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "GroupAlias")
 		os.Exit(1)
 	}
+	if err = (&controller.MFAMethodReconciler{ReconcilerBase: vaultresourcecontroller.NewFromManager(mgr, "MFAMethod")}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MFAMethod")
+		os.Exit(1)
+	}
+	if err = (&controller.MFALoginEnforcementReconciler{ReconcilerBase: vaultresourcecontroller.NewFromManager(mgr, "MFALoginEnforcement")}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MFALoginEnforcement")
+		os.Exit(1)
+	}
 
 	if err = (&controller.AuditReconciler{ReconcilerBase: vaultresourcecontroller.NewFromManager(mgr, "Audit")}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Audit")
@@ -562,6 +574,18 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Namespace")
 			os.Exit(1)
 		}
+		if err = (&redhatcopv1alpha1.VaultTenant{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "VaultTenant")
+			os.Exit(1)
+		}
+		if err = (&redhatcopv1alpha1.MFAMethod{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "MFAMethod")
+			os.Exit(1)
+		}
+		if err = (&redhatcopv1alpha1.MFALoginEnforcement{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "MFALoginEnforcement")
+			os.Exit(1)
+		}
 	}
 
 	//+kubebuilder:scaffold:builder
@@ -20,9 +20,14 @@ func prepareContext(ctx context.Context, r vaultresourcecontroller.ReconcilerBas
 	ctx = vaultutils.ContextWithKubeClient(ctx, r.GetClient())
 	ctx = vaultutils.ContextWithRestConfig(ctx, r.GetRestConfig())
 	ctx = vaultutils.ContextWithVaultConnection(ctx, VAR.GetVaultConnection())
-	vaultClient, err := VAR.GetKubeAuthConfiguration().GetVaultClient(ctx, VAR.GetNamespace())
+	kubeAuthConfiguration, err := VAR.GetKubeAuthConfiguration().WithInheritedNamespace(ctx, VAR.GetNamespace())
 	if err != nil {
-		rlog.Error(err, "unable to create vault client", "KubeAuthConfiguration", VAR.GetKubeAuthConfiguration(), "namespace", VAR.GetNamespace())
+		rlog.Error(err, "unable to retrieve the Vault namespace of", "namespace", VAR.GetNamespace())
+		return nil, err
+	}
+	vaultClient, err := kubeAuthConfiguration.GetVaultClient(ctx, VAR.GetNamespace())
+	if err != nil {
+		rlog.Error(err, "unable to create vault client", "KubeAuthConfiguration", kubeAuthConfiguration, "namespace", VAR.GetNamespace())
 		return nil, err
 	}
 	ctx = vaultutils.ContextWithVaultClient(ctx, vaultClient)
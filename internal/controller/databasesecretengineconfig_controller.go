@@ -139,6 +139,7 @@ func (r *DatabaseSecretEngineConfigReconciler) rotateRootPassword(ctx context.Co
 		return err
 	}
 	instance.Status.LastRootPasswordRotation = metav1.Now()
+	instance.SetRootCredentialsCondition()
 	err = r.GetClient().Status().Update(ctx, instance)
 	if err != nil {
 		return err
@@ -210,7 +211,7 @@ func (r *DatabaseSecretEngineConfigReconciler) SetupWithManager(mgr ctrl.Manager
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&redhatcopv1alpha1.DatabaseSecretEngineConfig{}, builder.WithPredicates(vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		For(&redhatcopv1alpha1.DatabaseSecretEngineConfig{}, builder.WithPredicates(predicate.Or[client.Object](vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate(), vaultresourcecontroller.NewAnnotationChangedPredicate(redhatcopv1alpha1.ReseedRootCredentialsAnnotation), vaultresourcecontroller.NewAnnotationChangedPredicate(redhatcopv1alpha1.VerifyConnectionAnnotation)))).
 		Watches(&corev1.Secret{
 			TypeMeta: metav1.TypeMeta{
 				Kind: "Secret",
@@ -268,8 +269,9 @@ func (r *DatabaseSecretEngineConfigReconciler) findApplicableBDSCForSecret(ctx c
 		r.Log.Error(err, "unable to retrieve the list of DatabaseSecretEngineConfig")
 		return nil, err
 	}
+	// once Vault rotated the root password, a change of the source is only pushed with the reseed annotation
 	for _, vr := range vrl.Items {
-		if vr.Spec.RootCredentials.Secret != nil && vr.Spec.RootCredentials.Secret.Name == secret.Name {
+		if vr.Spec.RootCredentials.Secret != nil && vr.Spec.RootCredentials.Secret.Name == secret.Name && !vr.IsRootPasswordRotatedByVault() {
 			result = append(result, vr)
 		}
 	}
@@ -285,8 +287,9 @@ func (r *DatabaseSecretEngineConfigReconciler) findApplicableDBSCForRandomSecret
 		r.Log.Error(err, "unable to retrieve the list of DatabaseSecretEngineConfig")
 		return nil, err
 	}
+	// once Vault rotated the root password, a change of the source is only pushed with the reseed annotation
 	for _, vr := range vrl.Items {
-		if vr.Spec.RootCredentials.RandomSecret != nil && vr.Spec.RootCredentials.RandomSecret.Name == randomSecret.Name {
+		if vr.Spec.RootCredentials.RandomSecret != nil && vr.Spec.RootCredentials.RandomSecret.Name == randomSecret.Name && !vr.IsRootPasswordRotatedByVault() {
 			result = append(result, vr)
 		}
 	}
@@ -18,17 +18,26 @@ package controller
 
 import (
 	"context"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	redhatcopv1alpha1 "github.com/redhat-cop/vault-config-operator/api/v1alpha1"
 	"github.com/redhat-cop/vault-config-operator/internal/controller/vaultresourcecontroller"
 )
 
+// staticCredentialsResyncDelay is how long after a rotation reported by Vault the credentials secret is synced, leaving time to the rotation queue of Vault, which is checked every 5 seconds.
+const staticCredentialsResyncDelay = 10 * time.Second
+
 // DatabaseSecretEngineStaticRoleReconciler reconciles a DatabaseSecretEngineStaticRole object
 type DatabaseSecretEngineStaticRoleReconciler struct {
 	vaultresourcecontroller.ReconcilerBase
@@ -37,6 +46,8 @@ type DatabaseSecretEngineStaticRoleReconciler struct {
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=databasesecretenginestaticroles,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=databasesecretenginestaticroles/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=databasesecretenginestaticroles/finalizers,verbs=update
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=databasesecretengineconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -65,12 +76,51 @@ func (r *DatabaseSecretEngineStaticRoleReconciler) Reconcile(ctx context.Context
 	}
 	vaultResource := vaultresourcecontroller.NewVaultResource(&r.ReconcilerBase, instance)
 
-	return vaultResource.Reconcile(ctx1, instance)
+	result, err := vaultResource.Reconcile(ctx1, instance)
+	// sync the credentials secret right after Vault rotates the credentials
+	if err == nil && instance.Spec.CredentialsSecret != nil && instance.Status.NextVaultRotation != nil {
+		resync := max(time.Until(instance.Status.NextVaultRotation.Time), 0) + staticCredentialsResyncDelay
+		if result.RequeueAfter == 0 || result.RequeueAfter > resync {
+			result.RequeueAfter = resync
+		}
+	}
+	return result, err
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DatabaseSecretEngineStaticRoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&redhatcopv1alpha1.DatabaseSecretEngineStaticRole{}, builder.WithPredicates(vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		For(&redhatcopv1alpha1.DatabaseSecretEngineStaticRole{}, builder.WithPredicates(predicate.Or[client.Object](vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate(), vaultresourcecontroller.NewAnnotationChangedPredicate(redhatcopv1alpha1.RotateAnnotation)))).
+		Owns(&corev1.Secret{}).
+		Watches(&redhatcopv1alpha1.DatabaseSecretEngineConfig{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findStaticRolesPublishingConfig(ctx, a)
+		}), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
+
+// findStaticRolesPublishingConfig returns the static roles of the namespace of the database connection that publish its connection URL to their credentials secret.
+func (r *DatabaseSecretEngineStaticRoleReconciler) findStaticRolesPublishingConfig(ctx context.Context, config client.Object) []reconcile.Request {
+	res := []reconcile.Request{}
+	dbConfig, ok := config.(*redhatcopv1alpha1.DatabaseSecretEngineConfig)
+	if !ok {
+		return res
+	}
+	rl := &redhatcopv1alpha1.DatabaseSecretEngineStaticRoleList{}
+	err := r.GetClient().List(ctx, rl, &client.ListOptions{Namespace: config.GetNamespace()})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of DatabaseSecretEngineStaticRoles")
+		return []reconcile.Request{}
+	}
+	for i := range rl.Items {
+		if rl.Items[i].Spec.CredentialsSecret == nil || rl.Items[i].GetConfigPath() != dbConfig.GetPath() {
+			continue
+		}
+		res = append(res, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      rl.Items[i].GetName(),
+				Namespace: rl.Items[i].GetNamespace(),
+			},
+		})
+	}
+	return res
+}
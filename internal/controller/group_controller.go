@@ -19,9 +19,15 @@ package controller
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -37,6 +43,9 @@ type GroupReconciler struct {
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=groups,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=groups/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=groups/finalizers,verbs=update
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=entities,verbs=get;list;watch
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=kubernetesauthengineroles,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces;serviceaccounts,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -76,7 +85,138 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *GroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// a resource may have stopped matching the member selectors, so the groups with a selector of its kind are reconciled regardless of its labels
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&redhatcopv1alpha1.Group{}, builder.WithPredicates(vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		Watches(&redhatcopv1alpha1.Entity{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findGroupsWithMemberSelector(ctx, a.GetNamespace(), func(selectors *redhatcopv1alpha1.GroupMemberSelectors) bool {
+				return selectors.Entities != nil
+			})
+		})).
+		Watches(&redhatcopv1alpha1.Group{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findGroupsWithMemberSelector(ctx, a.GetNamespace(), func(selectors *redhatcopv1alpha1.GroupMemberSelectors) bool {
+				return selectors.Groups != nil
+			})
+		})).
+		Watches(&redhatcopv1alpha1.KubernetesAuthEngineRole{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findGroupsWithMemberSelector(ctx, a.GetNamespace(), func(selectors *redhatcopv1alpha1.GroupMemberSelectors) bool {
+				return selectors.KubernetesAuthEngineRoles != nil
+			})
+		})).
+		Watches(&corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "Namespace",
+			},
+		}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findGroupsWithMemberSelector(ctx, "", func(selectors *redhatcopv1alpha1.GroupMemberSelectors) bool {
+				return selectors.KubernetesAuthEngineRoles != nil
+			})
+		})).
+		// a service account created or recreated with a new entity changes the members of the groups selecting it
+		Watches(&corev1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "ServiceAccount",
+			},
+		}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findGroupsSelectingServiceAccount(ctx, a.(*corev1.ServiceAccount))
+		})).
 		Complete(r)
 }
+
+// findGroupsSelectingServiceAccount returns the groups whose KubernetesAuthEngineRoles member selector selects the given service account.
+func (r *GroupReconciler) findGroupsSelectingServiceAccount(ctx context.Context, serviceAccount *corev1.ServiceAccount) []reconcile.Request {
+	res := []reconcile.Request{}
+	gl := &redhatcopv1alpha1.GroupList{}
+	err := r.GetClient().List(ctx, gl, &client.ListOptions{})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of Groups")
+		return []reconcile.Request{}
+	}
+	var namespace *corev1.Namespace
+	for i := range gl.Items {
+		group := &gl.Items[i]
+		if group.Spec.MemberSelectors == nil || group.Spec.MemberSelectors.KubernetesAuthEngineRoles == nil {
+			continue
+		}
+		if namespace == nil {
+			namespace = &corev1.Namespace{}
+			err := r.GetClient().Get(ctx, types.NamespacedName{Name: serviceAccount.Namespace}, namespace)
+			if err != nil {
+				// the groups are reconciled by the watch on the namespaces when a namespace is deleted
+				if !apierrors.IsNotFound(err) {
+					r.Log.Error(err, "unable to retrieve namespace", "namespace", serviceAccount.Namespace)
+				}
+				return []reconcile.Request{}
+			}
+		}
+		selects, err := r.selectsServiceAccount(ctx, group.Spec.MemberSelectors.KubernetesAuthEngineRoles, group.Namespace, serviceAccount, namespace)
+		if err != nil {
+			r.Log.Error(err, "unable to evaluate the member selector of group", "namespace", group.Namespace, "name", group.Name)
+			continue
+		}
+		if selects {
+			res = append(res, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      group.GetName(),
+					Namespace: group.GetNamespace(),
+				},
+			})
+		}
+	}
+	return res
+}
+
+// selectsServiceAccount returns whether a role of the given namespace selected by the selector binds the service account, in a namespace matching the namespace selector.
+func (r *GroupReconciler) selectsServiceAccount(ctx context.Context, selector *redhatcopv1alpha1.KubernetesAuthEngineRoleMemberSelector, groupNamespace string, serviceAccount *corev1.ServiceAccount, namespace *corev1.Namespace) (bool, error) {
+	if selector.NamespaceSelector != nil {
+		namespaceSelector, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+		if !namespaceSelector.Matches(labels.Set(namespace.GetLabels())) {
+			return false, nil
+		}
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector.Selector)
+	if err != nil {
+		return false, err
+	}
+	roleList := &redhatcopv1alpha1.KubernetesAuthEngineRoleList{}
+	err = r.GetClient().List(ctx, roleList, &client.ListOptions{Namespace: groupNamespace, LabelSelector: labelSelector})
+	if err != nil {
+		return false, err
+	}
+	for i := range roleList.Items {
+		binds, err := roleList.Items[i].BindsServiceAccount(serviceAccount, namespace)
+		if err != nil {
+			return false, err
+		}
+		if binds {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// findGroupsWithMemberSelector returns the groups of the given namespace, or of all namespaces if empty, with member selectors accepted by hasSelector.
+func (r *GroupReconciler) findGroupsWithMemberSelector(ctx context.Context, namespace string, hasSelector func(*redhatcopv1alpha1.GroupMemberSelectors) bool) []reconcile.Request {
+	res := []reconcile.Request{}
+	gl := &redhatcopv1alpha1.GroupList{}
+	err := r.GetClient().List(ctx, gl, &client.ListOptions{Namespace: namespace})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of Groups")
+		return []reconcile.Request{}
+	}
+	for i := range gl.Items {
+		if gl.Items[i].Spec.MemberSelectors == nil || !hasSelector(gl.Items[i].Spec.MemberSelectors) {
+			continue
+		}
+		res = append(res, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      gl.Items[i].GetName(),
+				Namespace: gl.Items[i].GetNamespace(),
+			},
+		})
+	}
+	return res
+}
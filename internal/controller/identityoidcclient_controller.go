@@ -19,9 +19,13 @@ package controller
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -37,6 +41,8 @@ type IdentityOIDCClientReconciler struct {
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=identityoidcclients,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=identityoidcclients/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=identityoidcclients/finalizers,verbs=update
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=identityoidcproviders,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
 
 func (r *IdentityOIDCClientReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
@@ -65,5 +71,32 @@ func (r *IdentityOIDCClientReconciler) Reconcile(ctx context.Context, req ctrl.R
 func (r *IdentityOIDCClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&redhatcopv1alpha1.IdentityOIDCClient{}, builder.WithPredicates(vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		Owns(&corev1.Secret{}).
+		Watches(&redhatcopv1alpha1.IdentityOIDCProvider{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findClientsPublishingProvider(ctx, a)
+		})).
 		Complete(r)
 }
+
+// findClientsPublishingProvider returns the clients of the namespace of the provider that publish its issuer to their credentials secret.
+func (r *IdentityOIDCClientReconciler) findClientsPublishingProvider(ctx context.Context, provider client.Object) []reconcile.Request {
+	res := []reconcile.Request{}
+	cl := &redhatcopv1alpha1.IdentityOIDCClientList{}
+	err := r.GetClient().List(ctx, cl, &client.ListOptions{Namespace: provider.GetNamespace()})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of IdentityOIDCClients")
+		return []reconcile.Request{}
+	}
+	for i := range cl.Items {
+		if cl.Items[i].Spec.CredentialsSecret == nil || cl.Items[i].Spec.CredentialsSecret.Provider != provider.GetName() {
+			continue
+		}
+		res = append(res, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      cl.Items[i].GetName(),
+				Namespace: cl.Items[i].GetNamespace(),
+			},
+		})
+	}
+	return res
+}
@@ -45,6 +45,7 @@ type KubernetesAuthEngineRoleReconciler struct {
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=kubernetesauthengineroles/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -109,6 +110,28 @@ func (r *KubernetesAuthEngineRoleReconciler) SetupWithManager(mgr ctrl.Manager)
 			}
 			return res
 		})).
+		Watches(&corev1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "ServiceAccount",
+			},
+		}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			res := []reconcile.Request{}
+			sa := a.(*corev1.ServiceAccount)
+			ncl, err := r.findKubernetesAuthEngineRolesWithServiceAccountEntities(ctx, sa)
+			if err != nil {
+				r.Log.Error(err, "unable to find applicable kubernetesAuthEngineRoles for service account", "namespace", sa.Namespace, "name", sa.Name)
+				return []reconcile.Request{}
+			}
+			for _, kubernetesAuthEngineRole := range ncl {
+				res = append(res, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      kubernetesAuthEngineRole.GetName(),
+						Namespace: kubernetesAuthEngineRole.GetNamespace(),
+					},
+				})
+			}
+			return res
+		})).
 		Complete(r)
 }
 
@@ -135,3 +158,37 @@ func (r *KubernetesAuthEngineRoleReconciler) findApplicableKubernetesAuthEngineR
 	}
 	return result, nil
 }
+
+// findKubernetesAuthEngineRolesWithServiceAccountEntities returns the roles managing the entities of their service accounts that bind the given service account.
+func (r *KubernetesAuthEngineRoleReconciler) findKubernetesAuthEngineRolesWithServiceAccountEntities(ctx context.Context, serviceAccount *corev1.ServiceAccount) ([]redhatcopv1alpha1.KubernetesAuthEngineRole, error) {
+	result := []redhatcopv1alpha1.KubernetesAuthEngineRole{}
+	vrl := &redhatcopv1alpha1.KubernetesAuthEngineRoleList{}
+	err := r.GetClient().List(ctx, vrl, &client.ListOptions{})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of KubernetesAuthEngineRoles")
+		return []redhatcopv1alpha1.KubernetesAuthEngineRole{}, err
+	}
+	var namespace *corev1.Namespace
+	for _, vr := range vrl.Items {
+		if vr.Spec.ServiceAccountEntities == nil {
+			continue
+		}
+		if vr.Spec.TargetNamespaces.TargetNamespaceSelector != nil && namespace == nil {
+			namespace = &corev1.Namespace{}
+			err := r.GetClient().Get(ctx, types.NamespacedName{Name: serviceAccount.Namespace}, namespace)
+			if err != nil {
+				r.Log.Error(err, "unable to retrieve namespace", "namespace", serviceAccount.Namespace)
+				return []redhatcopv1alpha1.KubernetesAuthEngineRole{}, err
+			}
+		}
+		binds, err := vr.BindsServiceAccount(serviceAccount, namespace)
+		if err != nil {
+			r.Log.Error(err, "unable to create selector from label selector", "selector", vr.Spec.TargetNamespaces.TargetNamespaceSelector)
+			return []redhatcopv1alpha1.KubernetesAuthEngineRole{}, err
+		}
+		if binds {
+			result = append(result, vr)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/vault-config-operator/api/v1alpha1"
+)
+
+var (
+	pkiCAExpiryTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vault_config_operator_pki_ca_expiry_timestamp_seconds",
+		Help: "Expiry of the CA certificate of a PKISecretEngineConfig, in seconds since the epoch.",
+	}, []string{"namespace", "name", "path"})
+
+	pkiCAExpiringSoon = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vault_config_operator_pki_ca_expiring_soon",
+		Help: "1 when the CA certificate of a PKISecretEngineConfig expires within its expiring soon threshold or has expired, 0 otherwise.",
+	}, []string{"namespace", "name", "path"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(pkiCAExpiryTimestamp, pkiCAExpiringSoon)
+}
+
+// recordPKICAMetrics exports the expiry of the CA certificate recorded in the status of the PKISecretEngineConfig.
+func recordPKICAMetrics(instance *redhatcopv1alpha1.PKISecretEngineConfig) {
+	deletePKICAMetrics(types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name})
+	if instance.Status.CA == nil || instance.Status.CA.NotAfter == nil {
+		return
+	}
+	labels := prometheus.Labels{"namespace": instance.Namespace, "name": instance.Name, "path": instance.GetPath()}
+	pkiCAExpiryTimestamp.With(labels).Set(float64(instance.Status.CA.NotAfter.Unix()))
+	expiringSoon := 0.0
+	if apimeta.IsStatusConditionTrue(instance.Status.Conditions, redhatcopv1alpha1.CAExpiringSoon) {
+		expiringSoon = 1
+	}
+	pkiCAExpiringSoon.With(labels).Set(expiringSoon)
+}
+
+// deletePKICAMetrics removes the series of the PKISecretEngineConfig, whatever its path.
+func deletePKICAMetrics(name types.NamespacedName) {
+	labels := prometheus.Labels{"namespace": name.Namespace, "name": name.Name}
+	pkiCAExpiryTimestamp.DeletePartialMatch(labels)
+	pkiCAExpiringSoon.DeletePartialMatch(labels)
+}
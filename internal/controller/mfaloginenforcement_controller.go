@@ -0,0 +1,122 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/vault-config-operator/api/v1alpha1"
+	"github.com/redhat-cop/vault-config-operator/internal/controller/vaultresourcecontroller"
+)
+
+// MFALoginEnforcementReconciler reconciles a MFALoginEnforcement object
+type MFALoginEnforcementReconciler struct {
+	vaultresourcecontroller.ReconcilerBase
+}
+
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=mfaloginenforcements,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=mfaloginenforcements/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=mfaloginenforcements/finalizers,verbs=update
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=mfamethods;authenginemounts;groups;entities,verbs=get;list;watch
+
+func (r *MFALoginEnforcementReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = log.FromContext(ctx)
+
+	// Fetch the instance
+	instance := &redhatcopv1alpha1.MFALoginEnforcement{}
+	err := r.GetClient().Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	ctx1, err := prepareContext(ctx, r.ReconcilerBase, instance)
+	if err != nil {
+		r.Log.Error(err, "unable to prepare context", "instance", instance)
+		return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
+	}
+	vaultResource := vaultresourcecontroller.NewVaultResource(&r.ReconcilerBase, instance)
+
+	return vaultResource.Reconcile(ctx1, instance)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MFALoginEnforcementReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the referenced resources get their Vault identifiers after their creation, so the enforcements referencing them are reconciled again when they change
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&redhatcopv1alpha1.MFALoginEnforcement{}, builder.WithPredicates(vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		Watches(&redhatcopv1alpha1.MFAMethod{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findMFALoginEnforcementsReferencing(ctx, a, func(spec *redhatcopv1alpha1.MFALoginEnforcementSpec) []string {
+				return spec.MFAMethods
+			})
+		})).
+		Watches(&redhatcopv1alpha1.AuthEngineMount{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findMFALoginEnforcementsReferencing(ctx, a, func(spec *redhatcopv1alpha1.MFALoginEnforcementSpec) []string {
+				return spec.AuthEngineMounts
+			})
+		})).
+		Watches(&redhatcopv1alpha1.Group{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findMFALoginEnforcementsReferencing(ctx, a, func(spec *redhatcopv1alpha1.MFALoginEnforcementSpec) []string {
+				return spec.Groups
+			})
+		})).
+		Watches(&redhatcopv1alpha1.Entity{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			return r.findMFALoginEnforcementsReferencing(ctx, a, func(spec *redhatcopv1alpha1.MFALoginEnforcementSpec) []string {
+				return spec.Entities
+			})
+		})).
+		Complete(r)
+}
+
+// findMFALoginEnforcementsReferencing returns the enforcements of the namespace of the object whose references, returned by getReferences, include the object.
+func (r *MFALoginEnforcementReconciler) findMFALoginEnforcementsReferencing(ctx context.Context, object client.Object, getReferences func(*redhatcopv1alpha1.MFALoginEnforcementSpec) []string) []reconcile.Request {
+	res := []reconcile.Request{}
+	el := &redhatcopv1alpha1.MFALoginEnforcementList{}
+	err := r.GetClient().List(ctx, el, &client.ListOptions{Namespace: object.GetNamespace()})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of MFALoginEnforcements")
+		return []reconcile.Request{}
+	}
+	for i := range el.Items {
+		if !slices.Contains(getReferences(&el.Items[i].Spec), object.GetName()) {
+			continue
+		}
+		res = append(res, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      el.Items[i].GetName(),
+				Namespace: el.Items[i].GetNamespace(),
+			},
+		})
+	}
+	return res
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/vault-config-operator/api/v1alpha1"
+	"github.com/redhat-cop/vault-config-operator/internal/controller/vaultresourcecontroller"
+)
+
+// MFAMethodReconciler reconciles a MFAMethod object
+type MFAMethodReconciler struct {
+	vaultresourcecontroller.ReconcilerBase
+}
+
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=mfamethods,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=mfamethods/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=mfamethods/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *MFAMethodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = log.FromContext(ctx)
+
+	// Fetch the instance
+	instance := &redhatcopv1alpha1.MFAMethod{}
+	err := r.GetClient().Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	ctx1, err := prepareContext(ctx, r.ReconcilerBase, instance)
+	if err != nil {
+		r.Log.Error(err, "unable to prepare context", "instance", instance)
+		return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
+	}
+	vaultResource := vaultresourcecontroller.NewVaultResource(&r.ReconcilerBase, instance)
+
+	return vaultResource.Reconcile(ctx1, instance)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MFAMethodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+
+	// this will filter secrets whose data did not change.
+	isUpdatedSecret := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			newSecret, ok := e.ObjectNew.(*corev1.Secret)
+			if !ok {
+				return false
+			}
+			oldSecret, ok := e.ObjectOld.(*corev1.Secret)
+			if !ok {
+				return true
+			}
+			return !reflect.DeepEqual(oldSecret.Data, newSecret.Data)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&redhatcopv1alpha1.MFAMethod{}, builder.WithPredicates(vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		Watches(&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "Secret",
+			},
+		}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			res := []reconcile.Request{}
+			s := a.(*corev1.Secret)
+			methods, err := r.findApplicableMFAMethodsForSecret(ctx, s)
+			if err != nil {
+				r.Log.Error(err, "unable to find applicable MFAMethods for namespace", "namespace", s.Namespace)
+				return []reconcile.Request{}
+			}
+			for _, method := range methods {
+				res = append(res, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      method.GetName(),
+						Namespace: method.GetNamespace(),
+					},
+				})
+			}
+			return res
+		}), builder.WithPredicates(isUpdatedSecret)).
+		Complete(r)
+}
+
+func (r *MFAMethodReconciler) findApplicableMFAMethodsForSecret(ctx context.Context, secret *corev1.Secret) ([]redhatcopv1alpha1.MFAMethod, error) {
+	result := []redhatcopv1alpha1.MFAMethod{}
+	vrl := &redhatcopv1alpha1.MFAMethodList{}
+	err := r.GetClient().List(ctx, vrl, &client.ListOptions{
+		Namespace: secret.Namespace,
+	})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of MFAMethod")
+		return nil, err
+	}
+	for _, vr := range vrl.Items {
+		if vr.GetCredentialsSecretName() == secret.Name {
+			result = append(result, vr)
+		}
+	}
+	return result, nil
+}
@@ -19,14 +19,17 @@ package controller
 import (
 	"context"
 
-	vault "github.com/hashicorp/vault/api"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	redhatcopv1alpha1 "github.com/redhat-cop/vault-config-operator/api/v1alpha1"
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
 	"github.com/redhat-cop/vault-config-operator/internal/controller/vaultresourcecontroller"
 )
 
@@ -74,16 +77,49 @@ func (r *NamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	// Auth namespace and parent namespace are can be set differently
 	// You can be authenticated in mynamespace but create namespace under mynamespace/somechildnamespace
-	vaultClient := ctx1.Value("vaultClient").(*vault.Client)
-	vaultClient.WithNamespace(string(instance.Spec.Path))
-	ctx2 := context.WithValue(ctx1, "vaultClient", vaultClient) //nolint:staticcheck // SA1029+SA4017: pre-existing bug — uses string key instead of typed VaultClientKey, and ignores WithNamespace return value. Fix tracked in Story R1.1.
+	parentPath, err := instance.GetParentPath(ctx1)
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the parent namespace", "instance", instance)
+		return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
+	}
+	vaultClient := vaultutils.VaultClientFromContext(ctx1)
+	if parentPath != vaultClient.Namespace() {
+		ctx1 = vaultutils.ContextWithVaultClient(ctx1, vaultClient.WithNamespace(parentPath))
+	}
 
-	return vaultResource.Reconcile(ctx2, instance)
+	return vaultResource.Reconcile(ctx1, instance)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *NamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&redhatcopv1alpha1.Namespace{}, builder.WithPredicates(vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		Watches(&redhatcopv1alpha1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.findChildNamespaces)).
 		Complete(r)
 }
+
+// findChildNamespaces returns the namespaces whose parent is the given namespace, so that they are created once the path of their parent is known.
+func (r *NamespaceReconciler) findChildNamespaces(ctx context.Context, obj client.Object) []reconcile.Request {
+	parent, ok := obj.(*redhatcopv1alpha1.Namespace)
+	if !ok || parent.Status.Path == "" {
+		return []reconcile.Request{}
+	}
+	res := []reconcile.Request{}
+	nl := &redhatcopv1alpha1.NamespaceList{}
+	err := r.GetClient().List(ctx, nl, &client.ListOptions{})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of Namespaces")
+		return []reconcile.Request{}
+	}
+	for i := range nl.Items {
+		if nl.Items[i].IsChildOf(parent) {
+			res = append(res, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      nl.Items[i].GetName(),
+					Namespace: nl.Items[i].GetNamespace(),
+				},
+			})
+		}
+	}
+	return res
+}
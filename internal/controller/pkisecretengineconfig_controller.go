@@ -18,17 +18,24 @@ package controller
 
 import (
 	"context"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	redhatcopv1alpha1 "github.com/redhat-cop/vault-config-operator/api/v1alpha1"
 	"github.com/redhat-cop/vault-config-operator/internal/controller/vaultresourcecontroller"
 )
 
+// tidyStatusPollInterval is how often the status of a running tidy is read back from Vault.
+const tidyStatusPollInterval = 30 * time.Second
+
 // PKISecretEngineConfigReconciler reconciles a PKISecretEngineConfig object
 type PKISecretEngineConfigReconciler struct {
 	vaultresourcecontroller.ReconcilerBase
@@ -37,7 +44,7 @@ type PKISecretEngineConfigReconciler struct {
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=pkisecretengineconfigs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=pkisecretengineconfigs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=pkisecretengineconfigs/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
 //+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;patch
 
@@ -57,6 +64,7 @@ func (r *PKISecretEngineConfigReconciler) Reconcile(ctx context.Context, req ctr
 	err := r.GetClient().Get(ctx, req.NamespacedName, instance)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			deletePKICAMetrics(req.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
@@ -69,12 +77,27 @@ func (r *PKISecretEngineConfigReconciler) Reconcile(ctx context.Context, req ctr
 	}
 	vaultResource := vaultresourcecontroller.NewVaultPKIEngineResource(&r.ReconcilerBase, instance)
 
-	return vaultResource.Reconcile(ctx1, instance)
+	result, err := vaultResource.Reconcile(ctx1, instance)
+	recordPKICAMetrics(instance)
+	// re-evaluate the CAExpiringSoon condition and its metric when the CA certificate enters the expiring soon threshold or expires,
+	// and rotate the default issuer when it enters renewBefore
+	if next := instance.GetNextExpiryCheck(time.Now()); err == nil && next != nil {
+		resync := max(time.Until(*next), time.Second)
+		if result.RequeueAfter == 0 || result.RequeueAfter > resync {
+			result.RequeueAfter = resync
+		}
+	}
+	// follow a running tidy more closely than the sync period, so that its results are reported when it finishes
+	if err == nil && instance.IsTidyRunning() && (result.RequeueAfter == 0 || result.RequeueAfter > tidyStatusPollInterval) {
+		result.RequeueAfter = tidyStatusPollInterval
+	}
+	return result, err
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PKISecretEngineConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&redhatcopv1alpha1.PKISecretEngineConfig{}, builder.WithPredicates(vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		For(&redhatcopv1alpha1.PKISecretEngineConfig{}, builder.WithPredicates(predicate.Or[client.Object](vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate(), vaultresourcecontroller.NewAnnotationChangedPredicate(redhatcopv1alpha1.TidyAnnotation)))).
+		Owns(&corev1.Secret{}).
 		Complete(r)
 }
@@ -22,8 +22,11 @@ import (
 	redhatcopv1alpha1 "github.com/redhat-cop/vault-config-operator/api/v1alpha1"
 	"github.com/redhat-cop/vault-config-operator/internal/controller/vaultresourcecontroller"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -35,6 +38,8 @@ type PolicyReconciler struct {
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=policies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=policies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=policies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=secretenginemounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=groups,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
 //+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;patch
@@ -78,5 +83,29 @@ func (r *PolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 func (r *PolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&redhatcopv1alpha1.Policy{}, builder.WithPredicates(vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		Watches(&redhatcopv1alpha1.SecretEngineMount{}, handler.EnqueueRequestsFromMapFunc(r.findReferencingPolicies)).
+		Watches(&redhatcopv1alpha1.Group{}, handler.EnqueueRequestsFromMapFunc(r.findReferencingPolicies)).
 		Complete(r)
 }
+
+// findReferencingPolicies returns the policies with a placeholder referencing the given object, so that they are reconciled when it changes.
+func (r *PolicyReconciler) findReferencingPolicies(ctx context.Context, obj client.Object) []reconcile.Request {
+	res := []reconcile.Request{}
+	pl := &redhatcopv1alpha1.PolicyList{}
+	err := r.GetClient().List(ctx, pl, &client.ListOptions{Namespace: obj.GetNamespace()})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of Policies")
+		return []reconcile.Request{}
+	}
+	for i := range pl.Items {
+		if pl.Items[i].References(obj) {
+			res = append(res, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pl.Items[i].GetName(),
+					Namespace: pl.Items[i].GetNamespace(),
+				},
+			})
+		}
+	}
+	return res
+}
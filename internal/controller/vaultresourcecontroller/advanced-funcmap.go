@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"text/template"
@@ -55,9 +56,13 @@ func AdvancedTemplateFuncMap(config *rest.Config, logger logr.Logger) template.F
 		"fromJson":      fromJSON,
 		"fromJsonArray": fromJSONArray,
 
-		// A variety of known templating functions that have not been implemented yet
-		"include":  func(string, any) string { return "not implemented" },
-		"tpl":      func(string, any) any { return "not implemented" },
+		// include and tpl need the template being executed, they are wired by ParseTemplate
+		"include": func(string, any) (string, error) {
+			return "", errors.New("include is only available in templates parsed with ParseTemplate")
+		},
+		"tpl": func(string, any) (string, error) {
+			return "", errors.New("tpl is only available in templates parsed with ParseTemplate")
+		},
 		"required": func(string, any) (any, error) { return "not implemented", nil },
 	}
 
@@ -83,6 +88,59 @@ func AdvancedTemplateFuncMap(config *rest.Config, logger logr.Logger) template.F
 	return f
 }
 
+// recursionMaxNums is the maximum nesting of include and tpl calls, it stops templates that include themselves.
+const recursionMaxNums = 1000
+
+// ParseTemplate parses a template with the given functions and wires the include and tpl functions to it.
+// include executes a named template defined with define and returns the result as a string, so that it can be piped.
+// tpl renders a string as a template, with access to the named templates.
+func ParseTemplate(name string, text string, funcMap template.FuncMap) (*template.Template, error) {
+	t := template.New(name)
+	f := template.FuncMap{}
+	for k, v := range funcMap {
+		f[k] = v
+	}
+
+	includedNames := map[string]int{}
+	f["include"] = func(templateName string, data any) (string, error) {
+		if includedNames[templateName] >= recursionMaxNums {
+			return "", fmt.Errorf("rendering template has a nested reference name: %s: unable to execute template", templateName)
+		}
+		includedNames[templateName]++
+		defer func() { includedNames[templateName]-- }()
+		var buf strings.Builder
+		if err := t.ExecuteTemplate(&buf, templateName, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
+	tplDepth := 0
+	f["tpl"] = func(text string, data any) (string, error) {
+		if tplDepth >= recursionMaxNums {
+			return "", errors.New("rendering template has too many nested tpl calls: unable to execute template")
+		}
+		tplDepth++
+		defer func() { tplDepth-- }()
+		c, err := t.Clone()
+		if err != nil {
+			return "", err
+		}
+		tplName := fmt.Sprintf("%s-tpl-%d", name, tplDepth)
+		c, err = c.New(tplName).Parse(text)
+		if err != nil {
+			return "", fmt.Errorf("cannot parse template %q: %w", text, err)
+		}
+		var buf strings.Builder
+		if err := c.ExecuteTemplate(&buf, tplName, data); err != nil {
+			return "", fmt.Errorf("error during tpl function execution for %q: %w", text, err)
+		}
+		return buf.String(), nil
+	}
+
+	return t.Funcs(f).Parse(text)
+}
+
 // toYAML takes an interface, marshals it to yaml, and returns a string. It will
 // always return a string, even on marshal error (empty string).
 //
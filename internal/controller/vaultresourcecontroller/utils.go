@@ -231,6 +231,21 @@ func (p PeriodicReconcilePredicate) Update(e event.UpdateEvent) bool {
 	return e.ObjectNew.GetGeneration() != e.ObjectOld.GetGeneration()
 }
 
+// NewAnnotationChangedPredicate reconciles the objects whose value of the annotation changed, for the annotations requesting an operation.
+func NewAnnotationChangedPredicate(annotation string) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return e.ObjectNew.GetAnnotations()[annotation] != e.ObjectOld.GetAnnotations()[annotation]
+		},
+	}
+}
+
 // CreateOrUpdateResource creates a resource if it doesn't exist, and updates (overwrites it), if it exist
 // if owner is not nil, the owner field os set
 // if namespace is not "", the namespace field of the object is overwritten with the passed value
@@ -0,0 +1,397 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vaultresourcecontroller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// VaultContextResolver returns the context carrying the authenticated vault client of the vault secret definition with the given name.
+type VaultContextResolver func(definition string) (context.Context, bool)
+
+// VaultTemplateFuncMap returns the templating functions that call Vault, reusing the authenticated clients of the vault secret definitions,
+// and the functions that manipulate the certificates and keys returned by Vault.
+func VaultTemplateFuncMap(resolver VaultContextResolver) template.FuncMap {
+	return template.FuncMap{
+		"vaultRead":     NewVaultReadFunction(resolver),
+		"vaultWrite":    NewVaultWriteFunction(resolver),
+		"pkcs12":        toPKCS12,
+		"pkcs12Trust":   toPKCS12TrustStore,
+		"jks":           toJKS,
+		"jksTrust":      toJKSTrustStore,
+		"x509":          parseX509,
+		"x509Chain":     parseX509Chain,
+		"splitPEM":      splitPEM,
+		"pemPrivateKey": pemPrivateKey,
+	}
+}
+
+// NewVaultReadFunction reads a path in Vault at rendering time with the authenticated client of the given vault secret definition.
+// As for the vault secret definitions, the data of KV v2 secrets is unwrapped.
+func NewVaultReadFunction(resolver VaultContextResolver) func(definition string, path string) (map[string]any, error) {
+	return func(definition string, path string) (map[string]any, error) {
+		ctx, ok := resolver(definition)
+		if !ok {
+			return nil, fmt.Errorf("vaultRead: unknown vault secret definition %s", definition)
+		}
+		secret, found, err := vaultutils.ReadSecret(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("vaultRead: secret not found at path %s", path)
+		}
+		return unwrapKV2Data(secret.Data), nil
+	}
+}
+
+// NewVaultWriteFunction writes a payload to a path in Vault at rendering time with the authenticated client of the given vault secret definition,
+// which allows for example to issue a certificate or to derive a value with the transit engine. The request is sent at every rendering.
+func NewVaultWriteFunction(resolver VaultContextResolver) func(definition string, path string, payload map[string]any) (map[string]any, error) {
+	return func(definition string, path string, payload map[string]any) (map[string]any, error) {
+		ctx, ok := resolver(definition)
+		if !ok {
+			return nil, fmt.Errorf("vaultWrite: unknown vault secret definition %s", definition)
+		}
+		vaultClient := vaultutils.VaultClientFromContext(ctx)
+		secret, err := vaultClient.Logical().WriteWithContext(ctx, path, payload)
+		if err != nil {
+			return nil, err
+		}
+		if secret == nil || secret.Data == nil {
+			return nil, fmt.Errorf("vaultWrite: no data returned at path %s", path)
+		}
+		return unwrapKV2Data(secret.Data), nil
+	}
+}
+
+func unwrapKV2Data(data map[string]any) map[string]any {
+	if kv2Data, ok := data["data"].(map[string]any); ok {
+		return kv2Data
+	}
+	return data
+}
+
+// splitPEM splits a PEM bundle into its blocks, each returned PEM encoded.
+// The bundle can also be base64 encoded, as it is the case for the data of a K8s Secret retrieved with lookup.
+func splitPEM(bundle any) ([]string, error) {
+	blocks, err := decodePEMBlocks(bundle)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		result = append(result, string(pem.EncodeToMemory(block)))
+	}
+	return result, nil
+}
+
+// pemPrivateKey returns the first private key block of a PEM bundle.
+func pemPrivateKey(bundle any) (string, error) {
+	blocks, err := decodePEMBlocks(bundle)
+	if err != nil {
+		return "", err
+	}
+	for _, block := range blocks {
+		if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			return string(pem.EncodeToMemory(block)), nil
+		}
+	}
+	return "", errors.New("pemPrivateKey: no private key found")
+}
+
+// parseX509 parses the first certificate of a PEM bundle and returns its main fields.
+func parseX509(bundle any) (map[string]any, error) {
+	certificates, err := parseCertificates(bundle)
+	if err != nil {
+		return nil, err
+	}
+	if len(certificates) == 0 {
+		return nil, errors.New("x509: no certificate found")
+	}
+	return certificateToMap(certificates[0]), nil
+}
+
+// parseX509Chain parses all the certificates of a PEM bundle and returns their main fields.
+func parseX509Chain(bundle any) ([]map[string]any, error) {
+	certificates, err := parseCertificates(bundle)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]map[string]any, 0, len(certificates))
+	for _, certificate := range certificates {
+		result = append(result, certificateToMap(certificate))
+	}
+	return result, nil
+}
+
+// toPKCS12 encodes a private key, its certificate and the CA chain in a PKCS#12 keystore protected by password.
+// The CA chain can be a PEM bundle or a list of PEM certificates, as returned by the Vault PKI engine in ca_chain.
+func toPKCS12(privateKey string, certificate string, caChain any, password string) (string, error) {
+	key, cert, caCerts, err := parseKeyPair(privateKey, certificate, caChain)
+	if err != nil {
+		return "", err
+	}
+	pfxData, err := pkcs12.Modern.WithRand(deterministicReader(privateKey, certificate, fmt.Sprint(caChain), password)).Encode(key, cert, caCerts, password)
+	if err != nil {
+		return "", err
+	}
+	return string(pfxData), nil
+}
+
+// toPKCS12TrustStore encodes the certificates of a PEM bundle in a PKCS#12 truststore protected by password.
+func toPKCS12TrustStore(caChain any, password string) (string, error) {
+	caCerts, err := parseCertificates(caChain)
+	if err != nil {
+		return "", err
+	}
+	pfxData, err := pkcs12.Modern.WithRand(deterministicReader(fmt.Sprint(caChain), password)).EncodeTrustStore(caCerts, password)
+	if err != nil {
+		return "", err
+	}
+	return string(pfxData), nil
+}
+
+// toJKS encodes a private key, its certificate and the CA chain in a Java keystore under the given alias, protected by password.
+// The creation time of the entry is the NotBefore of the certificate, so that the same input always produces the same keystore.
+func toJKS(alias string, privateKey string, certificate string, caChain any, password string) (string, error) {
+	key, cert, caCerts, err := parseKeyPair(privateKey, certificate, caChain)
+	if err != nil {
+		return "", err
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	chain := []keystore.Certificate{{Type: "X509", Content: cert.Raw}}
+	for _, caCert := range caCerts {
+		chain = append(chain, keystore.Certificate{Type: "X509", Content: caCert.Raw})
+	}
+	ks := keystore.New(keystore.WithCustomRandomNumberGenerator(deterministicReader(alias, privateKey, certificate, fmt.Sprint(caChain), password)))
+	err = ks.SetPrivateKeyEntry(alias, keystore.PrivateKeyEntry{
+		CreationTime:     cert.NotBefore,
+		PrivateKey:       keyDER,
+		CertificateChain: chain,
+	}, []byte(password))
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	err = ks.Store(&b, []byte(password))
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// toJKSTrustStore encodes the certificates of a PEM bundle in a Java truststore protected by password.
+// The certificates are stored under the aliases ca-0, ca-1, ...
+func toJKSTrustStore(caChain any, password string) (string, error) {
+	caCerts, err := parseCertificates(caChain)
+	if err != nil {
+		return "", err
+	}
+	ks := keystore.New(keystore.WithOrderedAliases())
+	for i, caCert := range caCerts {
+		err = ks.SetTrustedCertificateEntry(fmt.Sprintf("ca-%d", i), keystore.TrustedCertificateEntry{
+			CreationTime: caCert.NotBefore,
+			Certificate:  keystore.Certificate{Type: "X509", Content: caCert.Raw},
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	var b bytes.Buffer
+	err = ks.Store(&b, []byte(password))
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func parseKeyPair(privateKey string, certificate string, caChain any) (any, *x509.Certificate, []*x509.Certificate, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	certs, err := parseCertificates(certificate)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(certs) == 0 {
+		return nil, nil, nil, errors.New("no certificate found")
+	}
+	caCerts, err := parseCertificates(caChain)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return key, certs[0], caCerts, nil
+}
+
+func parsePrivateKey(privateKey string) (any, error) {
+	blocks, err := decodePEMBlocks(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range blocks {
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			return x509.ParsePKCS8PrivateKey(block.Bytes)
+		}
+	}
+	return nil, errors.New("no private key found")
+}
+
+func parseCertificates(bundle any) ([]*x509.Certificate, error) {
+	blocks, err := decodePEMBlocks(bundle)
+	if err != nil {
+		return nil, err
+	}
+	certificates := []*x509.Certificate{}
+	for _, block := range blocks {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	return certificates, nil
+}
+
+// decodePEMBlocks decodes the PEM blocks of a bundle. The bundle can be a string, a base64 encoded string, or a list of those.
+func decodePEMBlocks(bundle any) ([]*pem.Block, error) {
+	if bundle == nil {
+		return nil, nil
+	}
+	value := reflect.ValueOf(bundle)
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		blocks := []*pem.Block{}
+		for i := 0; i < value.Len(); i++ {
+			itemBlocks, err := decodePEMBlocks(value.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, itemBlocks...)
+		}
+		return blocks, nil
+	}
+	var data []byte
+	switch v := bundle.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return nil, fmt.Errorf("unsupported PEM bundle type %T", bundle)
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && !bytes.Contains(data, []byte("-----BEGIN")) {
+		decoded, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return nil, errors.New("the bundle is neither PEM nor base64 encoded PEM")
+		}
+		data = decoded
+	}
+	blocks := []*pem.Block{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+func certificateToMap(certificate *x509.Certificate) map[string]any {
+	ipAddresses := make([]any, 0, len(certificate.IPAddresses))
+	for _, ip := range certificate.IPAddresses {
+		ipAddresses = append(ipAddresses, ip.String())
+	}
+	dnsNames := make([]any, 0, len(certificate.DNSNames))
+	for _, dnsName := range certificate.DNSNames {
+		dnsNames = append(dnsNames, dnsName)
+	}
+	return map[string]any{
+		"subject":      certificate.Subject.String(),
+		"commonName":   certificate.Subject.CommonName,
+		"issuer":       certificate.Issuer.String(),
+		"serialNumber": certificate.SerialNumber.String(),
+		"notBefore":    certificate.NotBefore,
+		"notAfter":     certificate.NotAfter,
+		"dnsNames":     dnsNames,
+		"ipAddresses":  ipAddresses,
+		"isCA":         certificate.IsCA,
+		"pem":          string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})),
+	}
+}
+
+// deterministicReader returns a stream of pseudo random bytes derived from the seed.
+// It is used for the salts of the keystores so that rendering the same inputs twice gives the same output,
+// which avoids rewriting the K8s Secret and restarting the consuming workloads at each synchronization.
+func deterministicReader(seed ...string) io.Reader {
+	h := sha256.New()
+	for _, s := range seed {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return &hashCounterReader{seed: h.Sum(nil)}
+}
+
+type hashCounterReader struct {
+	seed    []byte
+	counter uint64
+	buffer  []byte
+}
+
+func (r *hashCounterReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.buffer) == 0 {
+			block := make([]byte, len(r.seed)+8)
+			copy(block, r.seed)
+			binary.BigEndian.PutUint64(block[len(r.seed):], r.counter)
+			r.counter++
+			sum := sha256.Sum256(block)
+			r.buffer = sum[:]
+		}
+		copied := copy(p[n:], r.buffer)
+		r.buffer = r.buffer[copied:]
+		n += copied
+	}
+	return n, nil
+}
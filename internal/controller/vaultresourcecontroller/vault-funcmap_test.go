@@ -0,0 +1,279 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vaultresourcecontroller
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// generateTestCertificate returns a PEM encoded self-signed certificate and its PKCS#8 PEM encoded private key.
+func generateTestCertificate(t *testing.T, commonName string, isCA bool) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(42),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              []string{commonName},
+		NotBefore:             time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2034, 1, 1, 0, 0, 0, 0, time.UTC),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
+
+func renderTemplate(t *testing.T, text string, data any) (string, error) {
+	t.Helper()
+	tpl, err := ParseTemplate("test", text, testTemplateFuncs())
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	err = tpl.Execute(&b, data)
+	return b.String(), err
+}
+
+func testTemplateFuncs() map[string]any {
+	f := map[string]any{}
+	for k, v := range VaultTemplateFuncMap(func(string) (context.Context, bool) { return nil, false }) {
+		f[k] = v
+	}
+	f["upper"] = strings.ToUpper
+	return f
+}
+
+func TestParseTemplate_IncludeAndTpl(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		data     any
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "include can be piped",
+			text:     `{{ define "user" }}{{ .username }}{{ end }}{{ include "user" . | upper }}`,
+			data:     map[string]any{"username": "admin"},
+			expected: "ADMIN",
+		},
+		{
+			name:     "tpl renders a string with the named templates",
+			text:     `{{ define "user" }}{{ .username }}{{ end }}{{ tpl .format . }}`,
+			data:     map[string]any{"username": "admin", "format": `user={{ include "user" . }}`},
+			expected: "user=admin",
+		},
+		{
+			name:    "recursive include is stopped",
+			text:    `{{ define "loop" }}{{ include "loop" . }}{{ end }}{{ include "loop" . }}`,
+			wantErr: true,
+		},
+		{
+			name:    "recursive tpl is stopped",
+			text:    `{{ tpl .format . }}`,
+			data:    map[string]any{"format": `{{ tpl .format . }}`},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := renderTemplate(t, tt.text, tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("got %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVaultRead_UnknownDefinition(t *testing.T) {
+	_, err := renderTemplate(t, `{{ vaultRead "missing" "secret/data/app" }}`, nil)
+	if err == nil || !strings.Contains(err.Error(), "unknown vault secret definition missing") {
+		t.Errorf("expected unknown definition error, got %v", err)
+	}
+}
+
+func TestVaultRead_RejectsPayload(t *testing.T) {
+	_, err := renderTemplate(t, `{{ vaultRead "missing" "transit/encrypt/app" .payload }}`, map[string]any{"payload": map[string]any{"plaintext": ""}})
+	if err == nil || !strings.Contains(err.Error(), "wrong number of args") {
+		t.Errorf("expected wrong number of args error, got %v", err)
+	}
+}
+
+func TestVaultWrite_UnknownDefinition(t *testing.T) {
+	_, err := renderTemplate(t, `{{ vaultWrite "missing" "transit/encrypt/app" .payload }}`, map[string]any{"payload": map[string]any{"plaintext": ""}})
+	if err == nil || !strings.Contains(err.Error(), "vaultWrite: unknown vault secret definition missing") {
+		t.Errorf("expected unknown definition error, got %v", err)
+	}
+}
+
+func TestSplitPEM(t *testing.T) {
+	cert1, _ := generateTestCertificate(t, "one.example.com", true)
+	cert2, _ := generateTestCertificate(t, "two.example.com", true)
+	bundle := cert1 + cert2
+
+	tests := []struct {
+		name   string
+		bundle any
+	}{
+		{name: "PEM bundle", bundle: bundle},
+		{name: "base64 encoded PEM bundle", bundle: base64.StdEncoding.EncodeToString([]byte(bundle))},
+		{name: "list of PEM certificates", bundle: []any{cert1, cert2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, err := splitPEM(tt.bundle)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(blocks) != 2 || blocks[0] != cert1 || blocks[1] != cert2 {
+				t.Errorf("unexpected blocks %v", blocks)
+			}
+		})
+	}
+
+	if _, err := splitPEM("not a pem bundle!"); err == nil {
+		t.Error("expected an error for an invalid bundle")
+	}
+}
+
+func TestParseX509(t *testing.T) {
+	cert, key := generateTestCertificate(t, "app.example.com", false)
+
+	result, err := parseX509(key + cert)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["commonName"] != "app.example.com" {
+		t.Errorf("commonName = %v", result["commonName"])
+	}
+	if result["serialNumber"] != "42" {
+		t.Errorf("serialNumber = %v", result["serialNumber"])
+	}
+	if result["isCA"] != false {
+		t.Errorf("isCA = %v", result["isCA"])
+	}
+	if notAfter, ok := result["notAfter"].(time.Time); !ok || notAfter.Year() != 2034 {
+		t.Errorf("notAfter = %v", result["notAfter"])
+	}
+
+	if _, err := parseX509(key); err == nil {
+		t.Error("expected an error when no certificate is found")
+	}
+}
+
+func TestToPKCS12(t *testing.T) {
+	cert, key := generateTestCertificate(t, "app.example.com", false)
+	ca, _ := generateTestCertificate(t, "ca.example.com", true)
+
+	pfx, err := toPKCS12(key, cert, []any{ca}, "changeit")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, decodedCert, caCerts, err := pkcs12.DecodeChain([]byte(pfx), "changeit")
+	if err != nil {
+		t.Fatalf("unable to decode keystore: %v", err)
+	}
+	if decodedCert.Subject.CommonName != "app.example.com" || len(caCerts) != 1 || caCerts[0].Subject.CommonName != "ca.example.com" {
+		t.Errorf("unexpected keystore content")
+	}
+
+	again, err := toPKCS12(key, cert, []any{ca}, "changeit")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again != pfx {
+		t.Error("encoding the same inputs twice should give the same keystore")
+	}
+}
+
+func TestToJKS(t *testing.T) {
+	cert, key := generateTestCertificate(t, "app.example.com", false)
+	ca, _ := generateTestCertificate(t, "ca.example.com", true)
+
+	jks, err := toJKS("app", key, cert, ca, "changeit")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ks := keystore.New()
+	if err := ks.Load(strings.NewReader(jks), []byte("changeit")); err != nil {
+		t.Fatalf("unable to load keystore: %v", err)
+	}
+	entry, err := ks.GetPrivateKeyEntry("app", []byte("changeit"))
+	if err != nil {
+		t.Fatalf("unable to get private key entry: %v", err)
+	}
+	if len(entry.CertificateChain) != 2 {
+		t.Errorf("expected a chain of 2 certificates, got %d", len(entry.CertificateChain))
+	}
+
+	again, err := toJKS("app", key, cert, ca, "changeit")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again != jks {
+		t.Error("encoding the same inputs twice should give the same keystore")
+	}
+
+	truststore, err := toJKSTrustStore(ca, "changeit")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ts := keystore.New()
+	if err := ts.Load(strings.NewReader(truststore), []byte("changeit")); err != nil {
+		t.Fatalf("unable to load truststore: %v", err)
+	}
+	if !ts.IsTrustedCertificateEntry("ca-0") {
+		t.Error("expected a trusted certificate entry ca-0")
+	}
+}
@@ -55,7 +55,16 @@ func (r *VaultPKIEngineResource) manageReconcileLogic(context context.Context, i
 
 	//Generate
 	generated := instance.(vaultutils.VaultPKIEngineObject).GetGeneratedStatus()
-	if !generated {
+	if importObject, ok := instance.(vaultutils.VaultPKIImportObject); ok && !generated && importObject.IsImport() {
+		// Import, the imported CA is already signed
+		err = importObject.ImportCA(context)
+		if err != nil {
+			log.Error(err, "unable to import CA", "instance", instance)
+			return err
+		}
+		instance.(vaultutils.VaultPKIEngineObject).SetGeneratedStatus(true)
+		instance.(vaultutils.VaultPKIEngineObject).SetSignedStatus(true)
+	} else if !generated {
 		vaultSecret, err := r.vaultPKIEngineEndpoint.Generate(context)
 		if err != nil {
 			log.Error(err, "unable to generate CA", "instance", instance)
@@ -84,7 +93,21 @@ func (r *VaultPKIEngineResource) manageReconcileLogic(context context.Context, i
 		instance.(vaultutils.VaultPKIEngineObject).SetSignedStatus(true)
 	}
 
-	// Config
+	// Exported, not in the reconcile which created the secret as the cache may not hold it yet
+	if exportedObject, ok := instance.(vaultutils.VaultPKIExportedObject); ok && generated {
+		err = exportedObject.ReconcileExported(context)
+		if err != nil {
+			log.Error(err, "unable to reconcile exported secret", "instance", instance)
+			return err
+		}
+	}
+
+	// Config, the cluster config first as the templated urls refer to it
+	err = r.vaultPKIEngineEndpoint.CreateOrUpdateConfigCluster(context)
+	if err != nil {
+		log.Error(err, "unable to create or update cluster config", "instance", instance)
+		return err
+	}
 	err = r.vaultPKIEngineEndpoint.CreateOrUpdateConfigUrls(context)
 	if err != nil {
 		log.Error(err, "unable to create or update url config", "instance", instance)
@@ -96,5 +119,49 @@ func (r *VaultPKIEngineResource) manageReconcileLogic(context context.Context, i
 		return err
 	}
 
-	return nil
+	// Issuers
+	if issuerObject, ok := instance.(vaultutils.VaultPKIIssuerObject); ok {
+		err = issuerObject.ReconcileIssuers(context)
+		if err != nil {
+			log.Error(err, "unable to reconcile issuers", "instance", instance)
+			return err
+		}
+	}
+
+	// Tidy
+	if tidyObject, ok := instance.(vaultutils.VaultPKITidyObject); ok {
+		err = tidyObject.ReconcileTidy(context)
+		if err != nil {
+			log.Error(err, "unable to reconcile tidy", "instance", instance)
+			return err
+		}
+	}
+
+	// ACME, after the issuers it may restrict
+	if acmeObject, ok := instance.(vaultutils.VaultPKIACMEObject); ok {
+		err = acmeObject.ReconcileACME(context)
+		if err != nil {
+			log.Error(err, "unable to reconcile ACME", "instance", instance)
+			return err
+		}
+	}
+
+	// Writes that follow the create or update, their error fails the reconciliation once the status is enriched
+	var postWriteErr error
+	if postWriteReconciler, ok := instance.(vaultutils.VaultPostWriteReconciler); ok {
+		postWriteErr = postWriteReconciler.ReconcilePostWrite(context)
+		if postWriteErr != nil {
+			log.Error(postWriteErr, "unable to reconcile the writes following the create/update of the vault resource", "instance", instance)
+		}
+	}
+
+	// Enrich status with Vault-side data if the object supports it
+	if enricher, ok := instance.(vaultutils.VaultStatusEnricher); ok {
+		if enrichErr := enricher.EnrichStatus(context); enrichErr != nil {
+			log.Error(enrichErr, "unable to enrich status from Vault", "instance", instance)
+			// Non-fatal: proceed so conditions are still updated
+		}
+	}
+
+	return postWriteErr
 }
@@ -39,11 +39,25 @@ func NewVaultResource(reconcilerBase *ReconcilerBase, obj client.Object) *VaultR
 
 func (r *VaultResource) Reconcile(ctx context.Context, instance client.Object) (ctrl.Result, error) {
 	return ReconcileWithFunctions(ctx, r.reconcilerBase, instance,
-		r.vaultEndpoint.DeleteIfExists,
+		func(ctx context.Context) error {
+			return r.manageCleanUpLogic(ctx, instance)
+		},
 		r.manageReconcileLogic,
 	)
 }
 
+func (r *VaultResource) manageCleanUpLogic(context context.Context, instance client.Object) error {
+	log := log.FromContext(context)
+	if deleter, ok := instance.(vaultutils.VaultDependentsDeleter); ok {
+		err := deleter.DeleteDependents(context)
+		if err != nil {
+			log.Error(err, "unable to delete dependent vault resources", "instance", instance)
+			return err
+		}
+	}
+	return r.vaultEndpoint.DeleteIfExists(context)
+}
+
 func (r *VaultResource) manageReconcileLogic(context context.Context, instance client.Object) error {
 	log := log.FromContext(context)
 	// prepare internal values
@@ -65,6 +79,15 @@ func (r *VaultResource) manageReconcileLogic(context context.Context, instance c
 		return err
 	}
 
+	// Writes that follow the create or update, their error fails the reconciliation once the status is enriched
+	var postWriteErr error
+	if postWriteReconciler, ok := instance.(vaultutils.VaultPostWriteReconciler); ok {
+		postWriteErr = postWriteReconciler.ReconcilePostWrite(context)
+		if postWriteErr != nil {
+			log.Error(postWriteErr, "unable to reconcile the writes following the create/update of the vault resource", "instance", instance)
+		}
+	}
+
 	// Enrich status with Vault-side data if the object supports it
 	if enricher, ok := instance.(vaultutils.VaultStatusEnricher); ok {
 		if enrichErr := enricher.EnrichStatus(context); enrichErr != nil {
@@ -73,5 +96,5 @@ func (r *VaultResource) manageReconcileLogic(context context.Context, instance c
 		}
 	}
 
-	return nil
+	return postWriteErr
 }
@@ -24,20 +24,26 @@ import (
 	"fmt"
 	"math"
 	"reflect"
-	"text/template"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	//utilstemplates "github.com/redhat-cop/operator-utils/pkg/util/templates"
 	redhatcopv1alpha1 "github.com/redhat-cop/vault-config-operator/api/v1alpha1"
 	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -50,7 +56,11 @@ const (
 	hashAnnotationName = "vaultsecret.redhatcop.redhat.io/secret-hash"
 	vaultSecretKind    = "VaultSecret"
 	secretKind         = "Secret"
+	configMapKind      = "ConfigMap"
 	secretAPIVersion   = "v1"
+
+	// copyOwnerAnnotationName identifies the VaultSecret, as namespace/name, that owns a copy of an output in a target namespace.
+	copyOwnerAnnotationName = "vaultsecret.redhatcop.redhat.io/copy-of"
 )
 
 // VaultSecretReconciler reconciles a VaultSecret object
@@ -61,7 +71,11 @@ type VaultSecretReconciler struct {
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=vaultsecrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=vaultsecrets/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=vaultsecrets/finalizers,verbs=update
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=vaultsecretgrants,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch;patch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
 //+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;patch
 
@@ -108,20 +122,46 @@ func (r *VaultSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return reconcile.Result{}, nil
 	}
 
+	if r.shouldRenewLeases(instance) {
+		err = r.manageLeaseRenewal(ctx, instance)
+		if err != nil {
+			r.Log.Error(err, "unable to complete lease renewal logic", "instance", instance)
+			return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
+		}
+	}
+
 	shouldSync, err := r.shouldSync(ctx, instance)
 	if err != nil {
 		// There was a problem determining if the event should cause a sync.
 		return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
 	}
 
+	// the outputs rendered by the sync, the cache may not reflect their update yet
+	var renderedOutputs []client.Object
 	if shouldSync {
-		err = r.manageSyncLogic(ctx, instance)
+		renderedOutputs, err = r.manageSyncLogic(ctx, instance)
 		if err != nil {
 			r.Log.Error(err, "unable to complete sync logic", "instance", instance)
 			return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
 		}
 	}
 
+	if instance.Spec.TargetNamespaces != nil || len(instance.Status.CopiedOutputs) > 0 {
+		err = r.manageCopyLogic(ctx, instance, renderedOutputs)
+		if err != nil {
+			r.Log.Error(err, "unable to complete copy logic", "instance", instance)
+			return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
+		}
+	}
+
+	if len(instance.Spec.RolloutTargets) > 0 {
+		err = r.manageRolloutLogic(ctx, instance, renderedOutputs)
+		if err != nil {
+			r.Log.Error(err, "unable to complete rollout logic", "instance", instance)
+			return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
+		}
+	}
+
 	duration, ok := r.calculateDuration(instance)
 
 	// If a duration incalculable, simply don't requeue
@@ -130,7 +170,7 @@ func (r *VaultSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, nil)
 	}
 
-	nextUpdateTime := instance.Status.LastVaultSecretUpdate.Add(duration)
+	nextUpdateTime := lastRefresh(instance).Add(duration)
 
 	nextTimestamp := metav1.NewTime(nextUpdateTime)
 	instance.Status.NextVaultSecretUpdate = &nextTimestamp
@@ -147,30 +187,63 @@ func (r *VaultSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 func (r *VaultSecretReconciler) manageCleanUpLogic(context context.Context, instance *redhatcopv1alpha1.VaultSecret) error {
 
-	k8sSecret := &corev1.Secret{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       secretKind,
-			APIVersion: secretAPIVersion,
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      instance.Spec.TemplatizedK8sSecret.Name,
-			Namespace: instance.Namespace,
-		},
+	for _, defstat := range instance.Status.VaultSecretDefinitionsStatus {
+		if defstat.LeaseID == "" {
+			continue
+		}
+		vaultSecretDefinition, ok := getVaultSecretDefinition(instance, defstat.Name)
+		if !ok {
+			r.Log.V(1).Info("vault secret definition no longer exists, leaving lease to expire", "name", defstat.Name, "leaseID", defstat.LeaseID)
+			continue
+		}
+		// a lease that cannot be revoked, for example because it already expired or its role was deleted, must not block the deletion, it expires at the end of its TTL
+		ctx, err := r.prepareDefinitionContext(context, instance, vaultSecretDefinition)
+		if err != nil {
+			r.Log.Error(err, "unable to prepare the context to revoke the lease, it will expire at the end of its TTL", "instance", instance, "leaseID", defstat.LeaseID)
+			continue
+		}
+		err = vaultutils.RevokeLease(ctx, defstat.LeaseID)
+		if err != nil {
+			r.Log.Error(err, "unable to revoke lease, it will expire at the end of its TTL", "instance", instance, "leaseID", defstat.LeaseID)
+		}
 	}
-	err := r.DeleteResourceIfExists(context, k8sSecret)
-	if err != nil {
-		r.Log.Error(err, "unable to delete k8s secret", "instance", instance, "k8s secret", k8sSecret)
-		return err
+
+	for _, copiedOutput := range instance.Status.CopiedOutputs {
+		err := r.deleteOutputCopy(context, instance, copiedOutput)
+		if err != nil {
+			r.Log.Error(err, "unable to delete copy of k8s output", "instance", instance, "kind", copiedOutput.Kind, "namespace", copiedOutput.Namespace, "name", copiedOutput.Name)
+			return err
+		}
+	}
+
+	for _, output := range instance.GetOutputs() {
+		k8sObject := newOutputObject(output.Kind)
+		k8sObject.SetName(output.Name)
+		k8sObject.SetNamespace(instance.Namespace)
+		err := r.DeleteResourceIfExists(context, k8sObject)
+		if err != nil {
+			r.Log.Error(err, "unable to delete k8s output", "instance", instance, "kind", output.Kind, "name", output.Name)
+			return err
+		}
 	}
 	return nil
 }
 
-func (r *VaultSecretReconciler) formatK8sSecret(instance *redhatcopv1alpha1.VaultSecret, data any) (*corev1.Secret, error) {
+func (r *VaultSecretReconciler) formatOutput(instance *redhatcopv1alpha1.VaultSecret, output redhatcopv1alpha1.VaultSecretOutput, data any, definitionsContext map[string]context.Context) (client.Object, error) {
+
+	funcMap := vaultresourcecontroller.AdvancedTemplateFuncMap(r.GetRestConfig(), r.Log)
+	vaultFuncMap := vaultresourcecontroller.VaultTemplateFuncMap(func(definition string) (context.Context, bool) {
+		ctx, ok := definitionsContext[definition]
+		return ctx, ok
+	})
+	for k, v := range vaultFuncMap {
+		funcMap[k] = v
+	}
 
 	bytesData := make(map[string][]byte)
-	for k, v := range instance.Spec.TemplatizedK8sSecret.StringData {
+	for k, v := range output.StringData {
 
-		tpl, err := template.New("").Funcs(vaultresourcecontroller.AdvancedTemplateFuncMap(r.GetRestConfig(), r.Log)).Parse(v)
+		tpl, err := vaultresourcecontroller.ParseTemplate(k, v, funcMap)
 		if err != nil {
 			r.Log.Error(err, "unable to create template", "instance", instance)
 			return nil, err
@@ -188,28 +261,85 @@ func (r *VaultSecretReconciler) formatK8sSecret(instance *redhatcopv1alpha1.Vaul
 	annotations := make(map[string]string)
 	annotations[hashAnnotationName] = vaultsecretutils.HashData(bytesData)
 
-	for k, v := range instance.Spec.TemplatizedK8sSecret.Annotations {
+	for k, v := range output.Annotations {
 		annotations[k] = v
 	}
 
+	objectMeta := metav1.ObjectMeta{
+		Name:        output.Name,
+		Namespace:   instance.Namespace,
+		Annotations: annotations,
+		Labels:      output.Labels,
+	}
+
+	if output.Kind == configMapKind {
+		stringData := make(map[string]string, len(bytesData))
+		for k, v := range bytesData {
+			stringData[k] = string(v)
+		}
+		return &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       configMapKind,
+				APIVersion: secretAPIVersion,
+			},
+			ObjectMeta: objectMeta,
+			Data:       stringData,
+		}, nil
+	}
+
 	k8sSecret := &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       secretKind,
 			APIVersion: secretAPIVersion,
 		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        instance.Spec.TemplatizedK8sSecret.Name,
-			Namespace:   instance.Namespace,
-			Annotations: annotations,
-			Labels:      instance.Spec.TemplatizedK8sSecret.Labels,
-		},
-		Data: bytesData,
-		Type: corev1.SecretType(instance.Spec.TemplatizedK8sSecret.Type),
+		ObjectMeta: objectMeta,
+		Data:       bytesData,
+		Type:       corev1.SecretType(output.Type),
 	}
 
 	return k8sSecret, nil
 }
 
+// newOutputObject returns an empty K8s object of the output kind, Secret by default.
+func newOutputObject(kind string) client.Object {
+	if kind == configMapKind {
+		return &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       configMapKind,
+				APIVersion: secretAPIVersion,
+			},
+		}
+	}
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       secretKind,
+			APIVersion: secretAPIVersion,
+		},
+	}
+}
+
+func getOutputKind(obj client.Object) string {
+	if _, ok := obj.(*corev1.ConfigMap); ok {
+		return configMapKind
+	}
+	return secretKind
+}
+
+// getOutputData returns the data of an output object in the form the hash annotation is computed on.
+func getOutputData(obj client.Object) (map[string][]byte, bool) {
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		return o.Data, true
+	case *corev1.ConfigMap:
+		data := make(map[string][]byte, len(o.Data))
+		for k, v := range o.Data {
+			data[k] = []byte(v)
+		}
+		return data, true
+	}
+	return nil, false
+}
+
 // Calculates the resync period based on the RefreshPeriod, and LeaseDurations returned from Vault for each secret defined (the smallest duration will be returned).
 // If no RefreshPeriod or Leasedurations are found return -1 and bool of false indicating that its was incalculable.
 func (r *VaultSecretReconciler) calculateDuration(instance *redhatcopv1alpha1.VaultSecret) (time.Duration, bool) {
@@ -272,41 +402,39 @@ func (r *VaultSecretReconciler) shouldSync(ctx context.Context, instance *redhat
 		}
 	}
 
-	// check if the k8s secret is valid (exists, owned, data not tampered with)
-	secretNamespacedName := &types.NamespacedName{
-		Name:      instance.Spec.TemplatizedK8sSecret.Name,
-		Namespace: instance.Namespace,
-	}
-	secret := &corev1.Secret{}
-	err := r.GetClient().Get(ctx, *secretNamespacedName, secret)
-	if err != nil {
-		//if k8s secret does not exist (it was deleted), it should sync
-		if apierrors.IsNotFound(err) {
-			return true, nil
+	// check if the k8s outputs are valid (exist, owned, data not tampered with)
+	for _, output := range instance.GetOutputs() {
+		outputNamespacedName := &types.NamespacedName{
+			Name:      output.Name,
+			Namespace: instance.Namespace,
+		}
+		k8sObject := newOutputObject(output.Kind)
+		err := r.GetClient().Get(ctx, *outputNamespacedName, k8sObject)
+		if err != nil {
+			//if k8s output does not exist (it was deleted), it should sync
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			// else there was an Error reading the object. It should not sync.
+			return false, err
 		}
-		// else there was an Error reading the object. It should not sync.
-		return false, err
-	} else {
 
-		//if the secret exists and isn't owned by this VaultSecret then the name needs to be different
-		if !vaultresourcecontroller.IsOwner(instance, secret) {
-			return false, fmt.Errorf("the k8s Secret %v is not owned by VaultSecret %v", secretNamespacedName.String(), toNamespacedName(instance))
+		//if the output exists and isn't owned by this VaultSecret then the name needs to be different
+		if !vaultresourcecontroller.IsOwner(instance, k8sObject) {
+			return false, fmt.Errorf("the k8s %v %v is not owned by VaultSecret %v", output.Kind, outputNamespacedName.String(), toNamespacedName(instance))
 		}
 
-		if secret.Annotations != nil {
-			hash, ok := secret.Annotations[hashAnnotationName]
-			if !ok {
-				return true, nil
-			}
-			// if the hash value in the k8s secret doesnt match the final data section, sync
-			if hash != vaultsecretutils.HashData(secret.Data) {
-				return true, nil
-			}
-			// else the hash matches. continue with logic.
-		} else {
-			// if annotation is nil, sync
+		hash, ok := k8sObject.GetAnnotations()[hashAnnotationName]
+		// if annotation is missing, sync
+		if !ok {
 			return true, nil
 		}
+		// if the hash value in the k8s output doesnt match the final data section, sync
+		data, _ := getOutputData(k8sObject)
+		if hash != vaultsecretutils.HashData(data) {
+			return true, nil
+		}
+		// else the hash matches. continue with logic.
 	}
 
 	// if the vaultsecret has synced before
@@ -316,8 +444,8 @@ func (r *VaultSecretReconciler) shouldSync(ctx context.Context, instance *redhat
 		if !ok {
 			return false, nil
 		}
-		// if the resync period has not elapsed, do not sync
-		if !instance.Status.LastVaultSecretUpdate.Add(duration).Before(time.Now()) {
+		// if the resync period has not elapsed since the last read or lease renewal, do not sync
+		if !lastRefresh(instance).Add(duration).Before(time.Now()) {
 			return false, nil
 		}
 	}
@@ -325,31 +453,31 @@ func (r *VaultSecretReconciler) shouldSync(ctx context.Context, instance *redhat
 	return true, nil
 }
 
-func (r *VaultSecretReconciler) manageSyncLogic(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret) error {
+// manageSyncLogic reads the secrets from Vault and creates or updates the K8s outputs, which it returns as rendered.
+func (r *VaultSecretReconciler) manageSyncLogic(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret) ([]client.Object, error) {
 
 	r.Log.V(1).Info("Sync VaultSecret", "namespacedName", toNamespacedName(instance))
 
 	mergedMap := make(map[string]any)
 
 	definitionsStatus := make([]redhatcopv1alpha1.VaultSecretDefinitionStatus, len(instance.Spec.VaultSecretDefinitions))
+	definitionsContext := make(map[string]context.Context, len(instance.Spec.VaultSecretDefinitions))
 
 	for idx, vaultSecretDefinition := range instance.Spec.VaultSecretDefinitions {
-		ctx = vaultutils.ContextWithVaultConnection(ctx, vaultSecretDefinition.GetVaultConnection())
-		vaultClient, err := vaultSecretDefinition.Authentication.GetVaultClient(ctx, instance.Namespace)
+		ctx, err := r.prepareDefinitionContext(ctx, instance, &vaultSecretDefinition)
 		if err != nil {
-			r.Log.Error(err, "unable to create vault client", "instance", instance)
-			return err
+			return nil, err
 		}
+		definitionsContext[vaultSecretDefinition.Name] = ctx
 
-		ctx = vaultutils.ContextWithVaultClient(ctx, vaultClient)
 		vaultSecretEndpoint := vaultutils.NewVaultSecretEndpoint(&vaultSecretDefinition)
 		vaultSecret, ok, err := vaultSecretEndpoint.GetSecret(ctx)
 		if err != nil {
 			r.Log.Error(err, "unable to read vault secret for ", "path", vaultSecretDefinition.GetPath())
-			return err
+			return nil, err
 		}
 		if !ok {
-			return errors.New("secret not found at path: " + vaultSecretDefinition.GetPath())
+			return nil, errors.New("secret not found at path: " + vaultSecretDefinition.GetPath())
 		}
 
 		r.Log.V(1).Info("", "", vaultSecret.LeaseDuration)
@@ -362,7 +490,7 @@ func (r *VaultSecretReconciler) manageSyncLogic(ctx context.Context, instance *r
 		}
 
 		if vaultSecret.Data == nil {
-			return errors.New("no data returned from vault secret for " + vaultSecretDefinition.GetPath())
+			return nil, errors.New("no data returned from vault secret for " + vaultSecretDefinition.GetPath())
 		}
 
 		// if its a kv v2, then the structure returned is different
@@ -375,25 +503,538 @@ func (r *VaultSecretReconciler) manageSyncLogic(ctx context.Context, instance *r
 
 	}
 
-	k8sSecret, err := r.formatK8sSecret(instance, mergedMap)
+	outputs := []redhatcopv1alpha1.OutputReference{}
+	rendered := []client.Object{}
+	for _, output := range instance.GetOutputs() {
+		k8sObject, err := r.formatOutput(instance, output, mergedMap, definitionsContext)
+		if err != nil {
+			r.Log.Error(err, "unable to format k8s output", "instance", instance, "kind", output.Kind, "name", output.Name)
+			return nil, err
+		}
+
+		err = r.CreateOrUpdateResource(ctx, instance, instance.GetNamespace(), k8sObject)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, redhatcopv1alpha1.OutputReference{Kind: output.Kind, Namespace: instance.Namespace, Name: output.Name})
+		rendered = append(rendered, k8sObject)
+	}
+
+	// the outputs removed from the spec would keep serving the previous credentials
+	for _, previousOutput := range instance.Status.Outputs {
+		if slices.Contains(outputs, previousOutput) {
+			continue
+		}
+		err := r.deleteRemovedOutput(ctx, instance, previousOutput)
+		if err != nil {
+			r.Log.Error(err, "unable to delete removed k8s output", "instance", instance, "kind", previousOutput.Kind, "name", previousOutput.Name)
+			return nil, err
+		}
+	}
+	instance.Status.Outputs = outputs
+
+	// the k8s outputs now carry the new credentials, the leases of the previous ones can be revoked
+	r.revokeRotatedLeases(definitionsContext, instance.Status.VaultSecretDefinitionsStatus, definitionsStatus)
+
+	now := metav1.NewTime(time.Now())
+	instance.Status.LastVaultSecretUpdate = &now
+	instance.Status.LastLeaseRenewal = nil
+	instance.Status.SyncedResourceVersion = vaultsecretutils.GetResourceVersion(instance.ObjectMeta)
+	instance.Status.VaultSecretDefinitionsStatus = definitionsStatus
+
+	return rendered, nil
+}
+
+// revokeRotatedLeases revokes the leases of the previous read that have been replaced by a new lease.
+// Failures are only logged, the old leases will expire on their own at the end of their TTL.
+func (r *VaultSecretReconciler) revokeRotatedLeases(definitionsContext map[string]context.Context, previousStatus []redhatcopv1alpha1.VaultSecretDefinitionStatus, currentStatus []redhatcopv1alpha1.VaultSecretDefinitionStatus) {
+	currentLeases := make(map[string]bool, len(currentStatus))
+	for _, defstat := range currentStatus {
+		currentLeases[defstat.LeaseID] = true
+	}
+	for _, defstat := range previousStatus {
+		if defstat.LeaseID == "" || currentLeases[defstat.LeaseID] {
+			continue
+		}
+		ctx, ok := definitionsContext[defstat.Name]
+		if !ok {
+			r.Log.V(1).Info("vault secret definition no longer exists, leaving lease to expire", "name", defstat.Name, "leaseID", defstat.LeaseID)
+			continue
+		}
+		if err := vaultutils.RevokeLease(ctx, defstat.LeaseID); err != nil {
+			r.Log.Error(err, "unable to revoke rotated lease, it will expire at the end of its TTL", "name", defstat.Name, "leaseID", defstat.LeaseID)
+		}
+	}
+}
+
+// manageCopyLogic copies the outputs to the target namespaces that granted this VaultSecret, and deletes the copies that are no longer desired.
+// The copies are made from the outputs in the namespace of the VaultSecret, so that Vault is not read again for every target namespace.
+func (r *VaultSecretReconciler) manageCopyLogic(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret, renderedOutputs []client.Object) error {
+	desiredCopies := []redhatcopv1alpha1.OutputReference{}
+	var errs []error
+
+	if instance.Spec.TargetNamespaces != nil {
+		namespaces, err := r.findGrantedTargetNamespaces(ctx, instance)
+		if err != nil {
+			return err
+		}
+		// the outputs that have not been created yet are copied at the next reconcile
+		sources, err := r.getOutputObjects(ctx, instance, renderedOutputs)
+		if err != nil {
+			return err
+		}
+		for _, source := range sources {
+			for _, namespace := range namespaces {
+				copiedOutput := redhatcopv1alpha1.OutputReference{Kind: getOutputKind(source), Namespace: namespace, Name: source.GetName()}
+				err := r.copyOutput(ctx, instance, source, namespace)
+				if err != nil {
+					r.Log.Error(err, "unable to copy k8s output", "instance", instance, "kind", copiedOutput.Kind, "namespace", namespace, "name", source.GetName())
+					errs = append(errs, err)
+					// keep tracking a copy that failed to update, so that it is not deleted
+					if !slices.Contains(instance.Status.CopiedOutputs, copiedOutput) {
+						continue
+					}
+				}
+				desiredCopies = append(desiredCopies, copiedOutput)
+			}
+		}
+	}
+
+	for _, copiedOutput := range instance.Status.CopiedOutputs {
+		if slices.Contains(desiredCopies, copiedOutput) {
+			continue
+		}
+		err := r.deleteOutputCopy(ctx, instance, copiedOutput)
+		if err != nil {
+			r.Log.Error(err, "unable to delete copy of k8s output", "instance", instance, "kind", copiedOutput.Kind, "namespace", copiedOutput.Namespace, "name", copiedOutput.Name)
+			errs = append(errs, err)
+			desiredCopies = append(desiredCopies, copiedOutput)
+		}
+	}
+
+	instance.Status.CopiedOutputs = desiredCopies
+	return errors.Join(errs...)
+}
+
+// findGrantedTargetNamespaces returns the target namespaces, other than the namespace of the VaultSecret, with a VaultSecretGrant allowing the VaultSecret.
+func (r *VaultSecretReconciler) findGrantedTargetNamespaces(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret) ([]string, error) {
+	namespaces := instance.Spec.TargetNamespaces.TargetNamespaces
+	if instance.Spec.TargetNamespaces.TargetNamespaceSelector != nil {
+		labelSelector, err := metav1.LabelSelectorAsSelector(instance.Spec.TargetNamespaces.TargetNamespaceSelector)
+		if err != nil {
+			r.Log.Error(err, "unable to create selector from label selector", "selector", instance.Spec.TargetNamespaces.TargetNamespaceSelector)
+			return nil, err
+		}
+		namespaceList := &corev1.NamespaceList{}
+		err = r.GetClient().List(ctx, namespaceList, &client.ListOptions{
+			LabelSelector: labelSelector,
+		})
+		if err != nil {
+			r.Log.Error(err, "unable to retrieve the list of namespaces")
+			return nil, err
+		}
+		namespaces = []string{}
+		for i := range namespaceList.Items {
+			namespaces = append(namespaces, namespaceList.Items[i].Name)
+		}
+	}
+
+	result := []string{}
+	for _, namespace := range namespaces {
+		if namespace == instance.Namespace {
+			continue
+		}
+		grantList := &redhatcopv1alpha1.VaultSecretGrantList{}
+		err := r.GetClient().List(ctx, grantList, client.InNamespace(namespace))
+		if err != nil {
+			r.Log.Error(err, "unable to retrieve the list of VaultSecretGrants", "namespace", namespace)
+			return nil, err
+		}
+		for i := range grantList.Items {
+			if grantList.Items[i].Allows(instance.Namespace, instance.Name) {
+				result = append(result, namespace)
+				break
+			}
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// copyOutput creates or updates the copy of an output in the target namespace.
+// An existing resource that is not a copy of this VaultSecret is never overwritten.
+func (r *VaultSecretReconciler) copyOutput(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret, source client.Object, namespace string) error {
+	existing := newOutputObject(getOutputKind(source))
+	err := r.GetClient().Get(ctx, types.NamespacedName{Namespace: namespace, Name: source.GetName()}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		if existing.GetAnnotations()[copyOwnerAnnotationName] != instance.GetCopyOwnerAnnotationValue() {
+			return fmt.Errorf("%s %s/%s already exists and is not a copy of VaultSecret %s", getOutputKind(source), namespace, source.GetName(), instance.GetCopyOwnerAnnotationValue())
+		}
+		data, _ := getOutputData(existing)
+		hash := existing.GetAnnotations()[hashAnnotationName]
+		if hash == source.GetAnnotations()[hashAnnotationName] && hash == vaultsecretutils.HashData(data) {
+			// the copy is up to date
+			return nil
+		}
+	}
+
+	annotations := make(map[string]string, len(source.GetAnnotations())+1)
+	for k, v := range source.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[copyOwnerAnnotationName] = instance.GetCopyOwnerAnnotationValue()
+	objectMeta := metav1.ObjectMeta{
+		Name:        source.GetName(),
+		Namespace:   namespace,
+		Annotations: annotations,
+		Labels:      source.GetLabels(),
+	}
+
+	var copiedOutput client.Object
+	switch o := source.(type) {
+	case *corev1.ConfigMap:
+		copiedOutput = &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       configMapKind,
+				APIVersion: secretAPIVersion,
+			},
+			ObjectMeta: objectMeta,
+			Data:       o.Data,
+		}
+	case *corev1.Secret:
+		copiedOutput = &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       secretKind,
+				APIVersion: secretAPIVersion,
+			},
+			ObjectMeta: objectMeta,
+			Data:       o.Data,
+			Type:       o.Type,
+		}
+	}
+	return r.CreateOrUpdateResource(ctx, nil, "", copiedOutput)
+}
+
+// deleteOutputCopy deletes the copy of an output, if it is still a copy of this VaultSecret.
+func (r *VaultSecretReconciler) deleteOutputCopy(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret, copiedOutput redhatcopv1alpha1.OutputReference) error {
+	k8sObject := newOutputObject(copiedOutput.Kind)
+	err := r.GetClient().Get(ctx, types.NamespacedName{Namespace: copiedOutput.Namespace, Name: copiedOutput.Name}, k8sObject)
 	if err != nil {
-		r.Log.Error(err, "unable to format k8s secret", "instance", instance)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
 		return err
 	}
+	if k8sObject.GetAnnotations()[copyOwnerAnnotationName] != instance.GetCopyOwnerAnnotationValue() {
+		r.Log.V(1).Info("resource is no longer a copy of the VaultSecret, leaving it in place", "kind", copiedOutput.Kind, "namespace", copiedOutput.Namespace, "name", copiedOutput.Name)
+		return nil
+	}
+	return r.DeleteResourceIfExists(ctx, k8sObject)
+}
 
-	err = r.CreateOrUpdateResource(ctx, instance, instance.GetNamespace(), k8sSecret)
+// deleteRemovedOutput deletes an output that was removed from the spec, unless it is no longer owned by the VaultSecret.
+func (r *VaultSecretReconciler) deleteRemovedOutput(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret, output redhatcopv1alpha1.OutputReference) error {
+	k8sObject := newOutputObject(output.Kind)
+	err := r.GetClient().Get(ctx, types.NamespacedName{Namespace: output.Namespace, Name: output.Name}, k8sObject)
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
 		return err
 	}
+	if !vaultresourcecontroller.IsOwner(instance, k8sObject) {
+		r.Log.V(1).Info("resource is no longer owned by the VaultSecret, leaving it in place", "kind", output.Kind, "namespace", output.Namespace, "name", output.Name)
+		return nil
+	}
+	return r.DeleteResourceIfExists(ctx, k8sObject)
+}
+
+// findVaultSecretsTargetingNamespace returns the VaultSecrets that select the given namespace as a target namespace,
+// or that copied outputs to it, so that the copies are deleted when the namespace is no longer selected.
+func (r *VaultSecretReconciler) findVaultSecretsTargetingNamespace(ctx context.Context, namespace *corev1.Namespace) ([]redhatcopv1alpha1.VaultSecret, error) {
+	result := []redhatcopv1alpha1.VaultSecret{}
+	vsl := &redhatcopv1alpha1.VaultSecretList{}
+	err := r.GetClient().List(ctx, vsl, &client.ListOptions{})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of VaultSecrets")
+		return []redhatcopv1alpha1.VaultSecret{}, err
+	}
+	for _, vs := range vsl.Items {
+		if slices.ContainsFunc(vs.Status.CopiedOutputs, func(copiedOutput redhatcopv1alpha1.OutputReference) bool {
+			return copiedOutput.Namespace == namespace.Name
+		}) {
+			result = append(result, vs)
+			continue
+		}
+		if vs.Spec.TargetNamespaces == nil {
+			continue
+		}
+		if vs.Spec.TargetNamespaces.TargetNamespaceSelector != nil {
+			labelSelector, err := metav1.LabelSelectorAsSelector(vs.Spec.TargetNamespaces.TargetNamespaceSelector)
+			if err != nil {
+				r.Log.Error(err, "unable to create selector from label selector", "selector", vs.Spec.TargetNamespaces.TargetNamespaceSelector)
+				return []redhatcopv1alpha1.VaultSecret{}, err
+			}
+			if labelSelector.Matches(labels.Set(namespace.GetLabels())) {
+				result = append(result, vs)
+			}
+			continue
+		}
+		if slices.Contains(vs.Spec.TargetNamespaces.TargetNamespaces, namespace.Name) {
+			result = append(result, vs)
+		}
+	}
+	return result, nil
+}
+
+// manageRolloutLogic propagates the hash of the K8s outputs to the pod template of the rollout targets when it has changed since the last rollout.
+// Changing the pod template triggers a rolling restart of the workloads, so that they pick up the new content of the K8s outputs.
+func (r *VaultSecretReconciler) manageRolloutLogic(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret, renderedOutputs []client.Object) error {
+	k8sObjects, err := r.getOutputObjects(ctx, instance, renderedOutputs)
+	if err != nil {
+		return err
+	}
+	hash := getOutputsHash(instance, k8sObjects)
+	if hash == "" || hash == instance.Status.RolloutSecretHash {
+		return nil
+	}
+
+	annotationName := instance.GetRolloutAnnotationName()
+	for _, rolloutTarget := range instance.Spec.RolloutTargets {
+		workloads, err := r.getRolloutWorkloads(ctx, instance.Namespace, rolloutTarget)
+		if err != nil {
+			r.Log.Error(err, "unable to retrieve rollout targets", "instance", instance, "kind", rolloutTarget.Kind)
+			return err
+		}
+		for _, workload := range workloads {
+			podTemplate := getPodTemplate(workload)
+			if podTemplate.Annotations[annotationName] == hash {
+				continue
+			}
+			patch := client.MergeFrom(workload.DeepCopyObject().(client.Object))
+			if podTemplate.Annotations == nil {
+				podTemplate.Annotations = map[string]string{}
+			}
+			podTemplate.Annotations[annotationName] = hash
+			err = r.GetClient().Patch(ctx, workload, patch)
+			if err != nil {
+				r.Log.Error(err, "unable to patch rollout target", "instance", instance, "kind", rolloutTarget.Kind, "name", workload.GetName())
+				return err
+			}
+			r.Log.V(1).Info("Rollout triggered", "kind", rolloutTarget.Kind, "namespacedName", toNamespacedName(workload))
+		}
+	}
+
+	instance.Status.RolloutSecretHash = hash
+	return nil
+}
+
+// getOutputObjects returns the K8s outputs as rendered by the sync of this reconcile, if any, otherwise as read from the cache.
+// The outputs that have not been created yet are omitted.
+func (r *VaultSecretReconciler) getOutputObjects(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret, renderedOutputs []client.Object) ([]client.Object, error) {
+	if renderedOutputs != nil {
+		return renderedOutputs, nil
+	}
+	k8sObjects := []client.Object{}
+	for _, output := range instance.GetOutputs() {
+		k8sObject := newOutputObject(output.Kind)
+		err := r.GetClient().Get(ctx, types.NamespacedName{Name: output.Name, Namespace: instance.Namespace}, k8sObject)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			r.Log.Error(err, "unable to read k8s output", "instance", instance, "kind", output.Kind, "name", output.Name)
+			return nil, err
+		}
+		k8sObjects = append(k8sObjects, k8sObject)
+	}
+	return k8sObjects, nil
+}
+
+// getOutputsHash returns the hash of the K8s outputs. With a single output this is the hash annotation of the output,
+// otherwise it is a hash of the hash annotations of all the outputs. An empty string is returned if any output has not been created yet.
+func getOutputsHash(instance *redhatcopv1alpha1.VaultSecret, k8sObjects []client.Object) string {
+	if len(k8sObjects) != len(instance.GetOutputs()) {
+		return ""
+	}
+	hashes := map[string][]byte{}
+	for _, k8sObject := range k8sObjects {
+		hash := k8sObject.GetAnnotations()[hashAnnotationName]
+		if hash == "" {
+			return ""
+		}
+		hashes[getOutputKind(k8sObject)+"/"+k8sObject.GetName()] = []byte(hash)
+	}
+	if len(hashes) == 1 {
+		for _, hash := range hashes {
+			return string(hash)
+		}
+	}
+	return vaultsecretutils.HashData(hashes)
+}
+
+// getRolloutWorkloads returns the workloads selected by the rollout target, a workload selected by name that doesn't exist is ignored.
+func (r *VaultSecretReconciler) getRolloutWorkloads(ctx context.Context, namespace string, rolloutTarget redhatcopv1alpha1.RolloutTarget) ([]client.Object, error) {
+	var workload client.Object
+	var workloadList client.ObjectList
+	switch rolloutTarget.Kind {
+	case "Deployment":
+		workload, workloadList = &appsv1.Deployment{}, &appsv1.DeploymentList{}
+	case "StatefulSet":
+		workload, workloadList = &appsv1.StatefulSet{}, &appsv1.StatefulSetList{}
+	case "DaemonSet":
+		workload, workloadList = &appsv1.DaemonSet{}, &appsv1.DaemonSetList{}
+	default:
+		return nil, fmt.Errorf("unsupported rollout target kind %s", rolloutTarget.Kind)
+	}
+
+	if rolloutTarget.Name != "" {
+		err := r.GetClient().Get(ctx, types.NamespacedName{Name: rolloutTarget.Name, Namespace: namespace}, workload)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []client.Object{workload}, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(rolloutTarget.Selector)
+	if err != nil {
+		return nil, err
+	}
+	err = r.GetClient().List(ctx, workloadList, &client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := []client.Object{}
+	switch list := workloadList.(type) {
+	case *appsv1.DeploymentList:
+		for i := range list.Items {
+			result = append(result, &list.Items[i])
+		}
+	case *appsv1.StatefulSetList:
+		for i := range list.Items {
+			result = append(result, &list.Items[i])
+		}
+	case *appsv1.DaemonSetList:
+		for i := range list.Items {
+			result = append(result, &list.Items[i])
+		}
+	}
+	return result, nil
+}
+
+func getPodTemplate(workload client.Object) *corev1.PodTemplateSpec {
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		return &w.Spec.Template
+	case *appsv1.StatefulSet:
+		return &w.Spec.Template
+	case *appsv1.DaemonSet:
+		return &w.Spec.Template
+	}
+	return &corev1.PodTemplateSpec{}
+}
+
+// shouldRenewLeases returns true when the refresh of the secret is due and it can be achieved by renewing the leases of the dynamic secrets.
+// A RefreshPeriod always forces the secrets to be read again.
+func (r *VaultSecretReconciler) shouldRenewLeases(instance *redhatcopv1alpha1.VaultSecret) bool {
+	if instance.Spec.RefreshPeriod != nil || !instance.HasRenewableLeases() {
+		return false
+	}
+	// a change of the resource will cause the secrets to be read again anyway
+	if instance.Spec.SyncOnResourceChange && instance.Status.SyncedResourceVersion != vaultsecretutils.GetResourceVersion(instance.ObjectMeta) {
+		return false
+	}
+	last := lastRefresh(instance)
+	if last == nil {
+		return false
+	}
+	duration, ok := r.calculateDuration(instance)
+	if !ok {
+		return false
+	}
+	return last.Add(duration).Before(time.Now())
+}
+
+// manageLeaseRenewal renews the leases of all the vault secret definitions.
+// If any of the leases cannot be renewed, the status is left untouched so that the secrets are read again from Vault.
+// When Vault grants less than the requested increment, the lease has reached its max TTL and is marked as no longer renewable.
+func (r *VaultSecretReconciler) manageLeaseRenewal(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret) error {
+
+	r.Log.V(1).Info("Renew VaultSecret leases", "namespacedName", toNamespacedName(instance))
+
+	definitionsStatus := make([]redhatcopv1alpha1.VaultSecretDefinitionStatus, len(instance.Status.VaultSecretDefinitionsStatus))
+
+	for idx, defstat := range instance.Status.VaultSecretDefinitionsStatus {
+		vaultSecretDefinition, ok := getVaultSecretDefinition(instance, defstat.Name)
+		if !ok {
+			return nil
+		}
+		ctx, err := r.prepareDefinitionContext(ctx, instance, vaultSecretDefinition)
+		if err != nil {
+			return err
+		}
+		renewedSecret, err := vaultutils.RenewLease(ctx, defstat.LeaseID, defstat.LeaseDuration)
+		if err != nil || renewedSecret == nil {
+			r.Log.Info("unable to renew lease, the secret will be read again from vault", "name", defstat.Name, "leaseID", defstat.LeaseID)
+			return nil
+		}
+		definitionsStatus[idx] = redhatcopv1alpha1.VaultSecretDefinitionStatus{
+			Name:          defstat.Name,
+			LeaseID:       defstat.LeaseID,
+			LeaseDuration: renewedSecret.LeaseDuration,
+			Renewable:     renewedSecret.Renewable && renewedSecret.LeaseDuration >= defstat.LeaseDuration,
+		}
+	}
 
 	now := metav1.NewTime(time.Now())
-	instance.Status.LastVaultSecretUpdate = &now
-	instance.Status.SyncedResourceVersion = vaultsecretutils.GetResourceVersion(instance.ObjectMeta)
+	instance.Status.LastLeaseRenewal = &now
 	instance.Status.VaultSecretDefinitionsStatus = definitionsStatus
 
 	return nil
 }
 
+// prepareDefinitionContext returns a context carrying the vault connection and an authenticated vault client for the vault secret definition.
+func (r *VaultSecretReconciler) prepareDefinitionContext(ctx context.Context, instance *redhatcopv1alpha1.VaultSecret, vaultSecretDefinition *redhatcopv1alpha1.VaultSecretDefinition) (context.Context, error) {
+	ctx = vaultutils.ContextWithVaultConnection(ctx, vaultSecretDefinition.GetVaultConnection())
+	kubeAuthConfiguration, err := vaultSecretDefinition.Authentication.WithInheritedNamespace(ctx, instance.Namespace)
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the Vault namespace", "instance", instance)
+		return nil, err
+	}
+	vaultClient, err := kubeAuthConfiguration.GetVaultClient(ctx, instance.Namespace)
+	if err != nil {
+		r.Log.Error(err, "unable to create vault client", "instance", instance)
+		return nil, err
+	}
+	return vaultutils.ContextWithVaultClient(ctx, vaultClient), nil
+}
+
+func getVaultSecretDefinition(instance *redhatcopv1alpha1.VaultSecret, name string) (*redhatcopv1alpha1.VaultSecretDefinition, bool) {
+	for i := range instance.Spec.VaultSecretDefinitions {
+		if instance.Spec.VaultSecretDefinitions[i].Name == name {
+			return &instance.Spec.VaultSecretDefinitions[i], true
+		}
+	}
+	return nil, false
+}
+
+// lastRefresh returns the last time the secret was refreshed, either by reading it from Vault or by renewing its leases.
+func lastRefresh(instance *redhatcopv1alpha1.VaultSecret) *metav1.Time {
+	if instance.Status.LastLeaseRenewal != nil && (instance.Status.LastVaultSecretUpdate == nil || instance.Status.LastLeaseRenewal.After(instance.Status.LastVaultSecretUpdate.Time)) {
+		return instance.Status.LastLeaseRenewal
+	}
+	return instance.Status.LastVaultSecretUpdate
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *VaultSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
@@ -433,17 +1074,75 @@ func (r *VaultSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		},
 	}
 
-	k8sSecretPredicate := predicate.Funcs{
+	k8sOutputPredicate := r.newOutputPredicate(r.isOwnedByController)
+	outputCopyPredicate := r.newOutputPredicate(isOutputCopy)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&redhatcopv1alpha1.VaultSecret{}, builder.WithPredicates(vaultSecretPredicate, vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		Owns(&corev1.Secret{}, builder.WithPredicates(k8sOutputPredicate)).
+		Owns(&corev1.ConfigMap{}, builder.WithPredicates(k8sOutputPredicate)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(mapOutputCopyToVaultSecret), builder.WithPredicates(outputCopyPredicate)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(mapOutputCopyToVaultSecret), builder.WithPredicates(outputCopyPredicate)).
+		Watches(&corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "Namespace",
+			},
+		}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			res := []reconcile.Request{}
+			ns := a.(*corev1.Namespace)
+			vsl, err := r.findVaultSecretsTargetingNamespace(ctx, ns)
+			if err != nil {
+				r.Log.Error(err, "unable to find applicable VaultSecrets for namespace", "namespace", ns.Name)
+				return []reconcile.Request{}
+			}
+			for _, vaultSecret := range vsl {
+				res = append(res, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      vaultSecret.GetName(),
+						Namespace: vaultSecret.GetNamespace(),
+					},
+				})
+			}
+			return res
+		})).
+		Watches(&redhatcopv1alpha1.VaultSecretGrant{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			res := []reconcile.Request{}
+			grant := a.(*redhatcopv1alpha1.VaultSecretGrant)
+			vsl := &redhatcopv1alpha1.VaultSecretList{}
+			err := r.GetClient().List(ctx, vsl, client.InNamespace(grant.Spec.SourceNamespace))
+			if err != nil {
+				r.Log.Error(err, "unable to retrieve the list of VaultSecrets", "namespace", grant.Spec.SourceNamespace)
+				return []reconcile.Request{}
+			}
+			for _, vaultSecret := range vsl.Items {
+				if vaultSecret.Spec.TargetNamespaces == nil && len(vaultSecret.Status.CopiedOutputs) == 0 {
+					continue
+				}
+				res = append(res, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      vaultSecret.GetName(),
+						Namespace: vaultSecret.GetNamespace(),
+					},
+				})
+			}
+			return res
+		})).
+		Complete(r)
+}
+
+// newOutputPredicate returns a predicate that triggers a reconcile when an output, or a copy of an output, is modified or deleted.
+func (r *VaultSecretReconciler) newOutputPredicate(isOutput func(obj client.Object) bool) predicate.Funcs {
+	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			newSecret, ok := e.ObjectNew.DeepCopyObject().(*corev1.Secret)
+			newData, ok := getOutputData(e.ObjectNew)
 			if !ok {
 				return false
 			}
 
-			if r.isOwnedSecretByController(newSecret) {
-				hash, ok := newSecret.Annotations[hashAnnotationName]
-				if !ok || hash != vaultsecretutils.HashData(newSecret.Data) {
-					r.Log.V(1).Info("Update Event - hash mismatch", "kind", secretKind, "namespacedName", toNamespacedName(e.ObjectNew))
+			if isOutput(e.ObjectNew) {
+				hash, ok := e.ObjectNew.GetAnnotations()[hashAnnotationName]
+				if !ok || hash != vaultsecretutils.HashData(newData) {
+					r.Log.V(1).Info("Update Event - hash mismatch", "kind", getOutputKind(e.ObjectNew), "namespacedName", toNamespacedName(e.ObjectNew))
 					return true
 				}
 			}
@@ -455,12 +1154,11 @@ func (r *VaultSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			//Useful for debugging
-			secret, ok := e.Object.DeepCopyObject().(*corev1.Secret)
-			if !ok {
+			if _, ok := getOutputData(e.Object); !ok {
 				return false
 			}
-			if r.isOwnedSecretByController(secret) {
-				r.Log.V(1).Info("Delete Event", "kind", secretKind, "namespacedName", toNamespacedName(e.Object))
+			if isOutput(e.Object) {
+				r.Log.V(1).Info("Delete Event", "kind", getOutputKind(e.Object), "namespacedName", toNamespacedName(e.Object))
 				return true
 			}
 			return false
@@ -469,15 +1167,30 @@ func (r *VaultSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			return false
 		},
 	}
+}
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&redhatcopv1alpha1.VaultSecret{}, builder.WithPredicates(vaultSecretPredicate, vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
-		Owns(&corev1.Secret{}, builder.WithPredicates(k8sSecretPredicate)).
-		Complete(r)
+// isOutputCopy returns whether the object is a copy of an output in a target namespace.
+func isOutputCopy(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()[copyOwnerAnnotationName]
+	return ok
+}
+
+// mapOutputCopyToVaultSecret returns the VaultSecret owning a copy of an output.
+func mapOutputCopyToVaultSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	namespace, name, found := strings.Cut(obj.GetAnnotations()[copyOwnerAnnotationName], "/")
+	if !found {
+		return []reconcile.Request{}
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}}
 }
 
-func (r *VaultSecretReconciler) isOwnedSecretByController(secret *corev1.Secret) bool {
-	for _, ownerRef := range secret.ObjectMeta.OwnerReferences {
+func (r *VaultSecretReconciler) isOwnedByController(obj client.Object) bool {
+	for _, ownerRef := range obj.GetOwnerReferences() {
 		if ownerRef.Kind == vaultSecretKind {
 			return true
 		}
@@ -0,0 +1,364 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/vault-config-operator/api/v1alpha1"
+	vaultutils "github.com/redhat-cop/vault-config-operator/api/v1alpha1/utils"
+	"github.com/redhat-cop/vault-config-operator/internal/controller/vaultresourcecontroller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	vaultTenantFieldOwner = "vault-config-operator"
+
+	// vaultTenantDeletionPollInterval is how often the deletion of the children of a VaultTenant is checked, on top of the watches on the children.
+	vaultTenantDeletionPollInterval = 5 * time.Second
+)
+
+// VaultTenantReconciler reconciles a VaultTenant object
+type VaultTenantReconciler struct {
+	vaultresourcecontroller.ReconcilerBase
+}
+
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=vaulttenants,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=vaulttenants/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=vaulttenants/finalizers,verbs=update
+//+kubebuilder:rbac:groups=redhatcop.redhat.io,resources=vaultresourcetemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.9.2/pkg/reconcile
+func (r *VaultTenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = log.FromContext(ctx)
+
+	// Fetch the instance
+	instance := &redhatcopv1alpha1.VaultTenant{}
+	err := r.GetClient().Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		if !controllerutil.ContainsFinalizer(instance, vaultutils.GetFinalizer(instance)) {
+			return reconcile.Result{}, nil
+		}
+		deleted, err := r.manageCleanUpLogic(ctx, instance)
+		if err != nil {
+			r.Log.Error(err, "unable to delete instance", "instance", instance)
+			return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
+		}
+		if !deleted {
+			return reconcile.Result{RequeueAfter: vaultTenantDeletionPollInterval}, nil
+		}
+		controllerutil.RemoveFinalizer(instance, vaultutils.GetFinalizer(instance))
+		err = r.GetClient().Update(ctx, instance)
+		if err != nil {
+			r.Log.Error(err, "unable to update instance", "instance", instance)
+			return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// the finalizer is added before any child is applied, so that the children are deleted in order even if the first reconcile fails
+	if !controllerutil.ContainsFinalizer(instance, vaultutils.GetFinalizer(instance)) {
+		controllerutil.AddFinalizer(instance, vaultutils.GetFinalizer(instance))
+		err = r.GetClient().Update(ctx, instance)
+		if err != nil {
+			r.Log.Error(err, "unable to add finalizer", "instance", instance)
+			return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
+		}
+	}
+
+	err = r.manageReconcileLogic(ctx, instance)
+	if err != nil {
+		r.Log.Error(err, "unable to complete reconcile logic", "instance", instance)
+	}
+	return vaultresourcecontroller.ManageOutcome(ctx, r.ReconcilerBase, instance, err)
+}
+
+// manageReconcileLogic renders the template of the tenant, applies the resulting resources, deletes the resources no longer rendered and aggregates the status of the children.
+func (r *VaultTenantReconciler) manageReconcileLogic(ctx context.Context, instance *redhatcopv1alpha1.VaultTenant) error {
+	resources, err := r.renderResources(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, resource := range resources {
+		err := r.applyChild(ctx, instance, resource)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Resources removed from the template are deleted, in reverse order of the previous rendering
+	for i := len(instance.Status.Children) - 1; i >= 0; i-- {
+		child := instance.Status.Children[i]
+		if slices.ContainsFunc(resources, func(resource *unstructured.Unstructured) bool {
+			return resource.GetKind() == child.Kind && resource.GetName() == child.Name
+		}) {
+			continue
+		}
+		_, err := r.deleteChild(ctx, instance, child)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	instance.Status.Children = r.aggregateChildren(ctx, instance, resources)
+	setChildrenReconciledCondition(instance)
+	return errors.Join(errs...)
+}
+
+// renderResources renders the VaultResourceTemplate of the tenant into the resources to apply.
+func (r *VaultTenantReconciler) renderResources(ctx context.Context, instance *redhatcopv1alpha1.VaultTenant) ([]*unstructured.Unstructured, error) {
+	vaultResourceTemplate := &redhatcopv1alpha1.VaultResourceTemplate{}
+	err := r.GetClient().Get(ctx, instance.GetTemplateReference(), vaultResourceTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve VaultResourceTemplate %s: %w", instance.GetTemplateReference(), err)
+	}
+	parameters, err := vaultResourceTemplate.ResolveParameters(instance.Spec.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	funcMap := vaultresourcecontroller.AdvancedTemplateFuncMap(r.GetRestConfig(), r.Log)
+	// lookup reads any resource with the credentials of the operator, which would disclose the resources of other namespaces
+	delete(funcMap, "lookup")
+	tpl, err := vaultresourcecontroller.ParseTemplate(vaultResourceTemplate.Name, vaultResourceTemplate.Spec.Template, funcMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the template of VaultResourceTemplate %s: %w", instance.GetTemplateReference(), err)
+	}
+	var b bytes.Buffer
+	err = tpl.Option("missingkey=error").Execute(&b, map[string]any{
+		"tenant": map[string]any{
+			"name":      instance.Name,
+			"namespace": instance.Namespace,
+		},
+		"parameters": parameters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to render the template of VaultResourceTemplate %s: %w", instance.GetTemplateReference(), err)
+	}
+	return instance.ParseResources(b.String())
+}
+
+// applyChild creates or updates a resource of the tenant with server-side apply. It refuses to take over a resource not created by the tenant.
+func (r *VaultTenantReconciler) applyChild(ctx context.Context, instance *redhatcopv1alpha1.VaultTenant, resource *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(resource.GroupVersionKind())
+	err := r.GetClient().Get(ctx, client.ObjectKeyFromObject(resource), existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && !metav1.IsControlledBy(existing, instance) {
+		return fmt.Errorf("%s %s already exists and is not managed by this VaultTenant", resource.GetKind(), resource.GetName())
+	}
+	err = controllerutil.SetControllerReference(instance, resource, r.GetScheme())
+	if err != nil {
+		return err
+	}
+	// without forcing ownership, the fields changed by another manager are reported as conflicts instead of being overwritten
+	err = r.GetClient().Apply(ctx, client.ApplyConfigurationFromUnstructured(resource), client.FieldOwner(vaultTenantFieldOwner))
+	if err != nil {
+		return fmt.Errorf("unable to apply %s %s: %w", resource.GetKind(), resource.GetName(), err)
+	}
+	return nil
+}
+
+// deleteChild deletes a resource of the tenant and returns whether it is gone. Resources not controlled by the tenant are left untouched.
+func (r *VaultTenantReconciler) deleteChild(ctx context.Context, instance *redhatcopv1alpha1.VaultTenant, child redhatcopv1alpha1.VaultTenantChild) (bool, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(redhatcopv1alpha1.GroupVersion.WithKind(child.Kind))
+	err := r.GetClient().Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: child.Name}, existing)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if !metav1.IsControlledBy(existing, instance) {
+		return true, nil
+	}
+	if existing.GetDeletionTimestamp().IsZero() {
+		err = r.GetClient().Delete(ctx, existing)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("unable to delete %s %s: %w", child.Kind, child.Name, err)
+		}
+	}
+	return false, nil
+}
+
+// manageCleanUpLogic deletes the children of the tenant one at a time, in reverse order of the template, so that a resource is only deleted once the resources depending on it are gone.
+// It returns whether all the children are gone.
+func (r *VaultTenantReconciler) manageCleanUpLogic(ctx context.Context, instance *redhatcopv1alpha1.VaultTenant) (bool, error) {
+	for i := len(instance.Status.Children) - 1; i >= 0; i-- {
+		deleted, err := r.deleteChild(ctx, instance, instance.Status.Children[i])
+		if err != nil || !deleted {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// aggregateChildren returns the status of the ReconcileSuccessful condition of each resource of the tenant.
+func (r *VaultTenantReconciler) aggregateChildren(ctx context.Context, instance *redhatcopv1alpha1.VaultTenant, resources []*unstructured.Unstructured) []redhatcopv1alpha1.VaultTenantChild {
+	children := []redhatcopv1alpha1.VaultTenantChild{}
+	for _, resource := range resources {
+		child := redhatcopv1alpha1.VaultTenantChild{
+			Kind:                resource.GetKind(),
+			Name:                resource.GetName(),
+			ReconcileSuccessful: metav1.ConditionUnknown,
+		}
+		obj, err := r.GetScheme().New(resource.GroupVersionKind())
+		if err != nil {
+			child.Message = err.Error()
+			children = append(children, child)
+			continue
+		}
+		object, ok := obj.(client.Object)
+		if !ok {
+			children = append(children, child)
+			continue
+		}
+		err = r.GetClient().Get(ctx, client.ObjectKeyFromObject(resource), object)
+		if err != nil {
+			child.Message = err.Error()
+			children = append(children, child)
+			continue
+		}
+		if conditionsAware, ok := object.(vaultutils.ConditionsAware); ok {
+			child.ReconcileSuccessful, child.Message = reconcileStatus(object.GetGeneration(), conditionsAware.GetConditions())
+		}
+		children = append(children, child)
+	}
+	return children
+}
+
+// reconcileStatus returns whether the last reconcile of a resource at the given generation succeeded, failed, or is not known yet.
+func reconcileStatus(generation int64, conditions []metav1.Condition) (metav1.ConditionStatus, string) {
+	successful := apimeta.FindStatusCondition(conditions, vaultresourcecontroller.ReconcileSuccessful)
+	if successful != nil && successful.Status == metav1.ConditionTrue && successful.ObservedGeneration == generation {
+		return metav1.ConditionTrue, ""
+	}
+	failed := apimeta.FindStatusCondition(conditions, vaultresourcecontroller.ReconcileFailed)
+	if failed != nil {
+		return metav1.ConditionFalse, failed.Message
+	}
+	return metav1.ConditionUnknown, ""
+}
+
+// setChildrenReconciledCondition sets the ChildrenReconciled condition from the status of the children.
+func setChildrenReconciledCondition(instance *redhatcopv1alpha1.VaultTenant) {
+	pending := []string{}
+	for _, child := range instance.Status.Children {
+		if child.ReconcileSuccessful != metav1.ConditionTrue {
+			pending = append(pending, child.Kind+"/"+child.Name)
+		}
+	}
+	condition := metav1.Condition{
+		Type:               redhatcopv1alpha1.ChildrenReconciled,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: instance.GetGeneration(),
+		Reason:             redhatcopv1alpha1.ChildrenReconciledReason,
+		Message:            fmt.Sprintf("%d resource(s) reconciled", len(instance.Status.Children)),
+	}
+	if len(pending) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = redhatcopv1alpha1.ChildrenNotReconciledReason
+		condition.Message = "not reconciled yet: " + strings.Join(pending, ", ")
+	}
+	apimeta.SetStatusCondition(&instance.Status.Conditions, condition)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VaultTenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&redhatcopv1alpha1.VaultTenant{}, builder.WithPredicates(vaultresourcecontroller.NewDefaultPeriodicReconcilePredicate())).
+		Watches(&redhatcopv1alpha1.VaultResourceTemplate{}, handler.EnqueueRequestsFromMapFunc(r.findTenantsOfTemplate))
+	// Any resource with conditions may be rendered by a template, so the tenants watch them all to aggregate their status
+	kinds := []string{}
+	for kind := range mgr.GetScheme().KnownTypes(redhatcopv1alpha1.GroupVersion) {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+	for _, kind := range kinds {
+		if kind == "VaultTenant" || kind == "VaultResourceTemplate" {
+			continue
+		}
+		obj, err := mgr.GetScheme().New(redhatcopv1alpha1.GroupVersion.WithKind(kind))
+		if err != nil {
+			return err
+		}
+		if _, ok := obj.(vaultutils.ConditionsAware); !ok {
+			continue
+		}
+		b = b.Owns(obj.(client.Object))
+	}
+	return b.Complete(r)
+}
+
+// findTenantsOfTemplate returns the tenants rendering the given template, so that they are reconciled when it changes.
+func (r *VaultTenantReconciler) findTenantsOfTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	res := []reconcile.Request{}
+	tl := &redhatcopv1alpha1.VaultTenantList{}
+	err := r.GetClient().List(ctx, tl, &client.ListOptions{Namespace: obj.GetNamespace()})
+	if err != nil {
+		r.Log.Error(err, "unable to retrieve the list of VaultTenants")
+		return []reconcile.Request{}
+	}
+	for i := range tl.Items {
+		if tl.Items[i].GetTemplateReference() == client.ObjectKeyFromObject(obj) {
+			res = append(res, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      tl.Items[i].GetName(),
+					Namespace: tl.Items[i].GetNamespace(),
+				},
+			})
+		}
+	}
+	return res
+}